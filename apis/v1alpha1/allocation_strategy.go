@@ -16,7 +16,7 @@ package v1alpha1
 
 type (
 	// OpenTelemetryTargetAllocatorAllocationStrategy represent which strategy to distribute target to each collector
-	// +kubebuilder:validation:Enum=least-weighted;consistent-hashing;per-node
+	// +kubebuilder:validation:Enum=least-weighted;consistent-hashing;per-node;load-aware;topology-aware
 	OpenTelemetryTargetAllocatorAllocationStrategy string
 )
 
@@ -29,4 +29,10 @@ const (
 
 	// OpenTelemetryTargetAllocatorAllocationStrategyPerNode targets will be assigned to the collector on the node they reside on (use only with daemon set).
 	OpenTelemetryTargetAllocatorAllocationStrategyPerNode OpenTelemetryTargetAllocatorAllocationStrategy = "per-node"
+
+	// OpenTelemetryTargetAllocatorAllocationStrategyLoadAware targets will be distributed to the collector with the lowest total target cost, as reported by collectors.
+	OpenTelemetryTargetAllocatorAllocationStrategyLoadAware OpenTelemetryTargetAllocatorAllocationStrategy = "load-aware"
+
+	// OpenTelemetryTargetAllocatorAllocationStrategyTopologyAware targets will be distributed to the collector with the least targets in the same zone, if there is any.
+	OpenTelemetryTargetAllocatorAllocationStrategyTopologyAware OpenTelemetryTargetAllocatorAllocationStrategy = "topology-aware"
 )
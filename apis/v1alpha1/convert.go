@@ -78,8 +78,9 @@ func tov1beta1(in OpenTelemetryCollector) (v1beta1.OpenTelemetryCollector, error
 				Replicas:       in.Status.Scale.Replicas,
 				StatusReplicas: in.Status.Scale.StatusReplicas,
 			},
-			Version: in.Status.Version,
-			Image:   in.Status.Image,
+			Version:    in.Status.Version,
+			Image:      in.Status.Image,
+			Conditions: copy.Status.Conditions,
 		},
 		Spec: v1beta1.OpenTelemetryCollectorSpec{
 			OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
@@ -173,6 +174,7 @@ func tov1beta1TA(in OpenTelemetryTargetAllocator) v1beta1.TargetAllocatorEmbedde
 		NodeSelector:       in.NodeSelector,
 		Resources:          in.Resources,
 		AllocationStrategy: tov1beta1TAAllocationStrategy(in.AllocationStrategy),
+		TopologyKey:        in.TopologyKey,
 		FilterStrategy:     tov1beta1TAFilterStrategy(in.FilterStrategy),
 		ServiceAccount:     in.ServiceAccount,
 		Image:              in.Image,
@@ -307,8 +309,9 @@ func tov1alpha1(in v1beta1.OpenTelemetryCollector) (*OpenTelemetryCollector, err
 				Replicas:       in.Status.Scale.Replicas,
 				StatusReplicas: in.Status.Scale.StatusReplicas,
 			},
-			Version: in.Status.Version,
-			Image:   in.Status.Image,
+			Version:    in.Status.Version,
+			Image:      in.Status.Image,
+			Conditions: copy.Status.Conditions,
 		},
 
 		Spec: OpenTelemetryCollectorSpec{
@@ -443,6 +446,7 @@ func tov1alpha1TA(in v1beta1.TargetAllocatorEmbedded) OpenTelemetryTargetAllocat
 		NodeSelector:       in.NodeSelector,
 		Resources:          in.Resources,
 		AllocationStrategy: tov1alpha1TAAllocationStrategy(in.AllocationStrategy),
+		TopologyKey:        in.TopologyKey,
 		FilterStrategy:     tov1alpha1TAFilterStrategy(in.FilterStrategy),
 		ServiceAccount:     in.ServiceAccount,
 		Image:              in.Image,
@@ -485,6 +489,10 @@ func tov1alpha1TAAllocationStrategy(strategy v1beta1.TargetAllocatorAllocationSt
 		return OpenTelemetryTargetAllocatorAllocationStrategyPerNode
 	case v1beta1.TargetAllocatorAllocationStrategyLeastWeighted:
 		return OpenTelemetryTargetAllocatorAllocationStrategyLeastWeighted
+	case v1beta1.TargetAllocatorAllocationStrategyLoadAware:
+		return OpenTelemetryTargetAllocatorAllocationStrategyLoadAware
+	case v1beta1.TargetAllocatorAllocationStrategyTopologyAware:
+		return OpenTelemetryTargetAllocatorAllocationStrategyTopologyAware
 	}
 	return ""
 }
@@ -504,6 +512,10 @@ func tov1beta1TAAllocationStrategy(strategy OpenTelemetryTargetAllocatorAllocati
 		return v1beta1.TargetAllocatorAllocationStrategyConsistentHashing
 	case OpenTelemetryTargetAllocatorAllocationStrategyLeastWeighted:
 		return v1beta1.TargetAllocatorAllocationStrategyLeastWeighted
+	case OpenTelemetryTargetAllocatorAllocationStrategyLoadAware:
+		return v1beta1.TargetAllocatorAllocationStrategyLoadAware
+	case OpenTelemetryTargetAllocatorAllocationStrategyTopologyAware:
+		return v1beta1.TargetAllocatorAllocationStrategyTopologyAware
 	}
 	return ""
 }
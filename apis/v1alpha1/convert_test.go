@@ -428,6 +428,7 @@ func createTA() OpenTelemetryTargetAllocator {
 			},
 		},
 		AllocationStrategy: OpenTelemetryTargetAllocatorAllocationStrategyConsistentHashing,
+		TopologyKey:        "topology.kubernetes.io/zone",
 		FilterStrategy:     "relabel-config",
 		ServiceAccount:     "serviceAccountName",
 		Image:              "custom_image",
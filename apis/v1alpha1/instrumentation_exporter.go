@@ -0,0 +1,160 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+type (
+	// ExporterProtocol is the transport protocol of the OTLP exporter.
+	// +kubebuilder:validation:Enum=grpc;http/protobuf
+	ExporterProtocol string
+)
+
+var nonEnvNameChars = regexp.MustCompile(`[^A-Z0-9_]`)
+
+const (
+	// ExporterProtocolGRPC represents OTLP over gRPC.
+	ExporterProtocolGRPC ExporterProtocol = "grpc"
+	// ExporterProtocolHTTPProtobuf represents OTLP over HTTP with binary protobuf payloads.
+	ExporterProtocolHTTPProtobuf ExporterProtocol = "http/protobuf"
+)
+
+// ExporterHeader is a header sent with the export requests, whose value is set inline or taken from a Secret.
+type ExporterHeader struct {
+	// Name of the header, e.g. Authorization.
+	// +required
+	Name string `json:"name"`
+
+	// Value of the header. It's percent-encoded in the OTEL_EXPORTER_OTLP_HEADERS env var.
+	// +optional
+	Value string `json:"value,omitempty"`
+
+	// SecretKeyRef selects the key of a Secret holding the value of the header. The Secret must exist in the
+	// namespace of the instrumented pods, and its value must already be percent-encoded where needed.
+	// +optional
+	SecretKeyRef *corev1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+}
+
+// EnvName returns the name of the env var the value of the header is read into from its Secret, its name in upper case
+// with the characters other than letters, digits and underscores replaced, prefixed with OTEL_EXPORTER_OTLP_HEADER_.
+func (h ExporterHeader) EnvName() string {
+	return "OTEL_EXPORTER_OTLP_HEADER_" + nonEnvNameChars.ReplaceAllString(strings.ToUpper(h.Name), "_")
+}
+
+// TLS defines the certificates of the OTLP exporter, mounted from a Secret and a ConfigMap in the namespace of the
+// instrumented pods, or already present on their filesystem.
+type TLS struct {
+	// SecretName is the name of the Secret holding the client certificate and key, and the CA certificate when
+	// ConfigMapName isn't set.
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+
+	// ConfigMapName is the name of the ConfigMap holding the CA certificate.
+	// +optional
+	ConfigMapName string `json:"configMapName,omitempty"`
+
+	// CA is the key of the CA certificate in the ConfigMap, or in the Secret when ConfigMapName isn't set, e.g.
+	// ca.crt, or the absolute path of a CA certificate on the filesystem of the pods, e.g.
+	// /var/run/secrets/kubernetes.io/serviceaccount/service-ca.crt.
+	// The value will be set in the OTEL_EXPORTER_OTLP_CERTIFICATE env var.
+	// +optional
+	CA string `json:"ca,omitempty"`
+
+	// Cert is the key of the client certificate in the Secret, e.g. tls.crt, or its absolute path.
+	// The value will be set in the OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE env var.
+	// +optional
+	Cert string `json:"cert,omitempty"`
+
+	// Key is the key of the client private key in the Secret, e.g. tls.key, or its absolute path.
+	// The value will be set in the OTEL_EXPORTER_OTLP_CLIENT_KEY env var.
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
+// validateExporter checks the headers set a value and don't share their env var, and the certificates can be found.
+// It warns about the settings which some of the auto-instrumentations don't support.
+func (s *InstrumentationSpec) validateExporter() ([]string, error) {
+	warnings := exporterWarnings(s.Exporter)
+	envNames := map[string]int{}
+	for i, header := range s.Exporter.Headers {
+		if header.Name == "" {
+			return warnings, fmt.Errorf("spec.exporter.headers[%d].name is required", i)
+		}
+		if j, ok := envNames[header.EnvName()]; ok {
+			return warnings, fmt.Errorf("spec.exporter.headers[%d] and spec.exporter.headers[%d] have the same name once normalized to %s", j, i, header.EnvName())
+		}
+		envNames[header.EnvName()] = i
+		if (header.Value == "") == (header.SecretKeyRef == nil) {
+			return warnings, fmt.Errorf("spec.exporter.headers[%d] should set exactly one of value and secretKeyRef", i)
+		}
+		if header.SecretKeyRef != nil && (header.SecretKeyRef.Name == "" || header.SecretKeyRef.Key == "") {
+			return warnings, fmt.Errorf("spec.exporter.headers[%d].secretKeyRef should set the name and key of the secret", i)
+		}
+	}
+
+	tls := s.Exporter.TLS
+	if tls == nil {
+		return warnings, nil
+	}
+	if (tls.Cert == "") != (tls.Key == "") {
+		return warnings, fmt.Errorf("spec.exporter.tls should set both cert and key, or none of them")
+	}
+	if tls.SecretName == "" && (isSecretKey(tls.Cert) || isSecretKey(tls.Key)) {
+		return warnings, fmt.Errorf("spec.exporter.tls.secretName is required to take the client certificate and key from a secret")
+	}
+	if tls.SecretName == "" && tls.ConfigMapName == "" && isSecretKey(tls.CA) {
+		return warnings, fmt.Errorf("spec.exporter.tls.configMapName or spec.exporter.tls.secretName is required to take the CA certificate from them")
+	}
+	return warnings, nil
+}
+
+// exporterWarnings returns the settings of the exporter which the Python auto-instrumentation image, which only ships
+// the http/protobuf exporter, and the Apache HTTPD and Nginx auto-instrumentations, which only read the endpoint,
+// don't support.
+func exporterWarnings(exporter Exporter) []string {
+	var warnings []string
+	if exporter.Protocol == ExporterProtocolGRPC {
+		warnings = append(warnings, "spec.exporter.protocol grpc isn't supported by the default Python auto-instrumentation image, which only ships the http/protobuf exporter")
+	}
+	var ignored []string
+	if exporter.TracesEndpoint != "" || exporter.MetricsEndpoint != "" || exporter.LogsEndpoint != "" {
+		ignored = append(ignored, "the per-signal endpoints")
+	}
+	if exporter.Protocol != "" {
+		ignored = append(ignored, "spec.exporter.protocol")
+	}
+	if len(exporter.Headers) > 0 {
+		ignored = append(ignored, "spec.exporter.headers")
+	}
+	if exporter.TLS != nil {
+		ignored = append(ignored, "spec.exporter.tls")
+	}
+	if len(ignored) > 0 {
+		warnings = append(warnings, fmt.Sprintf("the Apache HTTPD and Nginx auto-instrumentations only use spec.exporter.endpoint and ignore %s", strings.Join(ignored, ", ")))
+	}
+	return warnings
+}
+
+// isSecretKey returns whether the certificate is the key of a Secret or ConfigMap rather than a path.
+func isSecretKey(certificate string) bool {
+	return certificate != "" && !filepath.IsAbs(certificate)
+}
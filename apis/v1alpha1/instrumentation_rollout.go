@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+// InstrumentationRollout configures the restart of the workloads whose pods were injected with an outdated spec of
+// the instrumentation, since the changes of the instrumentation only apply to the pods created after them.
+type InstrumentationRollout struct {
+	// Restart enables the rolling restart of the Deployments, StatefulSets and DaemonSets whose pods were injected
+	// with an outdated spec of the instrumentation, i.e. before a change of its fields other than the rollout and the
+	// selector. Only the workloads of the namespace of the instrumentation are restarted, unless it's in one of the
+	// admin namespaces, and the StatefulSets and DaemonSets with the OnDelete update strategy and the paused
+	// Deployments are skipped. The restarts are paused while the instrumentation has the
+	// instrumentation.opentelemetry.io/rollout-paused annotation set to "true", and the workloads whose
+	// PodDisruptionBudgets don't allow disruptions are restarted later. The PodDisruptionBudgets are only checked
+	// before a restart: the pods are then replaced by the rolling update of the workload, which they don't limit.
+	// +optional
+	Restart bool `json:"restart,omitempty"`
+
+	// MaxConcurrent is the number of workloads restarted at the same time. The default is 1.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxConcurrent int32 `json:"maxConcurrent,omitempty"`
+}
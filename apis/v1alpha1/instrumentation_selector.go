@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type (
+	// InstrumentationLanguage represents the language of an auto-instrumentation, as in the
+	// instrumentation.opentelemetry.io/inject-<language> annotations.
+	// +kubebuilder:validation:Enum=java;nodejs;python;dotnet;go;apache-httpd;nginx;sdk
+	InstrumentationLanguage string
+)
+
+const (
+	// InstrumentationLanguageJava represents the Java auto-instrumentation.
+	InstrumentationLanguageJava InstrumentationLanguage = "java"
+	// InstrumentationLanguageNodeJS represents the NodeJS auto-instrumentation.
+	InstrumentationLanguageNodeJS InstrumentationLanguage = "nodejs"
+	// InstrumentationLanguagePython represents the Python auto-instrumentation.
+	InstrumentationLanguagePython InstrumentationLanguage = "python"
+	// InstrumentationLanguageDotNet represents the DotNet auto-instrumentation.
+	InstrumentationLanguageDotNet InstrumentationLanguage = "dotnet"
+	// InstrumentationLanguageGo represents the Go auto-instrumentation.
+	InstrumentationLanguageGo InstrumentationLanguage = "go"
+	// InstrumentationLanguageApacheHttpd represents the Apache HTTPD auto-instrumentation.
+	InstrumentationLanguageApacheHttpd InstrumentationLanguage = "apache-httpd"
+	// InstrumentationLanguageNginx represents the Nginx auto-instrumentation.
+	InstrumentationLanguageNginx InstrumentationLanguage = "nginx"
+	// InstrumentationLanguageSdk represents the injection of the SDK environment variables only.
+	InstrumentationLanguageSdk InstrumentationLanguage = "sdk"
+)
+
+// InstrumentationSelector selects the pods the instrumentation is injected into, without them being annotated.
+type InstrumentationSelector struct {
+	// Language is the auto-instrumentation injected into the selected pods.
+	// +required
+	Language InstrumentationLanguage `json:"language"`
+
+	// PodSelector selects the pods by their labels. An empty selector selects every pod of the selected namespaces.
+	// +optional
+	PodSelector *metav1.LabelSelector `json:"podSelector,omitempty"`
+
+	// NamespaceSelector selects the namespaces of the pods by their labels. It only selects other namespaces than the
+	// one of the instrumentation when the instrumentation is in the namespace of the operator or in one of its
+	// instrumentation admin namespaces. When it isn't set, only the pods of the namespace of the instrumentation are
+	// selected.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+}
+
+// validateSelector checks the selectors of the instrumentation can be parsed.
+func (s *InstrumentationSpec) validateSelector() error {
+	if s.Selector == nil {
+		return nil
+	}
+	if s.Selector.Language == "" {
+		return fmt.Errorf("spec.selector.language is required")
+	}
+	if _, err := metav1.LabelSelectorAsSelector(s.Selector.PodSelector); err != nil {
+		return fmt.Errorf("spec.selector.podSelector is not valid: %w", err)
+	}
+	if _, err := metav1.LabelSelectorAsSelector(s.Selector.NamespaceSelector); err != nil {
+		return fmt.Errorf("spec.selector.namespaceSelector is not valid: %w", err)
+	}
+	return nil
+}
@@ -76,6 +76,21 @@ type InstrumentationSpec struct {
 	// Nginx defines configuration for Nginx auto-instrumentation.
 	// +optional
 	Nginx Nginx `json:"nginx,omitempty"`
+
+	// Selector injects the instrumentation into the pods it selects, without them being annotated. The
+	// instrumentation.opentelemetry.io/inject-<language> annotations take precedence over it, and setting them to
+	// "false" opts pods out.
+	// +optional
+	Selector *InstrumentationSelector `json:"selector,omitempty"`
+
+	// LanguageDetection overrides the rules detecting the language of the containers of the pods annotated with
+	// instrumentation.opentelemetry.io/inject-auto. They're evaluated in order, before the built-in rules.
+	// +optional
+	LanguageDetection []LanguageDetectionRule `json:"languageDetection,omitempty"`
+
+	// Rollout restarts the workloads whose pods were injected with an outdated spec of the instrumentation.
+	// +optional
+	Rollout InstrumentationRollout `json:"rollout,omitempty"`
 }
 
 // Resource defines the configuration for the resource attributes, as defined by the OpenTelemetry specification.
@@ -96,6 +111,34 @@ type Exporter struct {
 	// Endpoint is address of the collector with OTLP endpoint.
 	// +optional
 	Endpoint string `json:"endpoint,omitempty"`
+
+	// TracesEndpoint is the address of the OTLP endpoint the traces are exported to, when it differs from Endpoint.
+	// The value will be set in the OTEL_EXPORTER_OTLP_TRACES_ENDPOINT env var.
+	// +optional
+	TracesEndpoint string `json:"tracesEndpoint,omitempty"`
+
+	// MetricsEndpoint is the address of the OTLP endpoint the metrics are exported to, when it differs from Endpoint.
+	// The value will be set in the OTEL_EXPORTER_OTLP_METRICS_ENDPOINT env var.
+	// +optional
+	MetricsEndpoint string `json:"metricsEndpoint,omitempty"`
+
+	// LogsEndpoint is the address of the OTLP endpoint the logs are exported to, when it differs from Endpoint.
+	// The value will be set in the OTEL_EXPORTER_OTLP_LOGS_ENDPOINT env var.
+	// +optional
+	LogsEndpoint string `json:"logsEndpoint,omitempty"`
+
+	// Protocol is the transport protocol of the OTLP exporter, grpc or http/protobuf.
+	// The value will be set in the OTEL_EXPORTER_OTLP_PROTOCOL env var.
+	// +optional
+	Protocol ExporterProtocol `json:"protocol,omitempty"`
+
+	// Headers are sent with every export request. Their values will be set in the OTEL_EXPORTER_OTLP_HEADERS env var.
+	// +optional
+	Headers []ExporterHeader `json:"headers,omitempty"`
+
+	// TLS defines the certificates the OTLP exporter uses to connect to the endpoint.
+	// +optional
+	TLS *TLS `json:"tls,omitempty"`
 }
 
 // Sampler defines sampling configuration.
@@ -300,6 +343,61 @@ type Nginx struct {
 
 // InstrumentationStatus defines status of the instrumentation.
 type InstrumentationStatus struct {
+	// Workloads are the workloads whose pods the instrumentation was injected into, or failed to be injected into.
+	// At most 100 of them are listed, the ones with failures first.
+	// +optional
+	// +listType=atomic
+	// +kubebuilder:validation:MaxItems=100
+	Workloads []InstrumentedWorkload `json:"workloads,omitempty"`
+
+	// WorkloadCount is the number of workloads whose pods the instrumentation was injected into, or failed to be
+	// injected into, including the ones Workloads doesn't list.
+	// +optional
+	WorkloadCount int32 `json:"workloadCount,omitempty"`
+}
+
+// InstrumentedWorkload is a workload whose pods the instrumentation was injected into.
+type InstrumentedWorkload struct {
+	// Kind of the workload owning the pods, e.g. Deployment, StatefulSet or DaemonSet. Pods without owner are
+	// reported as Pod.
+	Kind string `json:"kind"`
+
+	// Name of the workload.
+	Name string `json:"name"`
+
+	// Namespace of the workload.
+	Namespace string `json:"namespace"`
+
+	// Languages are the auto-instrumentations injected into the pods of the workload.
+	// +optional
+	// +listType=atomic
+	Languages []InstrumentedLanguage `json:"languages,omitempty"`
+
+	// Pods is the number of pods of the workload the instrumentation was injected into.
+	Pods int32 `json:"pods"`
+
+	// Failures is the number of pods of the workload the instrumentation failed to be injected into.
+	// +optional
+	Failures int32 `json:"failures,omitempty"`
+
+	// LastError is the error of the most recent pod of the workload the instrumentation failed to be injected into.
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+}
+
+// InstrumentedLanguage is an auto-instrumentation injected into the containers of the pods of a workload.
+type InstrumentedLanguage struct {
+	// Language of the auto-instrumentation.
+	Language InstrumentationLanguage `json:"language"`
+
+	// Image of the init container the auto-instrumentation was copied from, which carries the agent version.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Containers are the instrumented containers.
+	// +optional
+	// +listType=atomic
+	Containers []string `json:"containers,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -240,6 +240,17 @@ func (w InstrumentationWebhook) validate(r *Instrumentation) (admission.Warnings
 	default:
 		return warnings, fmt.Errorf("spec.sampler.type is not valid: %s", r.Spec.Sampler.Type)
 	}
+	if err := r.Spec.validateSelector(); err != nil {
+		return warnings, err
+	}
+	if err := r.Spec.validateLanguageDetection(); err != nil {
+		return warnings, err
+	}
+	exporterWarnings, err := r.Spec.validateExporter()
+	warnings = append(warnings, exporterWarnings...)
+	if err != nil {
+		return warnings, err
+	}
 	return warnings, nil
 }
 
@@ -19,6 +19,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
@@ -43,6 +45,7 @@ func TestInstrumentationDefaultingWebhook(t *testing.T) {
 	assert.Equal(t, "dotnet-img:1", inst.Spec.DotNet.Image)
 	assert.Equal(t, "apache-httpd-img:1", inst.Spec.ApacheHttpd.Image)
 	assert.Equal(t, "nginx-img:1", inst.Spec.Nginx.Image)
+	assert.Zero(t, inst.Spec.Rollout.MaxConcurrent, "maxConcurrent isn't written, the rollout falls back to restarting one workload at a time")
 }
 
 func TestInstrumentationValidatingWebhook(t *testing.T) {
@@ -113,6 +116,184 @@ func TestInstrumentationValidatingWebhook(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "selector without language",
+			err:  "spec.selector.language is required",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type: ParentBasedAlwaysOn,
+					},
+					Selector: &InstrumentationSelector{},
+				},
+			},
+		},
+		{
+			name: "selector with invalid pod selector",
+			err:  "spec.selector.podSelector is not valid",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type: ParentBasedAlwaysOn,
+					},
+					Selector: &InstrumentationSelector{
+						Language: InstrumentationLanguageJava,
+						PodSelector: &metav1.LabelSelector{
+							MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: "Matches"}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "selector",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type: ParentBasedAlwaysOn,
+					},
+					Selector: &InstrumentationSelector{
+						Language:          InstrumentationLanguageJava,
+						NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}},
+					},
+				},
+			},
+		},
+		{
+			name: "language detection rule without language",
+			err:  "spec.languageDetection[0].language is required",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type: ParentBasedAlwaysOn,
+					},
+					LanguageDetection: []LanguageDetectionRule{{Image: "openjdk"}},
+				},
+			},
+		},
+		{
+			name: "language detection rule without attributes",
+			err:  "spec.languageDetection[0] should set at least one of image, command and env",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type: ParentBasedAlwaysOn,
+					},
+					LanguageDetection: []LanguageDetectionRule{{Language: InstrumentationLanguageJava}},
+				},
+			},
+		},
+		{
+			name: "language detection rule with invalid image",
+			err:  "spec.languageDetection[1].image is not a valid regular expression",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type: ParentBasedAlwaysOn,
+					},
+					LanguageDetection: []LanguageDetectionRule{
+						{Language: InstrumentationLanguageJava, Env: "JAVA_HOME"},
+						{Language: InstrumentationLanguagePython, Image: "(python"},
+					},
+				},
+			},
+		},
+		{
+			name: "language detection",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type: ParentBasedAlwaysOn,
+					},
+					LanguageDetection: []LanguageDetectionRule{
+						{Language: InstrumentationLanguagePython, Image: "(^|/)ml-worker(:|$)", Command: "^celery$"},
+					},
+				},
+			},
+		},
+		{
+			name:     "exporter header without value",
+			err:      "spec.exporter.headers[0] should set exactly one of value and secretKeyRef",
+			warnings: []string{"the Apache HTTPD and Nginx auto-instrumentations only use spec.exporter.endpoint and ignore spec.exporter.headers"},
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type: ParentBasedAlwaysOn,
+					},
+					Exporter: Exporter{Headers: []ExporterHeader{{Name: "Authorization"}}},
+				},
+			},
+		},
+		{
+			name:     "exporter client certificate without key",
+			err:      "spec.exporter.tls should set both cert and key, or none of them",
+			warnings: []string{"the Apache HTTPD and Nginx auto-instrumentations only use spec.exporter.endpoint and ignore spec.exporter.tls"},
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type: ParentBasedAlwaysOn,
+					},
+					Exporter: Exporter{TLS: &TLS{SecretName: "gateway-client", Cert: "tls.crt"}},
+				},
+			},
+		},
+		{
+			name:     "exporter CA without configmap or secret",
+			err:      "spec.exporter.tls.configMapName or spec.exporter.tls.secretName is required to take the CA certificate from them",
+			warnings: []string{"the Apache HTTPD and Nginx auto-instrumentations only use spec.exporter.endpoint and ignore spec.exporter.tls"},
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type: ParentBasedAlwaysOn,
+					},
+					Exporter: Exporter{TLS: &TLS{CA: "ca.crt"}},
+				},
+			},
+		},
+		{
+			name:     "exporter headers with the same env var",
+			err:      "spec.exporter.headers[0] and spec.exporter.headers[1] have the same name once normalized to OTEL_EXPORTER_OTLP_HEADER_X_TENANT",
+			warnings: []string{"the Apache HTTPD and Nginx auto-instrumentations only use spec.exporter.endpoint and ignore spec.exporter.headers"},
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type: ParentBasedAlwaysOn,
+					},
+					Exporter: Exporter{Headers: []ExporterHeader{{Name: "X-Tenant", Value: "payments"}, {Name: "x_tenant", Value: "ledger"}}},
+				},
+			},
+		},
+		{
+			name: "exporter",
+			warnings: []string{
+				"spec.exporter.protocol grpc isn't supported by the default Python auto-instrumentation image, which only ships the http/protobuf exporter",
+				"the Apache HTTPD and Nginx auto-instrumentations only use spec.exporter.endpoint and ignore spec.exporter.protocol, spec.exporter.headers, spec.exporter.tls",
+			},
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type: ParentBasedAlwaysOn,
+					},
+					Exporter: Exporter{
+						Endpoint: "https://gateway:4317",
+						Protocol: ExporterProtocolGRPC,
+						Headers: []ExporterHeader{
+							{Name: "X-Tenant", Value: "payments"},
+							{Name: "Authorization", SecretKeyRef: &corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "gateway-token"},
+								Key:                  "authorization",
+							}},
+						},
+						TLS: &TLS{
+							SecretName: "gateway-client",
+							CA:         "/var/run/secrets/kubernetes.io/serviceaccount/service-ca.crt",
+							Cert:       "tls.crt",
+							Key:        "tls.key",
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// LanguageDetectionRule detects the language of the containers of the pods annotated with
+// instrumentation.opentelemetry.io/inject-auto. A rule matches a container when all the attributes it sets match.
+type LanguageDetectionRule struct {
+	// Language detected for the containers the rule matches.
+	// +required
+	Language InstrumentationLanguage `json:"language"`
+
+	// Image is a regular expression matched against the image of the container, e.g. (^|/)openjdk(:|@|$).
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Command is a regular expression matched against the name of the executable the container runs, from its
+	// command, or from its args when the command isn't set. The script of sh -c and bash -c is looked into.
+	// +optional
+	Command string `json:"command,omitempty"`
+
+	// Env is the name of an environment variable the container declares, e.g. JAVA_HOME.
+	// +optional
+	Env string `json:"env,omitempty"`
+}
+
+// validateLanguageDetection checks the language detection rules set a language and valid regular expressions.
+func (s *InstrumentationSpec) validateLanguageDetection() error {
+	for i, rule := range s.LanguageDetection {
+		if rule.Language == "" {
+			return fmt.Errorf("spec.languageDetection[%d].language is required", i)
+		}
+		if rule.Image == "" && rule.Command == "" && rule.Env == "" {
+			return fmt.Errorf("spec.languageDetection[%d] should set at least one of image, command and env", i)
+		}
+		if _, err := regexp.Compile(rule.Image); err != nil {
+			return fmt.Errorf("spec.languageDetection[%d].image is not a valid regular expression: %w", i, err)
+		}
+		if _, err := regexp.Compile(rule.Command); err != nil {
+			return fmt.Errorf("spec.languageDetection[%d].command is not a valid regular expression: %w", i, err)
+		}
+	}
+	return nil
+}
@@ -315,12 +315,16 @@ type OpenTelemetryTargetAllocator struct {
 	// +optional
 	Resources v1.ResourceRequirements `json:"resources,omitempty"`
 	// AllocationStrategy determines which strategy the target allocator should use for allocation.
-	// The current options are least-weighted, consistent-hashing and per-node. The default is
+	// The current options are least-weighted, consistent-hashing, per-node, load-aware and topology-aware. The default is
 	// consistent-hashing.
 	// WARNING: The per-node strategy currently ignores targets without a Node, like control plane components.
 	// +optional
 	// +kubebuilder:default:=consistent-hashing
 	AllocationStrategy OpenTelemetryTargetAllocatorAllocationStrategy `json:"allocationStrategy,omitempty"`
+	// TopologyKey is the node label whose values the topology-aware strategy keeps targets within, assigning them to
+	// collectors on nodes with the same value. The default is topology.kubernetes.io/zone.
+	// +optional
+	TopologyKey string `json:"topologyKey,omitempty"`
 	// FilterStrategy determines how to filter targets before allocating them among the collectors.
 	// The only current option is relabel-config (drops targets based on prom relabel_config).
 	// The default is relabel-config.
@@ -447,6 +451,14 @@ type OpenTelemetryCollectorStatus struct {
 	// +optional
 	// Deprecated: use "OpenTelemetryCollector.Status.Scale.Replicas" instead.
 	Replicas int32 `json:"replicas,omitempty"`
+
+	// Conditions represent the latest available observations of the OpenTelemetryCollector's state.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +patchStrategy=merge
+	// +patchMergeKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
 }
 
 // +kubebuilder:deprecatedversion:warning="OpenTelemetryCollector v1alpha1 is deprecated. Migrate to v1beta1."
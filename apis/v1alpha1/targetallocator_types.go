@@ -21,11 +21,13 @@ import (
 )
 
 func init() {
-	v1beta1.SchemeBuilder.Register(&TargetAllocator{}, &TargetAllocatorList{})
+	SchemeBuilder.Register(&TargetAllocator{}, &TargetAllocatorList{})
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+// +operator-sdk:csv:customresourcedefinitions:displayName="Target Allocator"
+// +operator-sdk:csv:customresourcedefinitions:resources={{Pod,v1},{Deployment,apps/v1},{ConfigMaps,v1},{Service,v1}}
 
 // TargetAllocator is the Schema for the targetallocators API.
 type TargetAllocator struct {
@@ -42,7 +44,7 @@ type TargetAllocator struct {
 type TargetAllocatorList struct {
 	metav1.TypeMeta `json:",inline"`
 	metav1.ListMeta `json:"metadata,omitempty"`
-	Items           []v1beta1.OpenTelemetryCollector `json:"items"`
+	Items           []TargetAllocator `json:"items"`
 }
 
 // TargetAllocatorStatus defines the observed state of Target Allocator.
@@ -60,13 +62,20 @@ type TargetAllocatorStatus struct {
 type TargetAllocatorSpec struct {
 	// Common defines fields that are common to all OpenTelemetry CRD workloads.
 	v1beta1.OpenTelemetryCommonFields `json:",inline"`
+	// CollectorSelector is the selector for Collector Pods the target allocator will allocate targets to.
+	// +optional
+	CollectorSelector metav1.LabelSelector `json:"collectorSelector,omitempty"`
 	// AllocationStrategy determines which strategy the target allocator should use for allocation.
-	// The current options are least-weighted, consistent-hashing and per-node. The default is
+	// The current options are least-weighted, consistent-hashing, per-node, load-aware and topology-aware. The default is
 	// consistent-hashing.
 	// WARNING: The per-node strategy currently ignores targets without a Node, like control plane components.
 	// +optional
 	// +kubebuilder:default:=consistent-hashing
 	AllocationStrategy v1beta1.TargetAllocatorAllocationStrategy `json:"allocationStrategy,omitempty"`
+	// TopologyKey is the node label whose values the topology-aware strategy keeps targets within, assigning them to
+	// collectors on nodes with the same value. The default is topology.kubernetes.io/zone.
+	// +optional
+	TopologyKey string `json:"topologyKey,omitempty"`
 	// FilterStrategy determines how to filter targets before allocating them among the collectors.
 	// The only current option is relabel-config (drops targets based on prom relabel_config).
 	// The default is relabel-config.
@@ -89,4 +98,10 @@ type TargetAllocatorSpec struct {
 	// +kubebuilder:validation:Optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Observability"
 	Observability v1beta1.ObservabilitySpec `json:"observability,omitempty"`
+	// Liveness config for the target allocator container, except the probe handler which always targets /livez.
+	// +optional
+	LivenessProbe *v1beta1.Probe `json:"livenessProbe,omitempty"`
+	// Readiness config for the target allocator container, except the probe handler which always targets /readyz.
+	// +optional
+	ReadinessProbe *v1beta1.Probe `json:"readinessProbe,omitempty"`
 }
@@ -156,6 +156,18 @@ func (in *DotNet) DeepCopy() *DotNet {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Exporter) DeepCopyInto(out *Exporter) {
 	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]ExporterHeader, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(TLS)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Exporter.
@@ -168,6 +180,26 @@ func (in *Exporter) DeepCopy() *Exporter {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExporterHeader) DeepCopyInto(out *ExporterHeader) {
+	*out = *in
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExporterHeader.
+func (in *ExporterHeader) DeepCopy() *ExporterHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(ExporterHeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Extensions) DeepCopyInto(out *Extensions) {
 	*out = *in
@@ -249,7 +281,7 @@ func (in *Ingress) DeepCopy() *Ingress {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Instrumentation) DeepCopyInto(out *Instrumentation) {
 	*out = *in
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	out.TypeMeta = in.TypeMeta
 	in.Spec.DeepCopyInto(&out.Spec)
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -305,10 +337,50 @@ func (in *InstrumentationList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstrumentationRollout) DeepCopyInto(out *InstrumentationRollout) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstrumentationRollout.
+func (in *InstrumentationRollout) DeepCopy() *InstrumentationRollout {
+	if in == nil {
+		return nil
+	}
+	out := new(InstrumentationRollout)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstrumentationSelector) DeepCopyInto(out *InstrumentationSelector) {
+	*out = *in
+	if in.PodSelector != nil {
+		in, out := &in.PodSelector, &out.PodSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstrumentationSelector.
+func (in *InstrumentationSelector) DeepCopy() *InstrumentationSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(InstrumentationSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InstrumentationSpec) DeepCopyInto(out *InstrumentationSpec) {
 	*out = *in
-	out.Exporter = in.Exporter
+	in.Exporter.DeepCopyInto(&out.Exporter)
 	in.Resource.DeepCopyInto(&out.Resource)
 	if in.Propagators != nil {
 		in, out := &in.Propagators, &out.Propagators
@@ -330,6 +402,17 @@ func (in *InstrumentationSpec) DeepCopyInto(out *InstrumentationSpec) {
 	in.Go.DeepCopyInto(&out.Go)
 	in.ApacheHttpd.DeepCopyInto(&out.ApacheHttpd)
 	in.Nginx.DeepCopyInto(&out.Nginx)
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(InstrumentationSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LanguageDetection != nil {
+		in, out := &in.LanguageDetection, &out.LanguageDetection
+		*out = make([]LanguageDetectionRule, len(*in))
+		copy(*out, *in)
+	}
+	out.Rollout = in.Rollout
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstrumentationSpec.
@@ -345,6 +428,13 @@ func (in *InstrumentationSpec) DeepCopy() *InstrumentationSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InstrumentationStatus) DeepCopyInto(out *InstrumentationStatus) {
 	*out = *in
+	if in.Workloads != nil {
+		in, out := &in.Workloads, &out.Workloads
+		*out = make([]InstrumentedWorkload, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstrumentationStatus.
@@ -357,6 +447,48 @@ func (in *InstrumentationStatus) DeepCopy() *InstrumentationStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstrumentedLanguage) DeepCopyInto(out *InstrumentedLanguage) {
+	*out = *in
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstrumentedLanguage.
+func (in *InstrumentedLanguage) DeepCopy() *InstrumentedLanguage {
+	if in == nil {
+		return nil
+	}
+	out := new(InstrumentedLanguage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstrumentedWorkload) DeepCopyInto(out *InstrumentedWorkload) {
+	*out = *in
+	if in.Languages != nil {
+		in, out := &in.Languages, &out.Languages
+		*out = make([]InstrumentedLanguage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstrumentedWorkload.
+func (in *InstrumentedWorkload) DeepCopy() *InstrumentedWorkload {
+	if in == nil {
+		return nil
+	}
+	out := new(InstrumentedWorkload)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Java) DeepCopyInto(out *Java) {
 	*out = *in
@@ -410,6 +542,21 @@ func (in *MetricSpec) DeepCopy() *MetricSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LanguageDetectionRule) DeepCopyInto(out *LanguageDetectionRule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LanguageDetectionRule.
+func (in *LanguageDetectionRule) DeepCopy() *LanguageDetectionRule {
+	if in == nil {
+		return nil
+	}
+	out := new(LanguageDetectionRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MetricsConfigSpec) DeepCopyInto(out *MetricsConfigSpec) {
 	*out = *in
@@ -962,6 +1109,13 @@ func (in *OpenTelemetryCollectorStatus) DeepCopyInto(out *OpenTelemetryCollector
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenTelemetryCollectorStatus.
@@ -1245,6 +1399,21 @@ func (in *ScaleSubresourceStatus) DeepCopy() *ScaleSubresourceStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLS) DeepCopyInto(out *TLS) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLS.
+func (in *TLS) DeepCopy() *TLS {
+	if in == nil {
+		return nil
+	}
+	out := new(TLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TargetAllocator) DeepCopyInto(out *TargetAllocator) {
 	*out = *in
@@ -1279,7 +1448,7 @@ func (in *TargetAllocatorList) DeepCopyInto(out *TargetAllocatorList) {
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]v1beta1.OpenTelemetryCollector, len(*in))
+		*out = make([]TargetAllocator, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1308,6 +1477,7 @@ func (in *TargetAllocatorList) DeepCopyObject() runtime.Object {
 func (in *TargetAllocatorSpec) DeepCopyInto(out *TargetAllocatorSpec) {
 	*out = *in
 	in.OpenTelemetryCommonFields.DeepCopyInto(&out.OpenTelemetryCommonFields)
+	in.CollectorSelector.DeepCopyInto(&out.CollectorSelector)
 	if in.ScrapeConfigs != nil {
 		in, out := &in.ScrapeConfigs, &out.ScrapeConfigs
 		*out = make([]v1beta1.AnyConfig, len(*in))
@@ -1317,6 +1487,16 @@ func (in *TargetAllocatorSpec) DeepCopyInto(out *TargetAllocatorSpec) {
 	}
 	in.PrometheusCR.DeepCopyInto(&out.PrometheusCR)
 	out.Observability = in.Observability
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(v1beta1.Probe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadinessProbe != nil {
+		in, out := &in.ReadinessProbe, &out.ReadinessProbe
+		*out = new(v1beta1.Probe)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetAllocatorSpec.
@@ -30,7 +30,9 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	ta "github.com/open-telemetry/opentelemetry-operator/internal/manifests/targetallocator/adapters"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
 	"github.com/open-telemetry/opentelemetry-operator/internal/rbac"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
 
 var (
@@ -64,6 +66,23 @@ var (
 			Verbs:           []string{"get"},
 		},
 	}
+	// targetAllocatorProbePolicyRules are the policy rules required to discover the targets of Probe resources.
+	targetAllocatorProbePolicyRules = []*rbacv1.PolicyRule{
+		{
+			APIGroups: []string{"monitoring.coreos.com"},
+			Resources: []string{"probes"},
+			Verbs:     []string{"*"},
+		},
+	}
+	// targetAllocatorScrapeConfigPolicyRules are the policy rules required to discover the targets of ScrapeConfig
+	// resources.
+	targetAllocatorScrapeConfigPolicyRules = []*rbacv1.PolicyRule{
+		{
+			APIGroups: []string{"monitoring.coreos.com"},
+			Resources: []string{"scrapeconfigs"},
+			Verbs:     []string{"*"},
+		},
+	}
 )
 
 // +kubebuilder:webhook:path=/mutate-opentelemetry-io-v1beta1-opentelemetrycollector,mutating=true,failurePolicy=fail,groups=opentelemetry.io,resources=opentelemetrycollectors,verbs=create;update,versions=v1beta1,name=mopentelemetrycollectorbeta.kb.io,sideEffects=none,admissionReviewVersions=v1
@@ -99,9 +118,9 @@ func (c CollectorWebhook) Default(_ context.Context, obj runtime.Object) error {
 	}
 
 	// We can default to one because dependent objects Deployment and HorizontalPodAutoScaler
-	// default to 1 as well.
+	// default to 1 as well. Jobs have their own parallelism instead.
 	one := int32(1)
-	if otelcol.Spec.Replicas == nil {
+	if otelcol.Spec.Replicas == nil && !otelcol.Spec.Mode.runsToCompletion() {
 		otelcol.Spec.Replicas = &one
 	}
 	if otelcol.Spec.TargetAllocator.Enabled && otelcol.Spec.TargetAllocator.Replicas == nil {
@@ -123,7 +142,7 @@ func (c CollectorWebhook) Default(_ context.Context, obj runtime.Object) error {
 	// which will work even if there is just one replica,
 	// not blocking node drains but preventing out-of-the-box
 	// from disruption generated by them with replicas > 1
-	if otelcol.Spec.PodDisruptionBudget == nil {
+	if otelcol.Spec.PodDisruptionBudget == nil && !otelcol.Spec.Mode.runsToCompletion() {
 		otelcol.Spec.PodDisruptionBudget = &PodDisruptionBudgetSpec{
 			MaxUnavailable: &intstr.IntOrString{
 				Type:   intstr.Int,
@@ -139,6 +158,8 @@ func (c CollectorWebhook) Default(_ context.Context, obj runtime.Object) error {
 	// out-of-the-box from disruption generated by them with replicas > 1
 	if otelcol.Spec.TargetAllocator.Enabled &&
 		otelcol.Spec.TargetAllocator.AllocationStrategy != TargetAllocatorAllocationStrategyLeastWeighted &&
+		otelcol.Spec.TargetAllocator.AllocationStrategy != TargetAllocatorAllocationStrategyLoadAware &&
+		otelcol.Spec.TargetAllocator.AllocationStrategy != TargetAllocatorAllocationStrategyTopologyAware &&
 		otelcol.Spec.TargetAllocator.PodDisruptionBudget == nil {
 		otelcol.Spec.TargetAllocator.PodDisruptionBudget = &PodDisruptionBudgetSpec{
 			MaxUnavailable: &intstr.IntOrString{
@@ -228,6 +249,39 @@ func (c CollectorWebhook) validate(ctx context.Context, r *OpenTelemetryCollecto
 		warnings = append(warnings, fmt.Sprintf("Collector config spec.config has null objects: %s. For compatibility with other tooling, such as kustomize and kubectl edit, it is recommended to use empty objects e.g. batch: {}.", strings.Join(nullObjects, ", ")))
 	}
 
+	// validate the pipeline graph, which the collector otherwise only rejects once it starts. With overlays, the
+	// configuration of the spec is only a part of the configuration, the controller validates the merged configuration
+	// and reports it in the ConfigValid condition.
+	if r.Spec.BaseConfig == nil && len(r.Spec.ConfigPatches) == 0 {
+		pipelineWarnings, pipelineErrs := r.Spec.Config.ValidatePipelines()
+		warnings = append(warnings, pipelineWarnings...)
+		if len(pipelineErrs) > 0 {
+			return warnings, fmt.Errorf("the OpenTelemetry Collector configuration is incorrect: %w", pipelineErrs.ToAggregate())
+		}
+	}
+
+	// validate the configuration overlays
+	if base := r.Spec.BaseConfig; base != nil {
+		if (base.CollectorName == "") == (base.ConfigMap == nil) {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec baseConfig configuration is incorrect, exactly one of collectorName and configMap must be set")
+		}
+		if base.CollectorName == r.Name {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec baseConfig configuration is incorrect, a collector can't be its own base")
+		}
+	}
+	for i, patch := range r.Spec.ConfigPatches {
+		if _, _, err := patch.decode(); err != nil {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec configPatches[%d] is incorrect: %w", i, err)
+		}
+	}
+
+	// validate the configuration references
+	referenceWarnings, referenceErr := r.Spec.validateConfigReferences()
+	warnings = append(warnings, referenceWarnings...)
+	if referenceErr != nil {
+		return warnings, referenceErr
+	}
+
 	// validate volumeClaimTemplates
 	if r.Spec.Mode != ModeStatefulSet && len(r.Spec.VolumeClaimTemplates) > 0 {
 		return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'volumeClaimTemplates'", r.Spec.Mode)
@@ -252,6 +306,11 @@ func (c CollectorWebhook) validate(ctx context.Context, r *OpenTelemetryCollecto
 		return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'AdditionalContainers'", r.Spec.Mode)
 	}
 
+	// validate the job and cronjob modes
+	if err := r.Spec.validateJob(); err != nil {
+		return warnings, err
+	}
+
 	// validate target allocator configs
 	if r.Spec.TargetAllocator.Enabled {
 		taWarnings, err := c.validateTargetAllocatorConfig(ctx, r)
@@ -344,6 +403,32 @@ func (c CollectorWebhook) validate(ctx context.Context, r *OpenTelemetryCollecto
 		return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'deploymentUpdateStrategy'", r.Spec.Mode)
 	}
 
+	// validate the canary rollout strategy
+	if r.Spec.Rollout != nil && r.Spec.Rollout.Strategy == RolloutStrategyCanary {
+		if r.Spec.Mode != ModeDeployment && r.Spec.Mode != ModeStatefulSet {
+			return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the %s rollout strategy", r.Spec.Mode, RolloutStrategyCanary)
+		}
+		// at least one canary replica is run, whatever the spec says
+		if r.Spec.Mode == ModeStatefulSet && r.Spec.Replicas != nil && max(1, r.Spec.Rollout.Canary.Replicas) >= *r.Spec.Replicas {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec rollout configuration is incorrect, canary replicas must be less than replicas in mode %s", ModeStatefulSet)
+		}
+	}
+
+	// validate the automatic rollback of the configuration
+	if r.Spec.Rollout != nil && r.Spec.Rollout.AutoRollback.Enabled {
+		if r.Spec.Mode == ModeSidecar || r.Spec.Mode.runsToCompletion() {
+			return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'rollout.autoRollback'", r.Spec.Mode)
+		}
+		if r.Spec.Rollout.Strategy == RolloutStrategyCanary {
+			warnings = append(warnings, "rollout.autoRollback is ignored with the canary rollout strategy, which rolls failing configurations back already")
+		}
+	}
+
+	// validate the restart of the pods on changes of what they mount
+	if (r.Spec.Mode == ModeSidecar || r.Spec.Mode.runsToCompletion()) && r.Spec.Rollout != nil && r.Spec.Rollout.RestartOnMountedChanges {
+		return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'rollout.restartOnMountedChanges'", r.Spec.Mode)
+	}
+
 	return warnings, nil
 }
 
@@ -377,9 +462,26 @@ func (c CollectorWebhook) validateTargetAllocatorConfig(ctx context.Context, r *
 	if err != nil {
 		return nil, fmt.Errorf("the OpenTelemetry Spec Prometheus configuration is incorrect, %w", err)
 	}
+	var rules []*rbacv1.PolicyRule
 	// if the prometheusCR is enabled, it needs a suite of permissions to function
 	if r.Spec.TargetAllocator.PrometheusCR.Enabled {
-		if subjectAccessReviews, err := c.reviewer.CheckPolicyRules(ctx, r.Spec.TargetAllocator.ServiceAccount, r.GetNamespace(), targetAllocatorCRPolicyRules...); err != nil {
+		rules = append(rules, targetAllocatorCRPolicyRules...)
+		// probes and scrape configs are only watched when selected
+		if r.Spec.TargetAllocator.PrometheusCR.ProbeSelector != nil {
+			rules = append(rules, targetAllocatorProbePolicyRules...)
+		}
+		if r.Spec.TargetAllocator.PrometheusCR.ScrapeConfigSelector != nil {
+			rules = append(rules, targetAllocatorScrapeConfigPolicyRules...)
+		}
+	}
+	// highly available replicas elect a leader through a lease, the operator only grants access to it to the service
+	// account it provisions
+	if r.Spec.TargetAllocator.ServiceAccount != "" && featuregate.TargetAllocatorHighAvailability.IsEnabled() &&
+		r.Spec.TargetAllocator.Replicas != nil && *r.Spec.TargetAllocator.Replicas > 1 {
+		rules = append(rules, targetAllocatorLeasePolicyRules(r.GetName())...)
+	}
+	if len(rules) > 0 {
+		if subjectAccessReviews, err := c.reviewer.CheckPolicyRules(ctx, r.Spec.TargetAllocator.ServiceAccount, r.GetNamespace(), rules...); err != nil {
 			return nil, fmt.Errorf("unable to check rbac rules %w", err)
 		} else if allowed, deniedReviews := rbac.AllSubjectAccessReviewsAllowed(subjectAccessReviews); !allowed {
 			return rbac.WarningsGroupedByResource(deniedReviews), nil
@@ -389,6 +491,22 @@ func (c CollectorWebhook) validateTargetAllocatorConfig(ctx context.Context, r *
 	return nil, nil
 }
 
+// targetAllocatorLeasePolicyRules are the policy rules required for the leader election of the target allocator replicas.
+func targetAllocatorLeasePolicyRules(name string) []*rbacv1.PolicyRule {
+	return []*rbacv1.PolicyRule{
+		{
+			APIGroups: []string{"coordination.k8s.io"},
+			Resources: []string{"leases"},
+			Verbs:     []string{"create"},
+		}, {
+			APIGroups:     []string{"coordination.k8s.io"},
+			Resources:     []string{"leases"},
+			ResourceNames: []string{naming.TargetAllocator(name)},
+			Verbs:         []string{"get", "update"},
+		},
+	}
+}
+
 func validateProbe(probeName string, probe *Probe) error {
 	if probe != nil {
 		if probe.InitialDelaySeconds != nil && *probe.InitialDelaySeconds < 0 {
@@ -23,6 +23,7 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	colfeaturegate "go.opentelemetry.io/collector/featuregate"
 	"gopkg.in/yaml.v3"
 	appsv1 "k8s.io/api/apps/v1"
 	authv1 "k8s.io/api/authorization/v1"
@@ -38,6 +39,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/rbac"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
 
 var (
@@ -73,6 +75,9 @@ func TestValidate(t *testing.T) {
 
 			warnings: []string{
 				"Collector config spec.config has null objects: extensions.foo:, processors.batch:, processors.foo:. For compatibility with other tooling, such as kustomize and kubectl edit, it is recommended to use empty objects e.g. batch: {}.",
+				"processor 'batch' is defined in spec.config.processors but isn't used in any pipeline",
+				"processor 'foo' is defined in spec.config.processors but isn't used in any pipeline",
+				"extension 'foo' is defined in spec.config.extensions but isn't used in spec.config.service.extensions",
 			},
 		},
 	}
@@ -528,6 +533,16 @@ var cfgYaml = `receivers:
    protocols:
      thrift_http:
        endpoint: 0.0.0.0:15268
+exporters:
+ debug: {}
+service:
+ pipelines:
+   metrics:
+     receivers: [examplereceiver, examplereceiver/settings, prometheus]
+     exporters: [debug]
+   traces:
+     receivers: [jaeger/custom]
+     exporters: [debug]
 `
 
 func TestOTELColValidatingWebhook(t *testing.T) {
@@ -657,6 +672,60 @@ func TestOTELColValidatingWebhook(t *testing.T) {
 				"missing the following rules for nonResourceURL: /metrics: [get]",
 			},
 		},
+		{
+			name: "unused configuration reference",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Config: cfg,
+					ConfigReferences: []ConfigReference{
+						{
+							Name: "api-key",
+							SecretKeyRef: &v1.SecretKeySelector{
+								LocalObjectReference: v1.LocalObjectReference{Name: "backend"},
+								Key:                  "api-key",
+							},
+						},
+					},
+				},
+			},
+			expectedWarnings: []string{
+				"reference 'api-key' is defined in spec.configReferences but isn't used in spec.config",
+			},
+		},
+		{
+			name:          "prom CR with probe and scrape config selectors admissions warning",
+			shouldFailSar: true, // force failure
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeStatefulSet,
+					TargetAllocator: TargetAllocatorEmbedded{
+						Enabled: true,
+						PrometheusCR: TargetAllocatorPrometheusCR{
+							Enabled:              true,
+							ProbeSelector:        &metav1.LabelSelector{},
+							ScrapeConfigSelector: &metav1.LabelSelector{},
+						},
+					},
+					Config: cfg,
+				},
+			},
+			expectedWarnings: []string{
+				"missing the following rules for monitoring.coreos.com/servicemonitors: [*]",
+				"missing the following rules for monitoring.coreos.com/podmonitors: [*]",
+				"missing the following rules for monitoring.coreos.com/probes: [*]",
+				"missing the following rules for monitoring.coreos.com/scrapeconfigs: [*]",
+				"missing the following rules for nodes/metrics: [get,list,watch]",
+				"missing the following rules for services: [get,list,watch]",
+				"missing the following rules for endpoints: [get,list,watch]",
+				"missing the following rules for namespaces: [get,list,watch]",
+				"missing the following rules for networking.k8s.io/ingresses: [get,list,watch]",
+				"missing the following rules for nodes: [get,list,watch]",
+				"missing the following rules for pods: [get,list,watch]",
+				"missing the following rules for configmaps: [get]",
+				"missing the following rules for discovery.k8s.io/endpointslices: [get,list,watch]",
+				"missing the following rules for nonResourceURL: /metrics: [get]",
+			},
+		},
 		{
 			name:          "prom CR no admissions warning",
 			shouldFailSar: false, // force SAR okay
@@ -1234,6 +1303,266 @@ func TestOTELColValidatingWebhook(t *testing.T) {
 			},
 			expectedErr: "the OpenTelemetry Collector mode is set to statefulset, which does not support the attribute 'deploymentUpdateStrategy'",
 		},
+		{
+			name: "pipeline exporting to an undefined exporter",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Config: Config{
+						Receivers: AnyConfig{Object: map[string]interface{}{"otlp": map[string]interface{}{}}},
+						Service: Service{
+							Pipelines: map[string]*Pipeline{
+								"traces": {
+									Receivers: []string{"otlp"},
+									Exporters: []string{"otlp"},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: `the OpenTelemetry Collector configuration is incorrect: spec.config.service.pipelines[traces].exporters[0]: Invalid value: "otlp": exporter isn't defined in spec.config.exporters or spec.config.connectors`,
+		},
+		{
+			name: "base configuration from both a collector and a ConfigMap",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					BaseConfig: &BaseConfigSource{
+						CollectorName: "base",
+						ConfigMap: &v1.ConfigMapKeySelector{
+							LocalObjectReference: v1.LocalObjectReference{Name: "base"},
+							Key:                  "collector.yaml",
+						},
+					},
+				},
+			},
+			expectedErr: "exactly one of collectorName and configMap must be set",
+		},
+		{
+			name: "invalid configuration patch",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					ConfigPatches: []ConfigPatch{
+						{Type: ConfigPatchTypeJSON, Patch: "processors: {}"},
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec configPatches[0] is incorrect",
+		},
+		{
+			name: "configuration reference selecting both a Secret and a ConfigMap",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					ConfigReferences: []ConfigReference{
+						{
+							Name: "api-key",
+							SecretKeyRef: &v1.SecretKeySelector{
+								LocalObjectReference: v1.LocalObjectReference{Name: "backend"},
+								Key:                  "api-key",
+							},
+							ConfigMapKeyRef: &v1.ConfigMapKeySelector{
+								LocalObjectReference: v1.LocalObjectReference{Name: "backend"},
+								Key:                  "api-key",
+							},
+						},
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec configReferences[0] configuration is incorrect, exactly one of secretKeyRef and configMapKeyRef must be set",
+		},
+		{
+			name: "undefined configuration reference",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Config: Config{
+						Receivers: AnyConfig{Object: map[string]interface{}{"otlp": map[string]interface{}{}}},
+						Exporters: AnyConfig{Object: map[string]interface{}{
+							"otlp": map[string]interface{}{
+								"headers": map[string]interface{}{"x-api-key": "${ref:api-key}"},
+							},
+						}},
+						Service: Service{
+							Pipelines: map[string]*Pipeline{
+								"traces": {
+									Receivers: []string{"otlp"},
+									Exporters: []string{"otlp"},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Collector configuration refers to api-key, which isn't defined in spec.configReferences",
+		},
+		{
+			name: "canary rollout in daemonset mode",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeDaemonSet,
+					Rollout: &RolloutSpec{
+						Strategy: RolloutStrategyCanary,
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Collector mode is set to daemonset, which does not support the canary rollout strategy",
+		},
+		{
+			name: "canary rollout to every replica of a statefulset",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeStatefulSet,
+					OpenTelemetryCommonFields: OpenTelemetryCommonFields{
+						Replicas: &three,
+					},
+					Rollout: &RolloutSpec{
+						Strategy: RolloutStrategyCanary,
+						Canary: CanaryRolloutSpec{
+							Replicas: 3,
+						},
+					},
+				},
+			},
+			expectedErr: "canary replicas must be less than replicas in mode statefulset",
+		},
+		{
+			name: "canary rollout to the single replica of a statefulset",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeStatefulSet,
+					OpenTelemetryCommonFields: OpenTelemetryCommonFields{
+						Replicas: &one,
+					},
+					Rollout: &RolloutSpec{
+						Strategy: RolloutStrategyCanary,
+					},
+				},
+			},
+			expectedErr: "canary replicas must be less than replicas in mode statefulset",
+		},
+		{
+			name: "automatic rollback in sidecar mode",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeSidecar,
+					Rollout: &RolloutSpec{
+						AutoRollback: AutoRollbackSpec{
+							Enabled: true,
+						},
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Collector mode is set to sidecar, which does not support the attribute 'rollout.autoRollback'",
+		},
+		{
+			name: "restart on mounted changes in sidecar mode",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeSidecar,
+					Rollout: &RolloutSpec{
+						RestartOnMountedChanges: true,
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Collector mode is set to sidecar, which does not support the attribute 'rollout.restartOnMountedChanges'",
+		},
+		{
+			name: "job settings in deployment mode",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeDeployment,
+					Job:  &JobSpec{},
+				},
+			},
+			expectedErr: "the OpenTelemetry Collector mode is set to deployment, which does not support the attribute 'job'",
+		},
+		{
+			name: "cronjob settings in job mode",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:    ModeJob,
+					CronJob: &CronJobSpec{Schedule: "@hourly"},
+				},
+			},
+			expectedErr: "the OpenTelemetry Collector mode is set to job, which does not support the attribute 'cronJob'",
+		},
+		{
+			name: "cronjob mode without schedule",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeCronJob,
+				},
+			},
+			expectedErr: "the OpenTelemetry Collector mode is set to cronjob, which requires the attribute 'cronJob.schedule'",
+		},
+		{
+			name: "replicas in job mode",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeJob,
+					OpenTelemetryCommonFields: OpenTelemetryCommonFields{
+						Replicas: &three,
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Collector mode is set to job, which does not support the attribute 'replicas', use 'job.parallelism' instead",
+		},
+		{
+			name: "autoscaler in cronjob mode",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:       ModeCronJob,
+					CronJob:    &CronJobSpec{Schedule: "@hourly"},
+					Autoscaler: &AutoscalerSpec{MaxReplicas: &three},
+				},
+			},
+			expectedErr: "the OpenTelemetry Collector mode is set to cronjob, which does not support the attribute 'autoscaler'",
+		},
+		{
+			name: "restart on mounted changes in job mode",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeJob,
+					Rollout: &RolloutSpec{
+						RestartOnMountedChanges: true,
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Collector mode is set to job, which does not support the attribute 'rollout.restartOnMountedChanges'",
+		},
+		{
+			name: "pod disruption budget in job mode",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeJob,
+					OpenTelemetryCommonFields: OpenTelemetryCommonFields{
+						PodDisruptionBudget: &PodDisruptionBudgetSpec{MaxUnavailable: &intstr.IntOrString{Type: intstr.Int, IntVal: 1}},
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Collector mode is set to job, which does not support the attribute 'podDisruptionBudget'",
+		},
+		{
+			name: "ingress in cronjob mode",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:    ModeCronJob,
+					CronJob: &CronJobSpec{Schedule: "@hourly"},
+					Ingress: Ingress{Type: IngressTypeIngress},
+				},
+			},
+			expectedErr: "the OpenTelemetry Collector mode is set to cronjob, which does not support the attribute 'ingress'",
+		},
+		{
+			name: "daemonset update strategy in job mode",
+			otelcol: OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					Mode: ModeJob,
+					DaemonSetUpdateStrategy: appsv1.DaemonSetUpdateStrategy{
+						Type: appsv1.OnDeleteDaemonSetStrategyType,
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Collector mode is set to job, which does not support the attribute 'daemonSetUpdateStrategy'",
+		},
 	}
 
 	for _, test := range tests {
@@ -1280,3 +1609,74 @@ func getReviewer(shouldFailSAR bool) *rbac.Reviewer {
 	})
 	return rbac.NewReviewer(c)
 }
+
+func TestTargetAllocatorLeaseWarnings(t *testing.T) {
+	require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.TargetAllocatorHighAvailability.ID(), true))
+	t.Cleanup(func() {
+		require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.TargetAllocatorHighAvailability.ID(), false))
+	})
+	one := int32(1)
+	two := int32(2)
+
+	cfg := Config{}
+	err := yaml.Unmarshal([]byte(cfgYaml), &cfg)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name             string
+		targetAllocator  TargetAllocatorEmbedded
+		expectedWarnings []string
+	}{
+		{
+			name: "own service account",
+			targetAllocator: TargetAllocatorEmbedded{
+				Enabled:  true,
+				Replicas: &two,
+			},
+		},
+		{
+			name: "single replica",
+			targetAllocator: TargetAllocatorEmbedded{
+				Enabled:        true,
+				Replicas:       &one,
+				ServiceAccount: "ta",
+			},
+		},
+		{
+			name: "service account without lease permissions",
+			targetAllocator: TargetAllocatorEmbedded{
+				Enabled:        true,
+				Replicas:       &two,
+				ServiceAccount: "ta",
+			},
+			expectedWarnings: []string{
+				"missing the following rules for coordination.k8s.io/leases: [create,get,update]",
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			cvw := &CollectorWebhook{
+				logger: logr.Discard(),
+				scheme: testScheme,
+				cfg: config.New(
+					config.WithCollectorImage("collector:v0.0.0"),
+					config.WithTargetAllocatorImage("ta:v0.0.0"),
+				),
+				reviewer: getReviewer(true),
+			}
+			otelcol := &OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-instance"},
+				Spec: OpenTelemetryCollectorSpec{
+					Mode:            ModeStatefulSet,
+					TargetAllocator: test.targetAllocator,
+					Config:          cfg,
+				},
+			}
+			warnings, err := cvw.ValidateCreate(context.Background(), otelcol)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, test.expectedWarnings, warnings)
+		})
+	}
+}
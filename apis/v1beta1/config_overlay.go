@@ -0,0 +1,176 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+type (
+	// ConfigPatchType represents how a patch is applied to the collector's configuration.
+	// +kubebuilder:validation:Enum=merge;json
+	ConfigPatchType string
+)
+
+const (
+	// ConfigPatchTypeMerge applies the patch as a JSON merge patch (RFC 7386). Objects are merged, null values remove
+	// keys, and lists are replaced.
+	ConfigPatchTypeMerge ConfigPatchType = "merge"
+
+	// ConfigPatchTypeJSON applies the patch as a JSON patch (RFC 6902), a list of operations.
+	ConfigPatchTypeJSON ConfigPatchType = "json"
+)
+
+// BaseConfigSource references the base configuration of a collector. Exactly one of its fields must be set.
+type BaseConfigSource struct {
+	// CollectorName is the name of another OpenTelemetryCollector in the same namespace, whose spec.config is used as
+	// the base configuration. The base configuration of that collector isn't followed.
+	// +optional
+	CollectorName string `json:"collectorName,omitempty"`
+
+	// ConfigMap selects a key of a ConfigMap in the same namespace, holding the base configuration as YAML.
+	// +optional
+	ConfigMap *corev1.ConfigMapKeySelector `json:"configMap,omitempty"`
+}
+
+// ConfigPatch is a patch applied to the collector's configuration.
+type ConfigPatch struct {
+	// Type determines how the patch is applied. The current options are merge and json. The default is merge.
+	// +optional
+	// +kubebuilder:default:=merge
+	Type ConfigPatchType `json:"type,omitempty"`
+
+	// Patch is the patch, as YAML or JSON.
+	// +required
+	Patch string `json:"patch"`
+}
+
+// componentSections are the sections of the configuration holding components, keyed by their ID.
+var componentSections = []string{"receivers", "exporters", "processors", "connectors", "extensions"}
+
+// Merge returns the configuration merged on top of the base configuration when there's one, with the patches applied
+// in order.
+func (c *Config) Merge(base *Config, patches []ConfigPatch) (Config, error) {
+	merged := Config{}
+	doc, err := overlayJSON(c)
+	if err != nil {
+		return merged, err
+	}
+	if base != nil {
+		baseJSON, marshalErr := json.Marshal(base)
+		if marshalErr != nil {
+			return merged, marshalErr
+		}
+		if doc, err = jsonpatch.MergePatch(baseJSON, doc); err != nil {
+			return merged, fmt.Errorf("failed to merge the configuration on top of the base configuration: %w", err)
+		}
+	}
+
+	for i, patch := range patches {
+		if doc, err = patch.apply(doc); err != nil {
+			return merged, fmt.Errorf("failed to apply spec.configPatches[%d]: %w", i, err)
+		}
+	}
+	if err = json.Unmarshal(doc, &merged); err != nil {
+		return merged, fmt.Errorf("failed to read the merged configuration: %w", err)
+	}
+	return merged, nil
+}
+
+// decode returns the patch as JSON, and its operations when it's a JSON patch.
+func (p ConfigPatch) decode() ([]byte, jsonpatch.Patch, error) {
+	data, err := yaml.YAMLToJSON([]byte(p.Patch))
+	if err != nil {
+		return nil, nil, err
+	}
+	switch p.Type {
+	case ConfigPatchTypeJSON:
+		operations, decodeErr := jsonpatch.DecodePatch(data)
+		return data, operations, decodeErr
+	case ConfigPatchTypeMerge, "":
+		var obj map[string]interface{}
+		if err = json.Unmarshal(data, &obj); err != nil || obj == nil {
+			return nil, nil, errors.New("a merge patch must be an object")
+		}
+		return data, nil, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown patch type %q", p.Type)
+	}
+}
+
+func (p ConfigPatch) apply(doc []byte) ([]byte, error) {
+	data, operations, err := p.decode()
+	if err != nil {
+		return nil, err
+	}
+	if operations != nil {
+		return operations.Apply(doc)
+	}
+	return jsonpatch.MergePatch(doc, data)
+}
+
+// overlayJSON returns the configuration as a merge patch. In a merge patch, null values remove keys, but in the
+// configuration they're either empty components, which are kept as empty objects, or unset fields, which are dropped.
+func overlayJSON(cfg *Config) ([]byte, error) {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	overlay := map[string]interface{}{}
+	if err = json.Unmarshal(data, &overlay); err != nil {
+		return nil, err
+	}
+	for _, section := range componentSections {
+		if components, ok := overlay[section].(map[string]interface{}); ok {
+			for id, component := range components {
+				components[id] = emptyNulls(component)
+			}
+		}
+	}
+	return json.Marshal(dropNulls(overlay))
+}
+
+// emptyNulls replaces null values in the configuration of a component with empty objects, which the collector
+// treats the same.
+func emptyNulls(value interface{}) interface{} {
+	switch v := value.(type) {
+	case nil:
+		return map[string]interface{}{}
+	case map[string]interface{}:
+		for key, nested := range v {
+			v[key] = emptyNulls(nested)
+		}
+	}
+	return value
+}
+
+func dropNulls(value interface{}) interface{} {
+	if v, ok := value.(map[string]interface{}); ok {
+		for key, nested := range v {
+			if nested == nil {
+				delete(v, key)
+				continue
+			}
+			v[key] = dropNulls(nested)
+		}
+	}
+	return value
+}
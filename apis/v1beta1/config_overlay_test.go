@@ -0,0 +1,221 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestConfigMerge(t *testing.T) {
+	base := `
+receivers:
+  otlp:
+    protocols:
+      grpc: {}
+processors:
+  batch: {}
+  resource:
+    attributes:
+    - key: cluster
+      value: base
+      action: upsert
+exporters:
+  otlp:
+    endpoint: base:4317
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      processors: [resource, batch]
+      exporters: [otlp]
+`
+	for _, tc := range []struct {
+		name     string
+		base     string
+		overlay  string
+		patches  []ConfigPatch
+		expected string
+		err      string
+	}{
+		{
+			name: "overlay on top of the base",
+			base: base,
+			overlay: `
+receivers: {}
+exporters:
+  otlp:
+    endpoint: eu-west-1:4317
+  debug:
+service:
+  pipelines: {}
+`,
+			expected: `
+receivers:
+  otlp:
+    protocols:
+      grpc: {}
+processors:
+  batch: {}
+  resource:
+    attributes:
+    - key: cluster
+      value: base
+      action: upsert
+exporters:
+  otlp:
+    endpoint: eu-west-1:4317
+  debug: {}
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      processors: [resource, batch]
+      exporters: [otlp]
+`,
+		},
+		{
+			name: "patches",
+			base: base,
+			overlay: `
+receivers: {}
+exporters: {}
+service:
+  pipelines: {}
+`,
+			patches: []ConfigPatch{
+				{
+					Type: ConfigPatchTypeMerge,
+					Patch: `
+processors:
+  batch: null
+service:
+  pipelines:
+    traces:
+      processors: [resource]
+`,
+				},
+				{
+					Type:  ConfigPatchTypeJSON,
+					Patch: `[{"op": "replace", "path": "/processors/resource/attributes/0/value", "value": "eu-west-1"}]`,
+				},
+			},
+			expected: `
+receivers:
+  otlp:
+    protocols:
+      grpc: {}
+processors:
+  resource:
+    attributes:
+    - key: cluster
+      value: eu-west-1
+      action: upsert
+exporters:
+  otlp:
+    endpoint: base:4317
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      processors: [resource]
+      exporters: [otlp]
+`,
+		},
+		{
+			name: "patches without a base",
+			overlay: `
+receivers:
+  otlp:
+exporters:
+  debug:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [debug]
+`,
+			patches: []ConfigPatch{{Patch: `{"exporters": {"debug": {"verbosity": "detailed"}}}`}},
+			expected: `
+receivers:
+  otlp: {}
+exporters:
+  debug:
+    verbosity: detailed
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [debug]
+`,
+		},
+		{
+			name:    "failing patch",
+			base:    base,
+			overlay: "receivers: {}",
+			patches: []ConfigPatch{{Type: ConfigPatchTypeJSON, Patch: `[{"op": "remove", "path": "/connectors/forward"}]`}},
+			err:     "failed to apply spec.configPatches[0]",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var baseCfg *Config
+			if tc.base != "" {
+				baseCfg = &Config{}
+				require.NoError(t, yaml.Unmarshal([]byte(tc.base), baseCfg))
+			}
+			overlay := Config{}
+			require.NoError(t, yaml.Unmarshal([]byte(tc.overlay), &overlay))
+
+			merged, err := overlay.Merge(baseCfg, tc.patches)
+			if tc.err != "" {
+				assert.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			expected := Config{}
+			require.NoError(t, yaml.Unmarshal([]byte(tc.expected), &expected))
+			expectedYaml, err := expected.Yaml()
+			require.NoError(t, err)
+			mergedYaml, err := merged.Yaml()
+			require.NoError(t, err)
+			assert.YAMLEq(t, expectedYaml, mergedYaml)
+		})
+	}
+}
+
+func TestConfigPatchDecode(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		patch ConfigPatch
+		err   string
+	}{
+		{name: "merge", patch: ConfigPatch{Patch: "processors:\n  batch: {}"}},
+		{name: "merge patch which isn't an object", patch: ConfigPatch{Type: ConfigPatchTypeMerge, Patch: "[]"}, err: "a merge patch must be an object"},
+		{name: "json", patch: ConfigPatch{Type: ConfigPatchTypeJSON, Patch: `[{"op": "remove", "path": "/processors/batch"}]`}},
+		{name: "json patch which isn't a list", patch: ConfigPatch{Type: ConfigPatchTypeJSON, Patch: "processors: {}"}, err: "cannot unmarshal"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := tc.patch.decode()
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.err)
+			}
+		})
+	}
+}
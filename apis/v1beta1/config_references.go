@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+type (
+	// ConfigReferenceMount represents how the value of a configuration reference reaches the collector.
+	// +kubebuilder:validation:Enum=env;file
+	ConfigReferenceMount string
+)
+
+const (
+	// ConfigReferenceMountEnv exposes the value as an environment variable of the collector container, and the
+	// placeholder is replaced with a reference to that variable.
+	ConfigReferenceMountEnv ConfigReferenceMount = "env"
+
+	// ConfigReferenceMountFile mounts the value as a file in the collector container, and the placeholder is replaced
+	// with the path of that file.
+	ConfigReferenceMountFile ConfigReferenceMount = "file"
+)
+
+// ConfigReference exposes a key of a Secret or a ConfigMap to the collector's configuration, where each ${ref:<name>}
+// placeholder is replaced according to MountAs. Exactly one of SecretKeyRef and ConfigMapKeyRef must be set.
+type ConfigReference struct {
+	// Name identifies the reference in the placeholders of the configuration.
+	// +required
+	// +kubebuilder:validation:MaxLength=59
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// SecretKeyRef selects a key of a Secret in the same namespace.
+	// +optional
+	SecretKeyRef *corev1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+
+	// ConfigMapKeyRef selects a key of a ConfigMap in the same namespace.
+	// +optional
+	ConfigMapKeyRef *corev1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+
+	// MountAs determines how the value reaches the collector. The current options are env and file. The default is
+	// env, where placeholders are replaced with ${env:<variable>}, while with file they are replaced with the path of
+	// the mounted file, e.g. for a TLS certificate.
+	// +optional
+	// +kubebuilder:default:=env
+	MountAs ConfigReferenceMount `json:"mountAs,omitempty"`
+}
+
+// configReferencePattern matches the placeholders of the configuration references, ${ref:<name>}.
+var configReferencePattern = regexp.MustCompile(`\$\{ref:([^}]*)\}`)
+
+// configReferences returns the names of the references used by the placeholders of the configuration.
+func (c *Config) configReferences() (map[string]bool, error) {
+	cfg, err := c.Yaml()
+	if err != nil {
+		return nil, err
+	}
+	names := map[string]bool{}
+	for _, match := range configReferencePattern.FindAllStringSubmatch(cfg, -1) {
+		names[match[1]] = true
+	}
+	return names, nil
+}
+
+// Placeholder returns the placeholder of the reference in the configuration.
+func (r ConfigReference) Placeholder() string {
+	return fmt.Sprintf("${ref:%s}", r.Name)
+}
+
+// EnvVarName returns the name of the environment variable holding the value of the reference, when mounted as such.
+func (r ConfigReference) EnvVarName() string {
+	return "OTEL_REF_" + strings.ToUpper(strings.ReplaceAll(r.Name, "-", "_"))
+}
+
+// Key returns the key of the Secret or the ConfigMap selected by the reference.
+func (r ConfigReference) Key() string {
+	switch {
+	case r.SecretKeyRef != nil:
+		return r.SecretKeyRef.Key
+	case r.ConfigMapKeyRef != nil:
+		return r.ConfigMapKeyRef.Key
+	default:
+		return ""
+	}
+}
+
+// validateConfigReferences checks that each reference selects a single key, and that the placeholders of the
+// configuration match the references. The latter is skipped with overlays, where placeholders may come from the base
+// configuration or the patches.
+func (s *OpenTelemetryCollectorSpec) validateConfigReferences() (admission.Warnings, error) {
+	defined := map[string]bool{}
+	for i, ref := range s.ConfigReferences {
+		if (ref.SecretKeyRef == nil) == (ref.ConfigMapKeyRef == nil) {
+			return nil, fmt.Errorf("the OpenTelemetry Spec configReferences[%d] configuration is incorrect, exactly one of secretKeyRef and configMapKeyRef must be set", i)
+		}
+		defined[ref.Name] = true
+	}
+	if s.BaseConfig != nil || len(s.ConfigPatches) > 0 {
+		return nil, nil
+	}
+
+	used, err := s.Config.configReferences()
+	if err != nil {
+		return nil, err
+	}
+	var undefined []string
+	for name := range used {
+		if !defined[name] {
+			undefined = append(undefined, name)
+		}
+	}
+	if len(undefined) > 0 {
+		sort.Strings(undefined)
+		return nil, fmt.Errorf("the OpenTelemetry Collector configuration refers to %s, which isn't defined in spec.configReferences", strings.Join(undefined, ", "))
+	}
+
+	var warnings admission.Warnings
+	for _, ref := range s.ConfigReferences {
+		if !used[ref.Name] {
+			warnings = append(warnings, fmt.Sprintf("reference '%s' is defined in spec.configReferences but isn't used in spec.config", ref.Name))
+		}
+	}
+	return warnings, nil
+}
@@ -0,0 +1,254 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// connectorSignals lists the pipeline types well-known connectors can be used between, keyed by the type of the
+// connector, then by the type of the pipeline it's an exporter in. Connectors which aren't listed are only checked
+// for being used as both an exporter and a receiver.
+var connectorSignals = map[string]map[string][]string{
+	"count":           {"traces": {"metrics"}, "metrics": {"metrics"}, "logs": {"metrics"}},
+	"exceptions":      {"traces": {"metrics", "logs"}},
+	"failover":        {"traces": {"traces"}, "metrics": {"metrics"}, "logs": {"logs"}},
+	"forward":         {"traces": {"traces"}, "metrics": {"metrics"}, "logs": {"logs"}, "profiles": {"profiles"}},
+	"roundrobin":      {"traces": {"traces"}, "metrics": {"metrics"}, "logs": {"logs"}},
+	"routing":         {"traces": {"traces"}, "metrics": {"metrics"}, "logs": {"logs"}, "profiles": {"profiles"}},
+	"servicegraph":    {"traces": {"metrics"}},
+	"signaltometrics": {"traces": {"metrics"}, "metrics": {"metrics"}, "logs": {"metrics"}},
+	"spanmetrics":     {"traces": {"metrics"}},
+	"sum":             {"traces": {"metrics"}, "metrics": {"metrics"}, "logs": {"metrics"}},
+}
+
+// componentType returns the type of a component or pipeline ID, which is the part before the optional name.
+func componentType(id string) string {
+	componentType, _, _ := strings.Cut(id, "/")
+	return componentType
+}
+
+// connectorUse is a pipeline a connector is used in, and where.
+type connectorUse struct {
+	signal string
+	path   *field.Path
+}
+
+// ValidatePipelines checks that the pipelines of the service only refer to components which are defined, and that
+// connectors join pipelines of compatible types. Components which are defined but never used are returned as
+// warnings. The webhook validates the configuration of collectors without overlays, the controller validates the
+// merged configuration of the others.
+func (c *Config) ValidatePipelines() (admission.Warnings, field.ErrorList) {
+	var errs field.ErrorList
+	var warnings admission.Warnings
+	servicePath := field.NewPath("spec", "config", "service")
+
+	var connectors map[string]interface{}
+	if c.Connectors != nil {
+		connectors = c.Connectors.Object
+	}
+	var processors map[string]interface{}
+	if c.Processors != nil {
+		processors = c.Processors.Object
+	}
+	used := map[string]map[string]bool{
+		"receiver":  {},
+		"processor": {},
+		"exporter":  {},
+		"connector": {},
+		"extension": {},
+	}
+	exportedTo := map[string][]connectorUse{}
+	receivedFrom := map[string][]connectorUse{}
+
+	// sort the pipelines so that the errors are deterministic
+	names := make([]string, 0, len(c.Service.Pipelines))
+	for name := range c.Service.Pipelines {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		pipeline := c.Service.Pipelines[name]
+		path := servicePath.Child("pipelines").Key(name)
+		if pipeline == nil {
+			errs = append(errs, field.Required(path, "pipeline must not be empty"))
+			continue
+		}
+		signal := componentType(name)
+
+		if len(pipeline.Receivers) == 0 {
+			errs = append(errs, field.Required(path.Child("receivers"), "pipeline must have at least one receiver"))
+		}
+		for i, id := range pipeline.Receivers {
+			idPath := path.Child("receivers").Index(i)
+			switch {
+			case hasComponent(c.Receivers.Object, id):
+				used["receiver"][id] = true
+			case hasComponent(connectors, id):
+				used["connector"][id] = true
+				receivedFrom[id] = append(receivedFrom[id], connectorUse{signal: signal, path: idPath})
+			default:
+				errs = append(errs, field.Invalid(idPath, id, "receiver isn't defined in spec.config.receivers or spec.config.connectors"))
+			}
+		}
+
+		for i, id := range pipeline.Processors {
+			if !hasComponent(processors, id) {
+				errs = append(errs, field.Invalid(path.Child("processors").Index(i), id, "processor isn't defined in spec.config.processors"))
+				continue
+			}
+			used["processor"][id] = true
+		}
+
+		if len(pipeline.Exporters) == 0 {
+			errs = append(errs, field.Required(path.Child("exporters"), "pipeline must have at least one exporter"))
+		}
+		for i, id := range pipeline.Exporters {
+			idPath := path.Child("exporters").Index(i)
+			switch {
+			case hasComponent(c.Exporters.Object, id):
+				used["exporter"][id] = true
+			case hasComponent(connectors, id):
+				used["connector"][id] = true
+				exportedTo[id] = append(exportedTo[id], connectorUse{signal: signal, path: idPath})
+			default:
+				errs = append(errs, field.Invalid(idPath, id, "exporter isn't defined in spec.config.exporters or spec.config.connectors"))
+			}
+		}
+	}
+	errs = append(errs, validateConnectors(exportedTo, receivedFrom)...)
+
+	if c.Service.Extensions != nil {
+		for i, id := range *c.Service.Extensions {
+			if !hasComponent(c.extensions(), id) {
+				errs = append(errs, field.Invalid(servicePath.Child("extensions").Index(i), id, "extension isn't defined in spec.config.extensions"))
+				continue
+			}
+			used["extension"][id] = true
+		}
+	}
+
+	for _, kind := range []struct {
+		name       string
+		components map[string]interface{}
+		usedIn     string
+	}{
+		{name: "receiver", components: c.Receivers.Object, usedIn: "any pipeline"},
+		{name: "processor", components: processors, usedIn: "any pipeline"},
+		{name: "exporter", components: c.Exporters.Object, usedIn: "any pipeline"},
+		{name: "connector", components: connectors, usedIn: "any pipeline"},
+		{name: "extension", components: c.extensions(), usedIn: "spec.config.service.extensions"},
+	} {
+		var unused []string
+		for id := range kind.components {
+			if !used[kind.name][id] {
+				unused = append(unused, id)
+			}
+		}
+		sort.Strings(unused)
+		for _, id := range unused {
+			warnings = append(warnings, fmt.Sprintf("%s '%s' is defined in spec.config.%ss but isn't used in %s", kind.name, id, kind.name, kind.usedIn))
+		}
+	}
+	return warnings, errs
+}
+
+// validateConnectors checks that every connector is used as both an exporter and a receiver, between pipelines of
+// types it supports.
+func validateConnectors(exportedTo, receivedFrom map[string][]connectorUse) field.ErrorList {
+	var errs field.ErrorList
+	ids := make([]string, 0, len(exportedTo)+len(receivedFrom))
+	for id := range exportedTo {
+		ids = append(ids, id)
+	}
+	for id := range receivedFrom {
+		if _, ok := exportedTo[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		exporters, receivers := exportedTo[id], receivedFrom[id]
+		switch {
+		case len(receivers) == 0:
+			errs = append(errs, field.Invalid(exporters[0].path, id, "connector is used as an exporter but not as a receiver in any pipeline"))
+			continue
+		case len(exporters) == 0:
+			errs = append(errs, field.Invalid(receivers[0].path, id, "connector is used as a receiver but not as an exporter in any pipeline"))
+			continue
+		}
+		supported, known := connectorSignals[componentType(id)]
+		if !known {
+			continue
+		}
+		for _, exporter := range exporters {
+			if !connects(supported, []string{exporter.signal}, signals(receivers)) {
+				errs = append(errs, field.Invalid(exporter.path, id, fmt.Sprintf("connector can't export from a %s pipeline to %s pipelines", exporter.signal, strings.Join(signals(receivers), ", "))))
+			}
+		}
+		for _, receiver := range receivers {
+			if !connects(supported, signals(exporters), []string{receiver.signal}) {
+				errs = append(errs, field.Invalid(receiver.path, id, fmt.Sprintf("connector can't receive in a %s pipeline from %s pipelines", receiver.signal, strings.Join(signals(exporters), ", "))))
+			}
+		}
+	}
+	return errs
+}
+
+// connects returns whether a connector supporting the given pipeline types connects any of the exporting pipeline
+// types to any of the receiving ones.
+func connects(supported map[string][]string, from, to []string) bool {
+	for _, exporter := range from {
+		for _, receiver := range supported[exporter] {
+			for _, signal := range to {
+				if receiver == signal {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func signals(uses []connectorUse) []string {
+	var signals []string
+	seen := map[string]bool{}
+	for _, use := range uses {
+		if !seen[use.signal] {
+			seen[use.signal] = true
+			signals = append(signals, use.signal)
+		}
+	}
+	sort.Strings(signals)
+	return signals
+}
+
+func hasComponent(components map[string]interface{}, id string) bool {
+	_, ok := components[id]
+	return ok
+}
+
+func (c *Config) extensions() map[string]interface{} {
+	if c.Extensions == nil {
+		return nil
+	}
+	return c.Extensions.Object
+}
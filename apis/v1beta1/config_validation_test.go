@@ -0,0 +1,209 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestValidatePipelines(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		config   string
+		errs     []string
+		warnings []string
+	}{
+		{
+			name: "valid",
+			config: `
+receivers:
+  otlp: {}
+processors:
+  batch: {}
+exporters:
+  debug: {}
+extensions:
+  health_check: {}
+service:
+  extensions: [health_check]
+  pipelines:
+    traces:
+      receivers: [otlp]
+      processors: [batch]
+      exporters: [debug]
+`,
+		},
+		{
+			name: "undefined components",
+			config: `
+receivers:
+  otlp: {}
+exporters:
+  debug: {}
+service:
+  extensions: [health_check]
+  pipelines:
+    traces:
+      receivers: [otlp, jaeger]
+      processors: [batch]
+      exporters: [debug, otlp]
+`,
+			errs: []string{
+				`spec.config.service.pipelines[traces].receivers[1]: Invalid value: "jaeger": receiver isn't defined in spec.config.receivers or spec.config.connectors`,
+				`spec.config.service.pipelines[traces].processors[0]: Invalid value: "batch": processor isn't defined in spec.config.processors`,
+				`spec.config.service.pipelines[traces].exporters[1]: Invalid value: "otlp": exporter isn't defined in spec.config.exporters or spec.config.connectors`,
+				`spec.config.service.extensions[0]: Invalid value: "health_check": extension isn't defined in spec.config.extensions`,
+			},
+		},
+		{
+			name: "pipeline without receivers and exporters",
+			config: `
+receivers:
+  otlp: {}
+exporters:
+  debug: {}
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+    metrics:
+      exporters: [debug]
+`,
+			errs: []string{
+				`spec.config.service.pipelines[metrics].receivers: Required value: pipeline must have at least one receiver`,
+				`spec.config.service.pipelines[traces].exporters: Required value: pipeline must have at least one exporter`,
+			},
+		},
+		{
+			name: "connector between compatible pipelines",
+			config: `
+receivers:
+  otlp: {}
+exporters:
+  debug: {}
+connectors:
+  spanmetrics: {}
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [spanmetrics]
+    metrics:
+      receivers: [spanmetrics]
+      exporters: [debug]
+`,
+		},
+		{
+			name: "connector between profiles pipelines",
+			config: `
+receivers:
+  otlp: {}
+exporters:
+  debug: {}
+connectors:
+  forward: {}
+service:
+  pipelines:
+    profiles/in:
+      receivers: [otlp]
+      exporters: [forward]
+    profiles/out:
+      receivers: [forward]
+      exporters: [debug]
+`,
+		},
+		{
+			name: "connector between incompatible pipelines",
+			config: `
+receivers:
+  otlp: {}
+exporters:
+  debug: {}
+connectors:
+  spanmetrics: {}
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [spanmetrics]
+    logs:
+      receivers: [spanmetrics]
+      exporters: [debug]
+`,
+			errs: []string{
+				`spec.config.service.pipelines[traces].exporters[0]: Invalid value: "spanmetrics": connector can't export from a traces pipeline to logs pipelines`,
+				`spec.config.service.pipelines[logs].receivers[0]: Invalid value: "spanmetrics": connector can't receive in a logs pipeline from traces pipelines`,
+			},
+		},
+		{
+			name: "connector only used as an exporter",
+			config: `
+receivers:
+  otlp: {}
+connectors:
+  forward: {}
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [forward]
+`,
+			errs: []string{
+				`spec.config.service.pipelines[traces].exporters[0]: Invalid value: "forward": connector is used as an exporter but not as a receiver in any pipeline`,
+			},
+		},
+		{
+			name: "unused components",
+			config: `
+receivers:
+  otlp: {}
+  jaeger: {}
+processors:
+  batch: {}
+exporters:
+  debug: {}
+extensions:
+  pprof: {}
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [debug]
+`,
+			warnings: []string{
+				"receiver 'jaeger' is defined in spec.config.receivers but isn't used in any pipeline",
+				"processor 'batch' is defined in spec.config.processors but isn't used in any pipeline",
+				"extension 'pprof' is defined in spec.config.extensions but isn't used in spec.config.service.extensions",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := Config{}
+			require.NoError(t, yaml.Unmarshal([]byte(tc.config), &cfg))
+
+			warnings, errs := cfg.ValidatePipelines()
+			var messages []string
+			for _, err := range errs {
+				messages = append(messages, err.Error())
+			}
+			assert.ElementsMatch(t, tc.errs, messages)
+			assert.ElementsMatch(t, tc.warnings, warnings)
+		})
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type (
+	// CollectorJobPhase represents where the latest run of the collector is at, in the job and cronjob modes.
+	CollectorJobPhase string
+)
+
+const (
+	// CollectorJobPhasePending means the collector didn't run yet, e.g. because the schedule of the cronjob wasn't
+	// reached.
+	CollectorJobPhasePending CollectorJobPhase = "Pending"
+
+	// CollectorJobPhaseRunning means the latest run of the collector is in progress.
+	CollectorJobPhaseRunning CollectorJobPhase = "Running"
+
+	// CollectorJobPhaseSucceeded means the latest run of the collector completed.
+	CollectorJobPhaseSucceeded CollectorJobPhase = "Succeeded"
+
+	// CollectorJobPhaseFailed means the latest run of the collector failed, because its pods failed too many times or
+	// it exceeded its deadline.
+	CollectorJobPhaseFailed CollectorJobPhase = "Failed"
+)
+
+// JobSpec configures the Job running the collector in the job and cronjob modes. The collector has to exit for a run
+// to complete, otherwise ActiveDeadlineSeconds ends it.
+type JobSpec struct {
+	// Completions is the number of pods which have to run the collector to completion. Defaults to 1.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	Completions *int32 `json:"completions,omitempty"`
+
+	// Parallelism is the maximum number of pods running the collector at the same time. Defaults to 1.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Parallelism *int32 `json:"parallelism,omitempty"`
+
+	// BackoffLimit is the number of retries before a run is considered failed. Defaults to 6.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	BackoffLimit *int32 `json:"backoffLimit,omitempty"`
+
+	// ActiveDeadlineSeconds is how long a run may take before its pods are terminated and it's considered failed.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	ActiveDeadlineSeconds *int64 `json:"activeDeadlineSeconds,omitempty"`
+
+	// RestartPolicy of the pods running the collector. The current options are OnFailure and Never. The default is
+	// OnFailure.
+	// +optional
+	// +kubebuilder:default:=OnFailure
+	// +kubebuilder:validation:Enum=OnFailure;Never
+	RestartPolicy corev1.RestartPolicy `json:"restartPolicy,omitempty"`
+}
+
+// CronJobSpec configures the CronJob running the collector in the cronjob mode.
+type CronJobSpec struct {
+	// Schedule of the runs, in the cron format.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	Schedule string `json:"schedule"`
+
+	// TimeZone of the schedule, e.g. Etc/UTC. Defaults to the time zone of the kube-controller-manager.
+	// +optional
+	TimeZone *string `json:"timeZone,omitempty"`
+
+	// ConcurrencyPolicy determines what happens when a run is due while the previous one is still in progress. The
+	// current options are Allow, Forbid and Replace. The default is Forbid.
+	// +optional
+	// +kubebuilder:default:=Forbid
+	// +kubebuilder:validation:Enum=Allow;Forbid;Replace
+	ConcurrencyPolicy batchv1.ConcurrencyPolicy `json:"concurrencyPolicy,omitempty"`
+
+	// StartingDeadlineSeconds is how late a run may start after its scheduled time before it's skipped.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	StartingDeadlineSeconds *int64 `json:"startingDeadlineSeconds,omitempty"`
+
+	// Suspend stops the runs from being scheduled, without affecting the one in progress.
+	// +optional
+	Suspend *bool `json:"suspend,omitempty"`
+
+	// SuccessfulJobsHistoryLimit is the number of successful runs kept. Defaults to 3.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	SuccessfulJobsHistoryLimit *int32 `json:"successfulJobsHistoryLimit,omitempty"`
+
+	// FailedJobsHistoryLimit is the number of failed runs kept. Defaults to 1.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	FailedJobsHistoryLimit *int32 `json:"failedJobsHistoryLimit,omitempty"`
+}
+
+// CollectorJobStatus reports the outcome of the latest run of the collector, in the job and cronjob modes.
+type CollectorJobStatus struct {
+	// Name of the Job of the latest run.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Phase of the latest run. It's one of Pending, Running, Succeeded and Failed.
+	Phase CollectorJobPhase `json:"phase"`
+
+	// Active is the number of pods of the latest run which are running.
+	// +optional
+	Active int32 `json:"active,omitempty"`
+
+	// Succeeded is the number of pods of the latest run which completed.
+	// +optional
+	Succeeded int32 `json:"succeeded,omitempty"`
+
+	// Failed is the number of pods of the latest run which failed.
+	// +optional
+	Failed int32 `json:"failed,omitempty"`
+
+	// StartTime is the time the latest run started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time the latest run completed, only set when it succeeded.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Message explains why the latest run failed.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// LastScheduleTime is the last time a run was scheduled, in the cronjob mode.
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// LastSuccessfulTime is the last time a run completed, in the cronjob mode.
+	// +optional
+	LastSuccessfulTime *metav1.Time `json:"lastSuccessfulTime,omitempty"`
+}
+
+// runsToCompletion returns whether the collector runs to completion in the mode, rather than continuously.
+func (m Mode) runsToCompletion() bool {
+	return m == ModeJob || m == ModeCronJob
+}
+
+// validateJob checks that the job and cronjob settings are only used in their modes, and that the settings of the
+// modes running continuously aren't used in them.
+func (s *OpenTelemetryCollectorSpec) validateJob() error {
+	if s.Job != nil && !s.Mode.runsToCompletion() {
+		return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'job'", s.Mode)
+	}
+	if s.CronJob != nil && s.Mode != ModeCronJob {
+		return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'cronJob'", s.Mode)
+	}
+	if s.Mode == ModeCronJob && (s.CronJob == nil || s.CronJob.Schedule == "") {
+		return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which requires the attribute 'cronJob.schedule'", s.Mode)
+	}
+	if !s.Mode.runsToCompletion() {
+		return nil
+	}
+	if s.Replicas != nil && *s.Replicas != 1 {
+		return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'replicas', use 'job.parallelism' instead", s.Mode)
+	}
+	if s.Autoscaler != nil {
+		return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'autoscaler'", s.Mode)
+	}
+	if s.PodDisruptionBudget != nil {
+		return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'podDisruptionBudget'", s.Mode)
+	}
+	if s.Ingress.Type != "" {
+		return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'ingress'", s.Mode)
+	}
+	if s.DaemonSetUpdateStrategy.Type != "" || s.DaemonSetUpdateStrategy.RollingUpdate != nil {
+		return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'daemonSetUpdateStrategy'", s.Mode)
+	}
+	return nil
+}
@@ -74,8 +74,139 @@ type OpenTelemetryCollectorStatus struct {
 	// Image indicates the container image to use for the OpenTelemetry Collector.
 	// +optional
 	Image string `json:"image,omitempty"`
+
+	// Conditions represent the latest available observations of the OpenTelemetryCollector's state.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +patchStrategy=merge
+	// +patchMergeKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+
+	// Pods summarizes the health of the collector's pods. In sidecar mode, these are the pods the collector was
+	// injected into, and only the collector container is taken into account.
+	// +optional
+	Pods *CollectorPodsStatus `json:"pods,omitempty"`
+
+	// Rollout records the progress of the rollout of the configuration, when the canary strategy or automatic
+	// rollbacks are used.
+	// +optional
+	Rollout *RolloutStatus `json:"rollout,omitempty"`
+
+	// EffectiveConfig is the configuration the collector runs, when spec.baseConfig or spec.configPatches are used.
+	// +optional
+	EffectiveConfig string `json:"effectiveConfig,omitempty"`
+
+	// Job reports the outcome of the latest run of the collector, in the job and cronjob modes.
+	// +optional
+	Job *CollectorJobStatus `json:"job,omitempty"`
 }
 
+// CollectorPodsStatus summarizes the health of the collector's pods.
+type CollectorPodsStatus struct {
+	// Ready is the number of pods whose collector container is ready.
+	Ready int32 `json:"ready"`
+
+	// NotReady is the number of pods whose collector container isn't ready, including crashlooping ones.
+	NotReady int32 `json:"notReady"`
+
+	// CrashLooping is the number of pods whose collector container is waiting to be restarted after crashing.
+	CrashLooping int32 `json:"crashLooping"`
+
+	// Restarts is the total number of restarts of the collector container across all pods.
+	Restarts int32 `json:"restarts"`
+
+	// Nodes breaks the counts down per node, for the nodes which run pods that aren't ready. Only the nodes with the
+	// most pods that aren't ready are listed.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Nodes []CollectorNodePodsStatus `json:"nodes,omitempty"`
+
+	// Zones breaks the counts down per zone, as given by the topology.kubernetes.io/zone label of the nodes. Pods
+	// on nodes without the label aren't accounted for.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Zones []CollectorZonePodsStatus `json:"zones,omitempty"`
+
+	// LastTermination is the most recent termination of a collector container among all pods. The collector reports
+	// why it rejected its configuration in the termination message.
+	// +optional
+	LastTermination *CollectorTermination `json:"lastTermination,omitempty"`
+}
+
+// CollectorNodePodsStatus holds the health of the collector's pods on a single node.
+type CollectorNodePodsStatus struct {
+	// Name of the node.
+	Name string `json:"name"`
+
+	// Ready is the number of pods on the node whose collector container is ready.
+	Ready int32 `json:"ready"`
+
+	// NotReady is the number of pods on the node whose collector container isn't ready.
+	NotReady int32 `json:"notReady"`
+
+	// CrashLooping is the number of pods on the node whose collector container is crashlooping.
+	CrashLooping int32 `json:"crashLooping"`
+}
+
+// CollectorZonePodsStatus holds the health of the collector's pods in a single zone.
+type CollectorZonePodsStatus struct {
+	// Name of the zone.
+	Name string `json:"name"`
+
+	// Ready is the number of pods in the zone whose collector container is ready.
+	Ready int32 `json:"ready"`
+
+	// NotReady is the number of pods in the zone whose collector container isn't ready.
+	NotReady int32 `json:"notReady"`
+
+	// CrashLooping is the number of pods in the zone whose collector container is crashlooping.
+	CrashLooping int32 `json:"crashLooping"`
+}
+
+// CollectorTermination describes the termination of a collector container.
+type CollectorTermination struct {
+	// Pod is the name of the pod the container belongs to.
+	Pod string `json:"pod"`
+
+	// Reason is a brief reason for the termination, like Error or OOMKilled.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Message is the termination message of the container, which holds the end of its logs when it failed.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// ExitCode is the exit code of the container.
+	ExitCode int32 `json:"exitCode"`
+
+	// FinishedAt is the time the container terminated.
+	// +optional
+	FinishedAt metav1.Time `json:"finishedAt,omitempty"`
+}
+
+// Condition types of the OpenTelemetryCollector status.
+const (
+	// CollectorConditionReady means the collector is reconciled and all its replicas are ready.
+	CollectorConditionReady = "Ready"
+	// CollectorConditionReconciled means the manifests of the collector were built and applied.
+	CollectorConditionReconciled = "Reconciled"
+	// CollectorConditionConfigValid means the manifests of the collector could be built from its configuration.
+	CollectorConditionConfigValid = "ConfigValid"
+	// CollectorConditionDegraded means the collector failed to reconcile, or some of its replicas aren't ready or are
+	// crashlooping.
+	CollectorConditionDegraded = "Degraded"
+	// CollectorConditionConfigRolledBack means the collector runs its last known-good configuration rather than the
+	// one of its spec, because pods running the latter were unhealthy. It's only set when the canary strategy or
+	// automatic rollbacks are used.
+	CollectorConditionConfigRolledBack = "ConfigRolledBack"
+	// CollectorConditionTargetAllocatorReady means all replicas of the target allocator are ready. It's only set
+	// when the target allocator is enabled.
+	CollectorConditionTargetAllocatorReady = "TargetAllocatorReady"
+)
+
 // OpenTelemetryCollectorSpec defines the desired state of OpenTelemetryCollector.
 type OpenTelemetryCollectorSpec struct {
 	// OpenTelemetryCommonFields are fields that are on all OpenTelemetry CRD workloads.
@@ -89,7 +220,7 @@ type OpenTelemetryCollectorSpec struct {
 	// TargetAllocator indicates a value which determines whether to spawn a target allocation resource or not.
 	// +optional
 	TargetAllocator TargetAllocatorEmbedded `json:"targetAllocator,omitempty"`
-	// Mode represents how the collector should be deployed (deployment, daemonset, statefulset or sidecar)
+	// Mode represents how the collector should be deployed (deployment, daemonset, statefulset, sidecar, job or cronjob)
 	// +optional
 	Mode Mode `json:"mode,omitempty"`
 	// UpgradeStrategy represents how the operator will handle upgrades to the CR when a newer version of the operator is deployed
@@ -141,6 +272,31 @@ type OpenTelemetryCollectorSpec struct {
 	// This is only applicable to Deployment mode.
 	// +optional
 	DeploymentUpdateStrategy appsv1.DeploymentStrategy `json:"deploymentUpdateStrategy,omitempty"`
+	// Rollout defines how changes to the configuration are rolled out.
+	// +optional
+	Rollout *RolloutSpec `json:"rollout,omitempty"`
+	// BaseConfig references a base configuration, which Config is merged on top of as a JSON merge patch. Components
+	// set to null in Config are kept as empty objects, other null values are ignored, use ConfigPatches to remove parts of
+	// the base configuration. The resulting configuration is recorded in status.effectiveConfig.
+	// +optional
+	BaseConfig *BaseConfigSource `json:"baseConfig,omitempty"`
+	// ConfigPatches are applied in order to the configuration, after Config was merged on top of BaseConfig.
+	// +optional
+	// +listType=atomic
+	ConfigPatches []ConfigPatch `json:"configPatches,omitempty"`
+	// ConfigReferences expose keys of Secrets and ConfigMaps to the configuration, which refers to them with
+	// ${ref:<name>} placeholders. The operator adds the environment variables and volumes they need to the collector,
+	// and restarts its pods when the referenced values change.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	ConfigReferences []ConfigReference `json:"configReferences,omitempty"`
+	// Job configures the Job running the collector, in the job and cronjob modes.
+	// +optional
+	Job *JobSpec `json:"job,omitempty"`
+	// CronJob configures the schedule of the collector, in the cronjob mode.
+	// +optional
+	CronJob *CronJobSpec `json:"cronJob,omitempty"`
 }
 
 // TargetAllocatorEmbedded defines the configuration for the Prometheus target allocator, embedded in the
@@ -158,12 +314,16 @@ type TargetAllocatorEmbedded struct {
 	// +optional
 	Resources v1.ResourceRequirements `json:"resources,omitempty"`
 	// AllocationStrategy determines which strategy the target allocator should use for allocation.
-	// The current options are least-weighted, consistent-hashing and per-node. The default is
+	// The current options are least-weighted, consistent-hashing, per-node, load-aware and topology-aware. The default is
 	// consistent-hashing.
 	// WARNING: The per-node strategy currently ignores targets without a Node, like control plane components.
 	// +optional
 	// +kubebuilder:default:=consistent-hashing
 	AllocationStrategy TargetAllocatorAllocationStrategy `json:"allocationStrategy,omitempty"`
+	// TopologyKey is the node label whose values the topology-aware strategy keeps targets within, assigning them to
+	// collectors on nodes with the same value. The default is topology.kubernetes.io/zone.
+	// +optional
+	TopologyKey string `json:"topologyKey,omitempty"`
 	// FilterStrategy determines how to filter targets before allocating them among the collectors.
 	// The only current option is relabel-config (drops targets based on prom relabel_config).
 	// The default is relabel-config.
@@ -0,0 +1,147 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type (
+	// RolloutStrategy represents how the operator rolls out changes to the collector's configuration.
+	// +kubebuilder:validation:Enum=all;canary
+	RolloutStrategy string
+
+	// RolloutPhase represents where the rollout of the collector's configuration is at.
+	RolloutPhase string
+)
+
+const (
+	// RolloutStrategyAll rolls configuration changes out to every replica, following the update strategy of the
+	// workload.
+	RolloutStrategyAll RolloutStrategy = "all"
+
+	// RolloutStrategyCanary first rolls configuration changes out to a few canary replicas, and promotes them to
+	// every replica once the canaries stayed healthy for a bake period.
+	RolloutStrategyCanary RolloutStrategy = "canary"
+)
+
+const (
+	// RolloutPhaseStable means every replica runs the stable configuration, which is the last one promoted from
+	// canary replicas, or known to be good.
+	RolloutPhaseStable RolloutPhase = "Stable"
+
+	// RolloutPhaseProgressing means a new configuration is being baked on the canary replicas, or being rolled out
+	// to every replica before it's known to be good.
+	RolloutPhaseProgressing RolloutPhase = "Progressing"
+
+	// RolloutPhaseRolledBack means the pods running a new configuration were unhealthy, and every replica runs the
+	// stable configuration again. The configuration has to change for a new rollout to start.
+	RolloutPhaseRolledBack RolloutPhase = "RolledBack"
+)
+
+// RolloutSpec defines how changes to the collector's configuration are rolled out.
+type RolloutSpec struct {
+	// Strategy determines how configuration changes are rolled out. The current options are all and canary. The
+	// default is all. The canary strategy is only supported in the deployment and statefulset modes.
+	// +optional
+	// +kubebuilder:default:=all
+	Strategy RolloutStrategy `json:"strategy,omitempty"`
+
+	// Canary configures the canary strategy.
+	// +optional
+	Canary CanaryRolloutSpec `json:"canary,omitempty"`
+
+	// AutoRollback configures rolling back to the last known-good configuration with the all strategy. The canary
+	// strategy always rolls back configurations the canary replicas are unhealthy with.
+	// +optional
+	AutoRollback AutoRollbackSpec `json:"autoRollback,omitempty"`
+
+	// RestartOnMountedChanges replaces the pods when the content of a ConfigMap or a Secret they use changes, through
+	// configmaps, volumes, envFrom or the valueFrom of env. It's only supported in the deployment, daemonset and
+	// statefulset modes.
+	// +optional
+	RestartOnMountedChanges bool `json:"restartOnMountedChanges,omitempty"`
+}
+
+// AutoRollbackSpec configures rolling back to the last known-good configuration. A configuration becomes known-good
+// once every pod runs it and stayed ready for a while. When a newer configuration leaves pods crashlooping or not
+// ready for too long, the workload is pointed back at the ConfigMap of the known-good configuration, without changing
+// the spec. The ConfigMap of the known-good configuration is kept regardless of ConfigVersions.
+type AutoRollbackSpec struct {
+	// Enabled turns automatic rollbacks on. It's only supported in the deployment, daemonset and statefulset modes.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// KnownGoodAfter is how long every pod running a configuration has to be ready for it to become known-good.
+	// Defaults to 5m.
+	// +optional
+	// +kubebuilder:default:="5m"
+	KnownGoodAfter metav1.Duration `json:"knownGoodAfter,omitempty"`
+
+	// ProgressDeadline is how long pods running a new configuration may be crashlooping or not ready before it's
+	// rolled back. Defaults to 10m.
+	// +optional
+	// +kubebuilder:default:="10m"
+	ProgressDeadline metav1.Duration `json:"progressDeadline,omitempty"`
+}
+
+// CanaryRolloutSpec configures the canary rollout of configuration changes. In the deployment mode, the canary
+// replicas are run by a second Deployment. In the statefulset mode, they're the highest ordinals of the StatefulSet,
+// updated using a partition.
+// Only changes to the configuration go through the canary, other changes are applied to every replica right away.
+type CanaryRolloutSpec struct {
+	// Replicas is the number of replicas running the new configuration while it's baked. Defaults to 1.
+	// +optional
+	// +kubebuilder:default:=1
+	// +kubebuilder:validation:Minimum:=1
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// BakeDuration is how long the canary replicas have to stay healthy before the new configuration is promoted.
+	// Defaults to 5m.
+	// +optional
+	// +kubebuilder:default:="5m"
+	BakeDuration metav1.Duration `json:"bakeDuration,omitempty"`
+
+	// MaxExportFailurePercent is the share of telemetry the canary replicas may fail to export, as reported by the
+	// otelcol_exporter_send_failed_* and otelcol_exporter_sent_* metrics of the collector. When set, the operator
+	// scrapes the metrics of the canary pods, which has to be allowed by network policies.
+	// +optional
+	// +kubebuilder:validation:Minimum:=0
+	// +kubebuilder:validation:Maximum:=100
+	MaxExportFailurePercent *int32 `json:"maxExportFailurePercent,omitempty"`
+}
+
+// RolloutStatus records the progress of the rollout of the collector's configuration.
+type RolloutStatus struct {
+	// Phase is where the rollout is at.
+	// +optional
+	Phase RolloutPhase `json:"phase,omitempty"`
+
+	// StableConfigHash is the hash of the last configuration promoted to every replica, or known to be good.
+	// +optional
+	StableConfigHash string `json:"stableConfigHash,omitempty"`
+
+	// CanaryConfigHash is the hash of the configuration being rolled out, or of the last one which was rolled back.
+	// +optional
+	CanaryConfigHash string `json:"canaryConfigHash,omitempty"`
+
+	// StartedAt is when the configuration being rolled out was first applied.
+	// +optional
+	StartedAt *metav1.Time `json:"startedAt,omitempty"`
+
+	// Message is a human-readable explanation of the phase, like why the configuration was rolled back.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
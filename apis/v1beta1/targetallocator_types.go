@@ -42,11 +42,29 @@ type TargetAllocatorPrometheusCR struct {
 	// label selector matches no objects.
 	// +optional
 	ServiceMonitorSelector *metav1.LabelSelector `json:"serviceMonitorSelector,omitempty"`
+	// Probes to be selected for target discovery.
+	// A label selector is a label query over a set of resources. The result of matchLabels and
+	// matchExpressions are ANDed. An empty label selector matches all objects. A null
+	// label selector matches no objects, and Probes aren't watched at all.
+	// +optional
+	ProbeSelector *metav1.LabelSelector `json:"probeSelector,omitempty"`
+	// Namespaces to be selected for Probe discovery. A null label selector matches all namespaces.
+	// +optional
+	ProbeNamespaceSelector *metav1.LabelSelector `json:"probeNamespaceSelector,omitempty"`
+	// ScrapeConfigs to be selected for target discovery.
+	// A label selector is a label query over a set of resources. The result of matchLabels and
+	// matchExpressions are ANDed. An empty label selector matches all objects. A null
+	// label selector matches no objects, and ScrapeConfigs aren't watched at all.
+	// +optional
+	ScrapeConfigSelector *metav1.LabelSelector `json:"scrapeConfigSelector,omitempty"`
+	// Namespaces to be selected for ScrapeConfig discovery. A null label selector matches all namespaces.
+	// +optional
+	ScrapeConfigNamespaceSelector *metav1.LabelSelector `json:"scrapeConfigNamespaceSelector,omitempty"`
 }
 
 type (
 	// TargetAllocatorAllocationStrategy represent a strategy Target Allocator uses to distribute targets to each collector
-	// +kubebuilder:validation:Enum=least-weighted;consistent-hashing;per-node
+	// +kubebuilder:validation:Enum=least-weighted;consistent-hashing;per-node;load-aware;topology-aware
 	TargetAllocatorAllocationStrategy string
 	// TargetAllocatorFilterStrategy represent a filtering strategy for targets before they are assigned to collectors
 	// +kubebuilder:validation:Enum="";relabel-config
@@ -63,6 +81,12 @@ const (
 	// TargetAllocatorAllocationStrategyPerNode targets will be assigned to the collector on the node they reside on (use only with daemon set).
 	TargetAllocatorAllocationStrategyPerNode TargetAllocatorAllocationStrategy = "per-node"
 
+	// TargetAllocatorAllocationStrategyLoadAware targets will be distributed to the collector with the lowest total target cost, as reported by collectors.
+	TargetAllocatorAllocationStrategyLoadAware TargetAllocatorAllocationStrategy = "load-aware"
+
+	// TargetAllocatorAllocationStrategyTopologyAware targets will be distributed to the collector with the least targets in the same zone, if there is any.
+	TargetAllocatorAllocationStrategyTopologyAware TargetAllocatorAllocationStrategy = "topology-aware"
+
 	// TargetAllocatorFilterStrategyRelabelConfig targets will be consistently drops targets based on the relabel_config.
 	TargetAllocatorFilterStrategyRelabelConfig TargetAllocatorFilterStrategy = "relabel-config"
 )
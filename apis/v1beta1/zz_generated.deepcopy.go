@@ -27,6 +27,23 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoRollbackSpec) DeepCopyInto(out *AutoRollbackSpec) {
+	*out = *in
+	out.KnownGoodAfter = in.KnownGoodAfter
+	out.ProgressDeadline = in.ProgressDeadline
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoRollbackSpec.
+func (in *AutoRollbackSpec) DeepCopy() *AutoRollbackSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoRollbackSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AutoscalerSpec) DeepCopyInto(out *AutoscalerSpec) {
 	*out = *in
@@ -74,6 +91,154 @@ func (in *AutoscalerSpec) DeepCopy() *AutoscalerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BaseConfigSource) DeepCopyInto(out *BaseConfigSource) {
+	*out = *in
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BaseConfigSource.
+func (in *BaseConfigSource) DeepCopy() *BaseConfigSource {
+	if in == nil {
+		return nil
+	}
+	out := new(BaseConfigSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryRolloutSpec) DeepCopyInto(out *CanaryRolloutSpec) {
+	*out = *in
+	out.BakeDuration = in.BakeDuration
+	if in.MaxExportFailurePercent != nil {
+		in, out := &in.MaxExportFailurePercent, &out.MaxExportFailurePercent
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryRolloutSpec.
+func (in *CanaryRolloutSpec) DeepCopy() *CanaryRolloutSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryRolloutSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CollectorJobStatus) DeepCopyInto(out *CollectorJobStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSuccessfulTime != nil {
+		in, out := &in.LastSuccessfulTime, &out.LastSuccessfulTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CollectorJobStatus.
+func (in *CollectorJobStatus) DeepCopy() *CollectorJobStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CollectorJobStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CollectorNodePodsStatus) DeepCopyInto(out *CollectorNodePodsStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CollectorNodePodsStatus.
+func (in *CollectorNodePodsStatus) DeepCopy() *CollectorNodePodsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CollectorNodePodsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CollectorPodsStatus) DeepCopyInto(out *CollectorPodsStatus) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]CollectorNodePodsStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]CollectorZonePodsStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastTermination != nil {
+		in, out := &in.LastTermination, &out.LastTermination
+		*out = new(CollectorTermination)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CollectorPodsStatus.
+func (in *CollectorPodsStatus) DeepCopy() *CollectorPodsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CollectorPodsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CollectorTermination) DeepCopyInto(out *CollectorTermination) {
+	*out = *in
+	in.FinishedAt.DeepCopyInto(&out.FinishedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CollectorTermination.
+func (in *CollectorTermination) DeepCopy() *CollectorTermination {
+	if in == nil {
+		return nil
+	}
+	out := new(CollectorTermination)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CollectorZonePodsStatus) DeepCopyInto(out *CollectorZonePodsStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CollectorZonePodsStatus.
+func (in *CollectorZonePodsStatus) DeepCopy() *CollectorZonePodsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CollectorZonePodsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Config) DeepCopyInto(out *Config) {
 	*out = *in
@@ -119,6 +284,86 @@ func (in *ConfigMapsSpec) DeepCopy() *ConfigMapsSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigPatch) DeepCopyInto(out *ConfigPatch) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigPatch.
+func (in *ConfigPatch) DeepCopy() *ConfigPatch {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigPatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigReference) DeepCopyInto(out *ConfigReference) {
+	*out = *in
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigReference.
+func (in *ConfigReference) DeepCopy() *ConfigReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CronJobSpec) DeepCopyInto(out *CronJobSpec) {
+	*out = *in
+	if in.TimeZone != nil {
+		in, out := &in.TimeZone, &out.TimeZone
+		*out = new(string)
+		**out = **in
+	}
+	if in.StartingDeadlineSeconds != nil {
+		in, out := &in.StartingDeadlineSeconds, &out.StartingDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Suspend != nil {
+		in, out := &in.Suspend, &out.Suspend
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SuccessfulJobsHistoryLimit != nil {
+		in, out := &in.SuccessfulJobsHistoryLimit, &out.SuccessfulJobsHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailedJobsHistoryLimit != nil {
+		in, out := &in.FailedJobsHistoryLimit, &out.FailedJobsHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CronJobSpec.
+func (in *CronJobSpec) DeepCopy() *CronJobSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CronJobSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Ingress) DeepCopyInto(out *Ingress) {
 	*out = *in
@@ -154,6 +399,41 @@ func (in *Ingress) DeepCopy() *Ingress {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobSpec) DeepCopyInto(out *JobSpec) {
+	*out = *in
+	if in.Completions != nil {
+		in, out := &in.Completions, &out.Completions
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Parallelism != nil {
+		in, out := &in.Parallelism, &out.Parallelism
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BackoffLimit != nil {
+		in, out := &in.BackoffLimit, &out.BackoffLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ActiveDeadlineSeconds != nil {
+		in, out := &in.ActiveDeadlineSeconds, &out.ActiveDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobSpec.
+func (in *JobSpec) DeepCopy() *JobSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(JobSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MetricSpec) DeepCopyInto(out *MetricSpec) {
 	*out = *in
@@ -325,6 +605,38 @@ func (in *OpenTelemetryCollectorSpec) DeepCopyInto(out *OpenTelemetryCollectorSp
 	}
 	in.DaemonSetUpdateStrategy.DeepCopyInto(&out.DaemonSetUpdateStrategy)
 	in.DeploymentUpdateStrategy.DeepCopyInto(&out.DeploymentUpdateStrategy)
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(RolloutSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BaseConfig != nil {
+		in, out := &in.BaseConfig, &out.BaseConfig
+		*out = new(BaseConfigSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigPatches != nil {
+		in, out := &in.ConfigPatches, &out.ConfigPatches
+		*out = make([]ConfigPatch, len(*in))
+		copy(*out, *in)
+	}
+	if in.ConfigReferences != nil {
+		in, out := &in.ConfigReferences, &out.ConfigReferences
+		*out = make([]ConfigReference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Job != nil {
+		in, out := &in.Job, &out.Job
+		*out = new(JobSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CronJob != nil {
+		in, out := &in.CronJob, &out.CronJob
+		*out = new(CronJobSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenTelemetryCollectorSpec.
@@ -341,6 +653,28 @@ func (in *OpenTelemetryCollectorSpec) DeepCopy() *OpenTelemetryCollectorSpec {
 func (in *OpenTelemetryCollectorStatus) DeepCopyInto(out *OpenTelemetryCollectorStatus) {
 	*out = *in
 	out.Scale = in.Scale
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Pods != nil {
+		in, out := &in.Pods, &out.Pods
+		*out = new(CollectorPodsStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(RolloutStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Job != nil {
+		in, out := &in.Job, &out.Job
+		*out = new(CollectorJobStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenTelemetryCollectorStatus.
@@ -604,6 +938,42 @@ func (in *Probe) DeepCopy() *Probe {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutSpec) DeepCopyInto(out *RolloutSpec) {
+	*out = *in
+	in.Canary.DeepCopyInto(&out.Canary)
+	out.AutoRollback = in.AutoRollback
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutSpec.
+func (in *RolloutSpec) DeepCopy() *RolloutSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutStatus) DeepCopyInto(out *RolloutStatus) {
+	*out = *in
+	if in.StartedAt != nil {
+		in, out := &in.StartedAt, &out.StartedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStatus.
+func (in *RolloutStatus) DeepCopy() *RolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScaleSubresourceStatus) DeepCopyInto(out *ScaleSubresourceStatus) {
 	*out = *in
@@ -774,6 +1144,26 @@ func (in *TargetAllocatorPrometheusCR) DeepCopyInto(out *TargetAllocatorPromethe
 		*out = new(metav1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ProbeSelector != nil {
+		in, out := &in.ProbeSelector, &out.ProbeSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProbeNamespaceSelector != nil {
+		in, out := &in.ProbeNamespaceSelector, &out.ProbeNamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ScrapeConfigSelector != nil {
+		in, out := &in.ScrapeConfigSelector, &out.ScrapeConfigSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ScrapeConfigNamespaceSelector != nil {
+		in, out := &in.ScrapeConfigNamespaceSelector, &out.ScrapeConfigNamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetAllocatorPrometheusCR.
@@ -16,7 +16,9 @@ package allocation
 
 import (
 	"errors"
+	"sort"
 	"sync"
+	"sync/atomic"
 
 	"github.com/go-logr/logr"
 	"github.com/prometheus/client_golang/prometheus"
@@ -40,6 +42,8 @@ func newAllocator(log logr.Logger, strategy Strategy, opts ...AllocationOption)
 		collectors:                    make(map[string]*Collector),
 		targetItems:                   make(map[string]*target.Item),
 		targetItemsPerJobPerCollector: make(map[string]map[string]map[string]bool),
+		targetCosts:                   make(map[string]float64),
+		changed:                       make(chan struct{}),
 		log:                           log,
 	}
 	for _, opt := range opts {
@@ -63,12 +67,23 @@ type allocator struct {
 	// collectorKey -> job -> target item hash -> true
 	targetItemsPerJobPerCollector map[string]map[string]map[string]bool
 
-	// m protects collectors, targetItems and targetItemsPerJobPerCollector for concurrent use.
+	// targetCosts holds the cost each assigned target contributes to its collector's load
+	// targetItem hash -> cost
+	targetCosts map[string]float64
+
+	// m protects collectors, targetItems, targetItemsPerJobPerCollector and targetCosts for concurrent use.
 	m sync.RWMutex
 
+	// generation is incremented with m held whenever the allocation changes, it can be read without the lock.
+	generation atomic.Uint64
+	// changed is closed and replaced with m held whenever generation is incremented.
+	changed chan struct{}
+
 	log logr.Logger
 
 	filter Filter
+
+	costs CostSource
 }
 
 // SetFilter sets the filtering hook to use.
@@ -76,6 +91,134 @@ func (a *allocator) SetFilter(filter Filter) {
 	a.filter = filter
 }
 
+// SetCostSource sets the source of target costs, and passes it on to the strategy if it makes use of it.
+func (a *allocator) SetCostSource(costs CostSource) {
+	a.m.Lock()
+	defer a.m.Unlock()
+	a.costs = costs
+	if s, ok := a.strategy.(costAwareStrategy); ok {
+		s.SetCostSource(costs)
+	}
+}
+
+// SetTopology passes the topology key and source on to the strategy if it makes use of them.
+func (a *allocator) SetTopology(key string, nodes TopologySource) {
+	a.m.Lock()
+	defer a.m.Unlock()
+	if s, ok := a.strategy.(topologySensitiveStrategy); ok {
+		s.SetTopology(key, nodes)
+	}
+}
+
+// CostAware returns whether the strategy takes the costs of the targets into account.
+func (a *allocator) CostAware() bool {
+	_, ok := a.strategy.(costAwareStrategy)
+	return ok
+}
+
+// Rebalance refreshes the costs of all assigned targets and then re-runs the allocation for every target, starting
+// with the most expensive ones. It does nothing for the strategies which don't consider cost, which keep their
+// current assignments.
+func (a *allocator) Rebalance() {
+	if !a.CostAware() {
+		return
+	}
+	timer := prometheus.NewTimer(TimeToAssign.WithLabelValues("Rebalance", a.strategy.GetName()))
+	defer timer.ObserveDuration()
+
+	a.m.Lock()
+	defer a.m.Unlock()
+
+	if len(a.collectors) == 0 {
+		return
+	}
+
+	costs := make(map[string]float64, len(a.targetItems))
+	hashes := make([]string, 0, len(a.targetItems))
+	for hash, item := range a.targetItems {
+		cost := targetCost(a.costs, item)
+		costs[hash] = cost
+		hashes = append(hashes, hash)
+		previous, ok := a.targetCosts[hash]
+		if !ok {
+			continue
+		}
+		if col, found := a.collectors[item.CollectorName]; found {
+			col.Load += cost - previous
+		}
+		a.targetCosts[hash] = cost
+	}
+	for _, col := range a.collectors {
+		CostPerCollector.WithLabelValues(col.Name, a.strategy.GetName()).Set(col.Load)
+	}
+	sort.Slice(hashes, func(i, j int) bool {
+		if costs[hashes[i]] != costs[hashes[j]] {
+			return costs[hashes[i]] > costs[hashes[j]]
+		}
+		return hashes[i] < hashes[j]
+	})
+
+	assignmentErrors := []error{}
+	moved := false
+	for _, hash := range hashes {
+		item := a.targetItems[hash]
+		previous := item.CollectorName
+		err := a.addTargetToTargetItems(item)
+		if err != nil {
+			assignmentErrors = append(assignmentErrors, err)
+		}
+		moved = moved || item.CollectorName != previous
+	}
+	if moved {
+		a.nextGeneration()
+	}
+	unassignedTargets := len(assignmentErrors)
+	if unassignedTargets > 0 {
+		err := errors.Join(assignmentErrors...)
+		a.log.Info("Could not assign targets for some jobs", "targets", unassignedTargets, "error", err)
+		TargetsUnassigned.Set(float64(unassignedTargets))
+	}
+}
+
+// RestoreAssignments seeds the allocator with previously persisted collectors and targets, without asking the
+// strategy. Targets which were assigned to a collector that isn't part of the restored ones are left unassigned.
+// Later calls to SetTargets and SetCollectors reconcile the restored state like any other change, so restored
+// assignments that are still valid are kept. It does nothing if the allocator isn't empty.
+func (a *allocator) RestoreAssignments(collectors map[string]*Collector, targets map[string]*target.Item) {
+	a.m.Lock()
+	defer a.m.Unlock()
+
+	if len(a.collectors) > 0 || len(a.targetItems) > 0 {
+		a.log.Info("Not restoring assignments, the allocator already has state")
+		return
+	}
+	for _, c := range collectors {
+		col := NewCollector(c.Name, c.NodeName)
+		col.TopologyLabels = c.TopologyLabels
+		a.collectors[c.Name] = col
+	}
+	a.strategy.SetCollectors(a.collectors)
+	for hash, item := range targets {
+		a.targetItems[hash] = item
+		col, ok := a.collectors[item.CollectorName]
+		if !ok {
+			item.CollectorName = ""
+			continue
+		}
+		a.addCollectorTargetItemMapping(item)
+		cost := targetCost(a.costs, item)
+		a.targetCosts[hash] = cost
+		col.NumTargets++
+		col.Load += cost
+	}
+	for _, col := range a.collectors {
+		TargetsPerCollector.WithLabelValues(col.Name, a.strategy.GetName()).Set(float64(col.NumTargets))
+		CostPerCollector.WithLabelValues(col.Name, a.strategy.GetName()).Set(col.Load)
+	}
+	CollectorsAllocatable.WithLabelValues(a.strategy.GetName()).Set(float64(len(a.collectors)))
+	a.nextGeneration()
+}
+
 // SetTargets accepts a list of targets that will be used to make
 // load balancing decisions. This method should be called when there are
 // new targets discovered or existing targets are shutdown.
@@ -96,6 +239,7 @@ func (a *allocator) SetTargets(targets map[string]*target.Item) {
 	// If there are any additions or removals
 	if len(targetsDiff.Additions()) != 0 || len(targetsDiff.Removals()) != 0 {
 		a.handleTargets(targetsDiff)
+		a.nextGeneration()
 	}
 }
 
@@ -117,9 +261,30 @@ func (a *allocator) SetCollectors(collectors map[string]*Collector) {
 	collectorsDiff := diff.Maps(a.collectors, collectors)
 	if len(collectorsDiff.Additions()) != 0 || len(collectorsDiff.Removals()) != 0 {
 		a.handleCollectors(collectorsDiff)
+		a.nextGeneration()
 	}
 }
 
+// Generation returns the current allocation generation.
+func (a *allocator) Generation() uint64 {
+	return a.generation.Load()
+}
+
+// Changed returns a channel which is closed the next time the allocation generation increases.
+func (a *allocator) Changed() <-chan struct{} {
+	a.m.RLock()
+	defer a.m.RUnlock()
+	return a.changed
+}
+
+// nextGeneration increments the allocation generation and wakes up everyone waiting for it to change. The caller of
+// this method has to acquire a lock.
+func (a *allocator) nextGeneration() {
+	a.generation.Add(1)
+	close(a.changed)
+	a.changed = make(chan struct{})
+}
+
 func (a *allocator) GetTargetsForCollectorAndJob(collector string, job string) []*target.Item {
 	a.m.RLock()
 	defer a.m.RUnlock()
@@ -138,6 +303,25 @@ func (a *allocator) GetTargetsForCollectorAndJob(collector string, job string) [
 	return targetItemsCopy
 }
 
+// CollectorTargets returns the targets assigned to the collector keyed by job name, along with the generation they
+// belong to and a channel which is closed the next time it increases, all read under the allocator lock.
+func (a *allocator) CollectorTargets(collector string) (map[string][]*target.Item, uint64, <-chan struct{}) {
+	a.m.RLock()
+	defer a.m.RUnlock()
+	targets := map[string][]*target.Item{}
+	for job, targetHashes := range a.targetItemsPerJobPerCollector[collector] {
+		if len(targetHashes) == 0 {
+			continue
+		}
+		items := make([]*target.Item, 0, len(targetHashes))
+		for targetHash := range targetHashes {
+			items = append(items, a.targetItems[targetHash])
+		}
+		targets[job] = items
+	}
+	return targets, a.generation.Load(), a.changed
+}
+
 // TargetItems returns a shallow copy of the targetItems map.
 func (a *allocator) TargetItems() map[string]*target.Item {
 	a.m.RLock()
@@ -149,6 +333,18 @@ func (a *allocator) TargetItems() map[string]*target.Item {
 	return targetItemsCopy
 }
 
+// TargetAssignments returns every target along with the name of the collector it's assigned to, copied under the
+// allocator lock.
+func (a *allocator) TargetAssignments() []TargetAssignment {
+	a.m.RLock()
+	defer a.m.RUnlock()
+	assignments := make([]TargetAssignment, 0, len(a.targetItems))
+	for _, item := range a.targetItems {
+		assignments = append(assignments, TargetAssignment{Item: item, Collector: item.CollectorName})
+	}
+	return assignments
+}
+
 // Collectors returns a shallow copy of the collectors map.
 func (a *allocator) Collectors() map[string]*Collector {
 	a.m.RLock()
@@ -218,8 +414,12 @@ func (a *allocator) addTargetToTargetItems(tg *target.Item) error {
 
 	tg.CollectorName = colOwner.Name
 	a.addCollectorTargetItemMapping(tg)
+	cost := targetCost(a.costs, tg)
+	a.targetCosts[tg.Hash()] = cost
 	a.collectors[colOwner.Name].NumTargets++
+	a.collectors[colOwner.Name].Load += cost
 	TargetsPerCollector.WithLabelValues(colOwner.String(), a.strategy.GetName()).Set(float64(a.collectors[colOwner.String()].NumTargets))
+	CostPerCollector.WithLabelValues(colOwner.String(), a.strategy.GetName()).Set(a.collectors[colOwner.String()].Load)
 
 	return nil
 }
@@ -235,7 +435,10 @@ func (a *allocator) unassignTargetItem(item *target.Item) {
 		return
 	}
 	c.NumTargets--
+	c.Load -= a.targetCosts[item.Hash()]
+	delete(a.targetCosts, item.Hash())
 	TargetsPerCollector.WithLabelValues(item.CollectorName, a.strategy.GetName()).Set(float64(c.NumTargets))
+	CostPerCollector.WithLabelValues(item.CollectorName, a.strategy.GetName()).Set(c.Load)
 	delete(a.targetItemsPerJobPerCollector[item.CollectorName][item.JobName], item.Hash())
 	if len(a.targetItemsPerJobPerCollector[item.CollectorName][item.JobName]) == 0 {
 		delete(a.targetItemsPerJobPerCollector[item.CollectorName], item.JobName)
@@ -256,10 +459,12 @@ func (a *allocator) removeCollector(collector *Collector) {
 	for _, targetItems := range a.targetItemsPerJobPerCollector[collector.Name] {
 		for targetHash := range targetItems {
 			a.targetItems[targetHash].CollectorName = ""
+			delete(a.targetCosts, targetHash)
 		}
 	}
 	delete(a.targetItemsPerJobPerCollector, collector.Name)
 	TargetsPerCollector.WithLabelValues(collector.Name, a.strategy.GetName()).Set(0)
+	CostPerCollector.WithLabelValues(collector.Name, a.strategy.GetName()).Set(0)
 }
 
 // addCollectorTargetItemMapping keeps track of which collector has which jobs and targets
@@ -285,7 +490,9 @@ func (a *allocator) handleCollectors(diff diff.Changes[*Collector]) {
 	}
 	// Insert the new collectors
 	for _, i := range diff.Additions() {
-		a.collectors[i.Name] = NewCollector(i.Name, i.NodeName)
+		col := NewCollector(i.Name, i.NodeName)
+		col.TopologyLabels = i.TopologyLabels
+		a.collectors[i.Name] = col
 	}
 
 	// Set collectors on the strategy
@@ -77,6 +77,43 @@ func TestCanSetTargetsBeforeCollectors(t *testing.T) {
 	})
 }
 
+func TestTargetAssignments(t *testing.T) {
+	RunForAllStrategies(t, func(t *testing.T, allocator Allocator) {
+		cols := MakeNCollectors(3, 0)
+		targets := MakeNNewTargetsWithEmptyCollectors(6, 0)
+		allocator.SetCollectors(cols)
+		allocator.SetTargets(targets)
+
+		assignments := allocator.TargetAssignments()
+		assert.Len(t, assignments, len(targets))
+		for _, assignment := range assignments {
+			assert.Contains(t, cols, assignment.Collector)
+			assert.Equal(t, assignment.Item.CollectorName, assignment.Collector)
+		}
+	})
+}
+
+func TestCollectorTargets(t *testing.T) {
+	RunForAllStrategies(t, func(t *testing.T, allocator Allocator) {
+		cols := MakeNCollectors(3, 0)
+		targets := MakeNNewTargetsWithEmptyCollectors(6, 0)
+		allocator.SetCollectors(cols)
+		allocator.SetTargets(targets)
+
+		total := 0
+		for name := range cols {
+			jobs, generation, changed := allocator.CollectorTargets(name)
+			assert.Equal(t, allocator.Generation(), generation)
+			assert.Equal(t, allocator.Changed(), changed)
+			for job, items := range jobs {
+				assert.ElementsMatch(t, allocator.GetTargetsForCollectorAndJob(name, job), items)
+				total += len(items)
+			}
+		}
+		assert.Equal(t, len(targets), total)
+	})
+}
+
 func TestAddingAndRemovingTargets(t *testing.T) {
 	RunForAllStrategies(t, func(t *testing.T, allocator Allocator) {
 		cols := MakeNCollectors(3, 0)
@@ -205,3 +242,32 @@ func TestAllocationCollision(t *testing.T) {
 		}
 	})
 }
+
+func TestGeneration(t *testing.T) {
+	RunForAllStrategies(t, func(t *testing.T, allocator Allocator) {
+		assert.Equal(t, uint64(0), allocator.Generation())
+
+		cols := MakeNCollectors(3, 0)
+		allocator.SetCollectors(cols)
+		assert.Equal(t, uint64(1), allocator.Generation())
+
+		targets := MakeNNewTargets(10, 3, 0)
+		allocator.SetTargets(targets)
+		assert.Equal(t, uint64(2), allocator.Generation())
+
+		// setting the same targets and collectors again doesn't change anything
+		allocator.SetTargets(targets)
+		allocator.SetCollectors(cols)
+		assert.Equal(t, uint64(2), allocator.Generation())
+
+		changed := allocator.Changed()
+		allocator.SetCollectors(MakeNCollectors(2, 0))
+		assert.Equal(t, uint64(3), allocator.Generation())
+		select {
+		case <-changed:
+		default:
+			assert.Fail(t, "the changed channel should be closed when the generation increases")
+		}
+		assert.NotEqual(t, changed, allocator.Changed())
+	})
+}
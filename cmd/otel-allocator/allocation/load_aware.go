@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package allocation
+
+import (
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/target"
+)
+
+const (
+	loadAwareStrategyName = "load-aware"
+
+	// loadAwareTolerance is the hysteresis applied before moving an already assigned target. A target only moves
+	// if its current collector carries more than (1 + loadAwareTolerance) times the load of the least loaded one.
+	loadAwareTolerance = 0.2
+)
+
+var _ Strategy = &loadAwareStrategy{}
+var _ costAwareStrategy = &loadAwareStrategy{}
+
+// loadAwareStrategy assigns targets to the collector with the lowest total target cost, as reported by a CostSource.
+// Without a CostSource, every target has the same cost and this behaves like the least-weighted strategy.
+type loadAwareStrategy struct {
+	costs CostSource
+}
+
+func newLoadAwareStrategy() Strategy {
+	return &loadAwareStrategy{}
+}
+
+func (s *loadAwareStrategy) GetName() string {
+	return loadAwareStrategyName
+}
+
+func (s *loadAwareStrategy) SetCostSource(costs CostSource) {
+	s.costs = costs
+}
+
+func (s *loadAwareStrategy) GetCollectorForTarget(collectors map[string]*Collector, item *target.Item) (*Collector, error) {
+	var least *Collector
+	for _, col := range collectors {
+		if least == nil || lessLoaded(col, least) {
+			least = col
+		}
+	}
+
+	current, ok := collectors[item.CollectorName]
+	if !ok || item.CollectorName == "" || least == nil || current == least {
+		return least, nil
+	}
+
+	// the current collector's load already includes this target, so only move it if the least loaded collector stays
+	// below the current one after the move, and the imbalance is above the tolerance
+	cost := targetCost(s.costs, item)
+	if current.Load <= least.Load*(1+loadAwareTolerance) || least.Load+cost >= current.Load {
+		return current, nil
+	}
+	return least, nil
+}
+
+func (s *loadAwareStrategy) SetCollectors(_ map[string]*Collector) {}
+
+// lessLoaded orders collectors by load, then by number of targets, then by name, so that assignment is deterministic.
+func lessLoaded(a, b *Collector) bool {
+	if a.Load != b.Load {
+		return a.Load < b.Load
+	}
+	if a.NumTargets != b.NumTargets {
+		return a.NumTargets < b.NumTargets
+	}
+	return a.Name < b.Name
+}
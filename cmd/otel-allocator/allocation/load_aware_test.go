@@ -0,0 +1,162 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package allocation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/target"
+)
+
+var _ CostSource = staticCosts{}
+
+// staticCosts returns a fixed cost per target hash, defaulting to 1.
+type staticCosts map[string]float64
+
+func (c staticCosts) TargetCost(item *target.Item) float64 {
+	if cost, ok := c[item.Hash()]; ok {
+		return cost
+	}
+	return 1
+}
+
+func collectorLoads(collectors map[string]*Collector) []float64 {
+	var loads []float64
+	for _, col := range collectors {
+		loads = append(loads, col.Load)
+	}
+	return loads
+}
+
+func TestLoadAwareWithoutCostsBalancesTargetCount(t *testing.T) {
+	s, err := New(loadAwareStrategyName, logger)
+	require.NoError(t, err)
+
+	s.SetCollectors(MakeNCollectors(3, 0))
+	s.SetTargets(MakeNNewTargets(30, 3, 0))
+
+	for _, col := range s.Collectors() {
+		assert.Equal(t, 10, col.NumTargets)
+		assert.Equal(t, float64(10), col.Load)
+	}
+}
+
+func TestLoadAwareBalancesCost(t *testing.T) {
+	costs := staticCosts{}
+	targets := MakeNNewTargets(12, 3, 0)
+	var expensive []string
+	for hash := range targets {
+		if len(expensive) < 3 {
+			// three very expensive targets, like kube-state-metrics
+			costs[hash] = 100
+			expensive = append(expensive, hash)
+		}
+	}
+
+	s, err := New(loadAwareStrategyName, logger, WithCostSource(costs))
+	require.NoError(t, err)
+	s.SetCollectors(MakeNCollectors(3, 0))
+	s.SetTargets(targets)
+	s.Rebalance()
+
+	// the expensive targets should each end up on a different collector
+	owners := map[string]bool{}
+	items := s.TargetItems()
+	for _, hash := range expensive {
+		owners[items[hash].CollectorName] = true
+	}
+	assert.Len(t, owners, 3)
+	for _, load := range collectorLoads(s.Collectors()) {
+		assert.InDelta(t, 103, load, 103*loadAwareTolerance)
+	}
+}
+
+func TestLoadAwareHysteresis(t *testing.T) {
+	costs := staticCosts{}
+	s, err := New(loadAwareStrategyName, logger, WithCostSource(costs))
+	require.NoError(t, err)
+	s.SetCollectors(MakeNCollectors(2, 0))
+	s.SetTargets(MakeNNewTargets(10, 2, 0))
+
+	before := map[string]string{}
+	for hash, item := range s.TargetItems() {
+		before[hash] = item.CollectorName
+	}
+
+	// a small change in cost is within the tolerance, nothing should move
+	for hash := range before {
+		costs[hash] = 1.1
+		break
+	}
+	s.Rebalance()
+	for hash, item := range s.TargetItems() {
+		assert.Equal(t, before[hash], item.CollectorName)
+	}
+
+	// a large change should move targets away from the overloaded collector
+	var heavy string
+	for hash := range before {
+		heavy = hash
+		costs[hash] = 10
+		break
+	}
+	s.Rebalance()
+	moved := 0
+	items := s.TargetItems()
+	for hash, item := range items {
+		if before[hash] != item.CollectorName {
+			moved++
+		}
+	}
+	assert.Positive(t, moved)
+	assert.Equal(t, before[heavy], items[heavy].CollectorName, "the expensive target itself should stay put")
+	loads := collectorLoads(s.Collectors())
+	assert.InDelta(t, loads[0], loads[1], 10*loadAwareTolerance+1)
+}
+
+func TestLoadAwareCollectorRemoval(t *testing.T) {
+	s, err := New(loadAwareStrategyName, logger)
+	require.NoError(t, err)
+	s.SetCollectors(MakeNCollectors(3, 0))
+	s.SetTargets(MakeNNewTargets(30, 3, 0))
+
+	s.SetCollectors(MakeNCollectors(2, 0))
+	for _, col := range s.Collectors() {
+		assert.Equal(t, 15, col.NumTargets)
+		assert.Equal(t, float64(15), col.Load)
+	}
+}
+
+func TestCostAware(t *testing.T) {
+	for _, name := range GetRegisteredAllocatorNames() {
+		s, err := New(name, logger)
+		require.NoError(t, err)
+		assert.Equal(t, name == loadAwareStrategyName, s.CostAware(), name)
+	}
+}
+
+func TestRebalanceIgnoresStrategiesWithoutCosts(t *testing.T) {
+	s, err := New(perNodeStrategyName, logger, WithCostSource(staticCosts{}))
+	require.NoError(t, err)
+	s.SetCollectors(MakeNCollectors(3, 0))
+	s.SetTargets(MakeNNewTargets(9, 3, 0))
+	generation := s.Generation()
+
+	s.Rebalance()
+	assert.Equal(t, generation, s.Generation())
+}
@@ -26,6 +26,12 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/target"
 )
 
+// TargetAssignment is a target along with the name of the collector it was assigned to when it was read.
+type TargetAssignment struct {
+	Item      *target.Item
+	Collector string
+}
+
 type AllocatorProvider func(log logr.Logger, opts ...AllocationOption) Allocator
 
 var (
@@ -53,6 +59,11 @@ var (
 		Name: "opentelemetry_allocator_targets_unassigned",
 		Help: "Number of targets that could not be assigned due to missing node label.",
 	})
+	// CostPerCollector records the total cost of the targets assigned to each collector.
+	CostPerCollector = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "opentelemetry_allocator_cost_per_collector",
+		Help: "The total cost of the targets assigned to each collector.",
+	}, []string{"collector_name", "strategy"})
 )
 
 type AllocationOption func(Allocator)
@@ -67,6 +78,41 @@ func WithFilter(filter Filter) AllocationOption {
 	}
 }
 
+// CostSource provides the observed cost of scraping a target, for example its series or sample count.
+type CostSource interface {
+	// TargetCost returns the cost of the given target. Implementations should return a sensible default for targets
+	// they have no observations for.
+	TargetCost(item *target.Item) float64
+}
+
+// WithCostSource sets the CostSource used to weigh targets.
+func WithCostSource(costs CostSource) AllocationOption {
+	return func(allocator Allocator) {
+		allocator.SetCostSource(costs)
+	}
+}
+
+// TopologySource provides the topology labels of Kubernetes Nodes, like the zone they run in.
+type TopologySource interface {
+	// NodeTopologyLabels returns the topology labels of the given Node, or nil if they aren't known.
+	NodeTopologyLabels(nodeName string) map[string]string
+}
+
+// WithTopology sets the Node label whose values define topology domains, and the source of Node topology labels.
+func WithTopology(key string, nodes TopologySource) AllocationOption {
+	return func(allocator Allocator) {
+		allocator.SetTopology(key, nodes)
+	}
+}
+
+// targetCost returns the cost of the target according to costs, or 1 if costs is nil.
+func targetCost(costs CostSource, item *target.Item) float64 {
+	if costs == nil {
+		return 1
+	}
+	return costs.TargetCost(item)
+}
+
 func RecordTargetsKept(targets map[string]*target.Item) {
 	targetsRemaining.Add(float64(len(targets)))
 }
@@ -98,9 +144,30 @@ type Allocator interface {
 	SetCollectors(collectors map[string]*Collector)
 	SetTargets(targets map[string]*target.Item)
 	TargetItems() map[string]*target.Item
+	// TargetAssignments returns every target along with the collector it's assigned to. Unlike the CollectorName of
+	// the items TargetItems returns, the collector names are read under the allocator lock.
+	TargetAssignments() []TargetAssignment
 	Collectors() map[string]*Collector
 	GetTargetsForCollectorAndJob(collector string, job string) []*target.Item
+	// CollectorTargets returns the targets assigned to the collector keyed by job name, along with the generation they
+	// belong to and the channel Changed would return, as one consistent snapshot.
+	CollectorTargets(collector string) (map[string][]*target.Item, uint64, <-chan struct{})
 	SetFilter(filter Filter)
+	SetCostSource(costs CostSource)
+	SetTopology(key string, nodes TopologySource)
+	// RestoreAssignments seeds an empty allocator with previously persisted collectors and targets, keeping the
+	// collector each target was assigned to.
+	RestoreAssignments(collectors map[string]*Collector, targets map[string]*target.Item)
+	// CostAware returns whether the strategy takes the costs of the targets into account, and so whether Rebalance
+	// can move targets.
+	CostAware() bool
+	// Rebalance refreshes the cost of every target and gives the strategy a chance to reassign them.
+	Rebalance()
+	// Generation returns a number which increases every time the targets, the collectors, or the assignments between
+	// them change.
+	Generation() uint64
+	// Changed returns a channel which is closed the next time the generation increases.
+	Changed() <-chan struct{}
 }
 
 type Strategy interface {
@@ -112,6 +179,16 @@ type Strategy interface {
 	GetName() string
 }
 
+// costAwareStrategy is implemented by strategies which take target costs into account.
+type costAwareStrategy interface {
+	SetCostSource(CostSource)
+}
+
+// topologySensitiveStrategy is implemented by strategies which take the topology of targets and collectors into account.
+type topologySensitiveStrategy interface {
+	SetTopology(key string, nodes TopologySource)
+}
+
 var _ consistent.Member = Collector{}
 
 // Collector Creates a struct that holds Collector information.
@@ -121,6 +198,10 @@ type Collector struct {
 	Name       string
 	NodeName   string
 	NumTargets int
+	// Load is the sum of the costs of the targets assigned to this Collector.
+	Load float64
+	// TopologyLabels are the topology labels of the Node this Collector runs on, like its zone.
+	TopologyLabels map[string]string
 }
 
 func (c Collector) Hash() string {
@@ -148,10 +229,22 @@ func init() {
 	if err != nil {
 		panic(err)
 	}
+	err = Register(loadAwareStrategyName, func(log logr.Logger, opts ...AllocationOption) Allocator {
+		return newAllocator(log, newLoadAwareStrategy(), opts...)
+	})
+	if err != nil {
+		panic(err)
+	}
 	err = Register(perNodeStrategyName, func(log logr.Logger, opts ...AllocationOption) Allocator {
 		return newAllocator(log, newPerNodeStrategy(), opts...)
 	})
 	if err != nil {
 		panic(err)
 	}
+	err = Register(TopologyAwareStrategyName, func(log logr.Logger, opts ...AllocationOption) Allocator {
+		return newAllocator(log, newTopologyAwareStrategy(), opts...)
+	})
+	if err != nil {
+		panic(err)
+	}
 }
@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package allocation
+
+import (
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/util/strutil"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/target"
+)
+
+// TopologyAwareStrategyName is exported so the collector watcher only watches Nodes when this strategy is in use.
+const TopologyAwareStrategyName = "topology-aware"
+
+const (
+	// nodeLabelPrefix prefixes the Node labels attached to targets by Kubernetes service discovery.
+	nodeLabelPrefix = "__meta_kubernetes_node_label_"
+	// endpointSliceZoneLabel holds the zone of an endpoint discovered through EndpointSlices.
+	endpointSliceZoneLabel model.LabelName = "__meta_kubernetes_endpointslice_endpoint_zone"
+)
+
+var _ Strategy = &topologyAwareStrategy{}
+
+// topologyAwareStrategy assigns targets to the collector with the least targets in the same topology domain, like the
+// same zone. Targets whose domain isn't known, or whose domain has no collectors, go to the collector with the least
+// targets overall.
+type topologyAwareStrategy struct {
+	key   string
+	nodes TopologySource
+	// collectorsByDomain holds the collectors in each topology domain, by name
+	collectorsByDomain map[string]map[string]*Collector
+}
+
+func newTopologyAwareStrategy() Strategy {
+	return &topologyAwareStrategy{
+		key:                v1.LabelTopologyZone,
+		collectorsByDomain: make(map[string]map[string]*Collector),
+	}
+}
+
+func (s *topologyAwareStrategy) GetName() string {
+	return TopologyAwareStrategyName
+}
+
+// SetTopology sets the Node label whose values are topology domains, and where to look up the labels of target Nodes.
+func (s *topologyAwareStrategy) SetTopology(key string, nodes TopologySource) {
+	if key != "" {
+		s.key = key
+	}
+	s.nodes = nodes
+}
+
+func (s *topologyAwareStrategy) GetCollectorForTarget(collectors map[string]*Collector, item *target.Item) (*Collector, error) {
+	candidates := collectors
+	if inDomain, ok := s.collectorsByDomain[s.targetDomain(item)]; ok {
+		candidates = inDomain
+	}
+
+	// keep the current assignment as long as it's still a candidate
+	if item.CollectorName != "" {
+		if col, ok := candidates[item.CollectorName]; ok {
+			return col, nil
+		}
+	}
+
+	var col *Collector
+	for _, v := range candidates {
+		if col == nil || v.NumTargets < col.NumTargets || (v.NumTargets == col.NumTargets && v.Name < col.Name) {
+			col = v
+		}
+	}
+	return col, nil
+}
+
+func (s *topologyAwareStrategy) SetCollectors(collectors map[string]*Collector) {
+	clear(s.collectorsByDomain)
+	for _, collector := range collectors {
+		domain := collector.TopologyLabels[s.key]
+		if domain == "" {
+			continue
+		}
+		if _, ok := s.collectorsByDomain[domain]; !ok {
+			s.collectorsByDomain[domain] = make(map[string]*Collector)
+		}
+		s.collectorsByDomain[domain][collector.Name] = collector
+	}
+}
+
+// targetDomain returns the topology domain of the target, or an empty string if it isn't known. The domain is taken
+// from the Node labels attached by service discovery if there are any, and otherwise from the labels of the Node the
+// target runs on.
+func (s *topologyAwareStrategy) targetDomain(item *target.Item) string {
+	if domain, ok := item.Labels[model.LabelName(nodeLabelPrefix+strutil.SanitizeLabelName(s.key))]; ok {
+		return string(domain)
+	}
+	if s.key == v1.LabelTopologyZone {
+		if domain, ok := item.Labels[endpointSliceZoneLabel]; ok {
+			return string(domain)
+		}
+	}
+	if s.nodes == nil {
+		return ""
+	}
+	nodeName := item.GetNodeName()
+	if nodeName == "" {
+		return ""
+	}
+	return s.nodes.NodeTopologyLabels(nodeName)[s.key]
+}
@@ -0,0 +1,146 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package allocation
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/target"
+)
+
+var _ TopologySource = staticTopology{}
+
+// staticTopology maps Node names to their zone.
+type staticTopology map[string]string
+
+func (t staticTopology) NodeTopologyLabels(nodeName string) map[string]string {
+	zone, ok := t[nodeName]
+	if !ok {
+		return nil
+	}
+	return map[string]string{"topology.kubernetes.io/zone": zone}
+}
+
+func zonedCollector(name, node, zone string) *Collector {
+	col := NewCollector(name, node)
+	col.TopologyLabels = map[string]string{"topology.kubernetes.io/zone": zone}
+	return col
+}
+
+func zonedTargets(n int, zone string) map[string]*target.Item {
+	items := map[string]*target.Item{}
+	for i := 0; i < n; i++ {
+		item := target.NewItem("job", fmt.Sprintf("%s-%d:8080", zone, i), model.LabelSet{
+			"__meta_kubernetes_node_label_topology_kubernetes_io_zone": model.LabelValue(zone),
+		}, "")
+		items[item.Hash()] = item
+	}
+	return items
+}
+
+func TestTopologyAwarePrefersSameZone(t *testing.T) {
+	s, err := New(TopologyAwareStrategyName, logger)
+	require.NoError(t, err)
+	s.SetCollectors(map[string]*Collector{
+		"col-a-0": zonedCollector("col-a-0", "node-a-0", "zone-a"),
+		"col-a-1": zonedCollector("col-a-1", "node-a-1", "zone-a"),
+		"col-b-0": zonedCollector("col-b-0", "node-b-0", "zone-b"),
+	})
+	targets := zonedTargets(4, "zone-a")
+	for hash, item := range zonedTargets(3, "zone-b") {
+		targets[hash] = item
+	}
+	s.SetTargets(targets)
+
+	collectors := s.Collectors()
+	for _, item := range s.TargetItems() {
+		zone := string(item.Labels["__meta_kubernetes_node_label_topology_kubernetes_io_zone"])
+		assert.Equal(t, zone, collectors[item.CollectorName].TopologyLabels["topology.kubernetes.io/zone"])
+	}
+	assert.Equal(t, 2, collectors["col-a-0"].NumTargets)
+	assert.Equal(t, 2, collectors["col-a-1"].NumTargets)
+	assert.Equal(t, 3, collectors["col-b-0"].NumTargets)
+}
+
+func TestTopologyAwareFallsBackToAnyCollector(t *testing.T) {
+	s, err := New(TopologyAwareStrategyName, logger)
+	require.NoError(t, err)
+	s.SetCollectors(map[string]*Collector{
+		"col-a-0": zonedCollector("col-a-0", "node-a-0", "zone-a"),
+		"col-b-0": zonedCollector("col-b-0", "node-b-0", "zone-b"),
+	})
+	targets := zonedTargets(4, "zone-c")
+	unzoned := target.NewItem("job", "control-plane:8080", model.LabelSet{}, "")
+	targets[unzoned.Hash()] = unzoned
+	s.SetTargets(targets)
+
+	assert.Len(t, s.TargetItems(), 5)
+	for _, item := range s.TargetItems() {
+		assert.NotEmpty(t, item.CollectorName)
+	}
+	collectors := s.Collectors()
+	assert.InDelta(t, collectors["col-a-0"].NumTargets, collectors["col-b-0"].NumTargets, 1)
+
+	// once zone-c gets a collector, its targets move there
+	s.SetCollectors(map[string]*Collector{
+		"col-a-0": zonedCollector("col-a-0", "node-a-0", "zone-a"),
+		"col-b-0": zonedCollector("col-b-0", "node-b-0", "zone-b"),
+		"col-c-0": zonedCollector("col-c-0", "node-c-0", "zone-c"),
+	})
+	for _, item := range s.TargetItems() {
+		if item.Hash() == unzoned.Hash() {
+			continue
+		}
+		assert.Equal(t, "col-c-0", item.CollectorName)
+	}
+}
+
+func TestTopologyAwareTargetZoneLookup(t *testing.T) {
+	s, err := New(TopologyAwareStrategyName, logger, WithTopology("", staticTopology{"node-a": "zone-a", "node-b": "zone-b"}))
+	require.NoError(t, err)
+	s.SetCollectors(map[string]*Collector{
+		"col-a": zonedCollector("col-a", "node-a", "zone-a"),
+		"col-b": zonedCollector("col-b", "node-b", "zone-b"),
+	})
+	podTarget := target.NewItem("job", "pod:8080", model.LabelSet{"__meta_kubernetes_pod_node_name": "node-b"}, "")
+	endpointTarget := target.NewItem("job", "endpoint:8080", model.LabelSet{"__meta_kubernetes_endpointslice_endpoint_zone": "zone-a"}, "")
+	s.SetTargets(map[string]*target.Item{
+		podTarget.Hash():      podTarget,
+		endpointTarget.Hash(): endpointTarget,
+	})
+
+	items := s.TargetItems()
+	assert.Equal(t, "col-b", items[podTarget.Hash()].CollectorName)
+	assert.Equal(t, "col-a", items[endpointTarget.Hash()].CollectorName)
+}
+
+func TestTopologyAwareCustomKey(t *testing.T) {
+	s, err := New(TopologyAwareStrategyName, logger, WithTopology("topology.kubernetes.io/region", nil))
+	require.NoError(t, err)
+	east := NewCollector("col-east", "node-0")
+	east.TopologyLabels = map[string]string{"topology.kubernetes.io/region": "east"}
+	west := NewCollector("col-west", "node-1")
+	west.TopologyLabels = map[string]string{"topology.kubernetes.io/region": "west"}
+	s.SetCollectors(map[string]*Collector{east.Name: east, west.Name: west})
+
+	item := target.NewItem("job", "target:8080", model.LabelSet{"__meta_kubernetes_node_label_topology_kubernetes_io_region": "west"}, "")
+	s.SetTargets(map[string]*target.Item{item.Hash(): item})
+	assert.Equal(t, "col-west", s.TargetItems()[item.Hash()].CollectorName)
+}
@@ -15,6 +15,7 @@
 package collector
 
 import (
+	"errors"
 	"os"
 	"time"
 
@@ -43,25 +44,46 @@ var (
 	})
 )
 
+var _ allocation.TopologySource = &Watcher{}
+
 type Watcher struct {
 	log               logr.Logger
 	k8sClient         kubernetes.Interface
 	close             chan struct{}
 	minUpdateInterval time.Duration
+	// topologyKeys are the Node labels copied to collectors. Nodes are only watched if there are any.
+	topologyKeys []string
+	nodeInformer cache.SharedIndexInformer
+}
+
+type Option func(*Watcher)
+
+// WithTopologyKeys makes the Watcher copy the given labels of the Node each collector runs on to the collector.
+func WithTopologyKeys(keys ...string) Option {
+	return func(w *Watcher) {
+		w.topologyKeys = append(w.topologyKeys, keys...)
+	}
 }
 
-func NewCollectorWatcher(logger logr.Logger, kubeConfig *rest.Config) (*Watcher, error) {
+func NewCollectorWatcher(logger logr.Logger, kubeConfig *rest.Config, opts ...Option) (*Watcher, error) {
 	clientset, err := kubernetes.NewForConfig(kubeConfig)
 	if err != nil {
 		return &Watcher{}, err
 	}
 
-	return &Watcher{
+	w := &Watcher{
 		log:               logger.WithValues("component", "opentelemetry-targetallocator"),
 		k8sClient:         clientset,
 		close:             make(chan struct{}),
 		minUpdateInterval: defaultMinUpdateInterval,
-	}, nil
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	if len(w.topologyKeys) > 0 {
+		w.nodeInformer = informers.NewSharedInformerFactory(clientset, time.Second*30).Core().V1().Nodes().Informer()
+	}
+	return w, nil
 }
 
 func (k *Watcher) Watch(labelSelector *metav1.LabelSelector, fn func(collectors map[string]*allocation.Collector)) error {
@@ -80,6 +102,14 @@ func (k *Watcher) Watch(labelSelector *metav1.LabelSelector, fn func(collectors
 		informers.WithTweakListOptions(listOptionsFunc))
 	informer := informerFactory.Core().V1().Pods().Informer()
 
+	// collectors are only reported once the Nodes are known, so that they carry their topology labels
+	if k.nodeInformer != nil {
+		go k.nodeInformer.Run(k.close)
+		if !cache.WaitForCacheSync(k.close, k.nodeInformer.HasSynced) {
+			return errors.New("failed to sync the Node cache")
+		}
+	}
+
 	notify := make(chan struct{}, 1)
 	go k.rateLimitedCollectorHandler(notify, informer.GetStore(), fn)
 
@@ -133,12 +163,36 @@ func (k *Watcher) runOnCollectors(store cache.Store, fn func(collectors map[stri
 		if pod.Spec.NodeName == "" {
 			continue
 		}
-		collectorMap[pod.Name] = allocation.NewCollector(pod.Name, pod.Spec.NodeName)
+		collector := allocation.NewCollector(pod.Name, pod.Spec.NodeName)
+		collector.TopologyLabels = k.NodeTopologyLabels(pod.Spec.NodeName)
+		collectorMap[pod.Name] = collector
 	}
 	collectorsDiscovered.Set(float64(len(collectorMap)))
 	fn(collectorMap)
 }
 
+// NodeTopologyLabels returns the labels of the given Node whose keys are one of the topology keys.
+func (k *Watcher) NodeTopologyLabels(nodeName string) map[string]string {
+	if k.nodeInformer == nil {
+		return nil
+	}
+	obj, exists, err := k.nodeInformer.GetStore().GetByKey(nodeName)
+	if err != nil || !exists {
+		return nil
+	}
+	node := obj.(*v1.Node)
+	var labels map[string]string
+	for _, key := range k.topologyKeys {
+		if value, ok := node.Labels[key]; ok {
+			if labels == nil {
+				labels = make(map[string]string, len(k.topologyKeys))
+			}
+			labels[key] = value
+		}
+	}
+	return labels
+}
+
 func (k *Watcher) Close() {
 	close(k.close)
 }
@@ -25,6 +25,7 @@ import (
 	"github.com/stretchr/testify/require"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes/fake"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
@@ -185,3 +186,49 @@ func Test_closeChannel(t *testing.T) {
 	podWatcher.Close()
 	wg.Wait()
 }
+
+func Test_topologyLabels(t *testing.T) {
+	podWatcher := getTestPodWatcher()
+	podWatcher.topologyKeys = []string{v1.LabelTopologyZone, v1.LabelTopologyRegion}
+	podWatcher.nodeInformer = informers.NewSharedInformerFactory(podWatcher.k8sClient, 0).Core().V1().Nodes().Informer()
+	defer close(podWatcher.close)
+
+	node := &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-node",
+			Labels: map[string]string{
+				v1.LabelTopologyZone: "zone-a",
+				v1.LabelHostname:     "test-node",
+			},
+		},
+	}
+	_, err := podWatcher.k8sClient.CoreV1().Nodes().Create(context.Background(), node, metav1.CreateOptions{})
+	require.NoError(t, err)
+	_, err = podWatcher.k8sClient.CoreV1().Pods("test-ns").Create(context.Background(), pod("test-pod"), metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	var actual map[string]*allocation.Collector
+	mapMutex := sync.Mutex{}
+	go func(podWatcher Watcher) {
+		err := podWatcher.Watch(&labelSelector, func(colMap map[string]*allocation.Collector) {
+			mapMutex.Lock()
+			defer mapMutex.Unlock()
+			actual = colMap
+		})
+		require.NoError(t, err)
+	}(podWatcher)
+
+	want := map[string]*allocation.Collector{
+		"test-pod": {
+			Name:           "test-pod",
+			NodeName:       "test-node",
+			TopologyLabels: map[string]string{v1.LabelTopologyZone: "zone-a"},
+		},
+	}
+	assert.EventuallyWithT(t, func(collect *assert.CollectT) {
+		mapMutex.Lock()
+		defer mapMutex.Unlock()
+		assert.Equal(collect, want, actual)
+	}, time.Second, time.Millisecond)
+	assert.Nil(t, podWatcher.NodeTopologyLabels("unknown-node"))
+}
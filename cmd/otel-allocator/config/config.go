@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"io/fs"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -43,19 +44,30 @@ const (
 	DefaultCRScrapeInterval   model.Duration = model.Duration(time.Second * 30)
 	DefaultAllocationStrategy                = "consistent-hashing"
 	DefaultFilterStrategy                    = "relabel-config"
+	DefaultTopologyKey                       = "topology.kubernetes.io/zone"
+	DefaultRebalanceInterval                 = time.Minute
+	DefaultLeaseDuration                     = 15 * time.Second
+	DefaultRenewDeadline                     = 10 * time.Second
+	DefaultRetryPeriod                       = 2 * time.Second
+	DefaultSnapshotInterval                  = 30 * time.Second
 )
 
 type Config struct {
-	ListenAddr         string                `yaml:"listen_addr,omitempty"`
-	KubeConfigFilePath string                `yaml:"kube_config_file_path,omitempty"`
-	ClusterConfig      *rest.Config          `yaml:"-"`
-	RootLogger         logr.Logger           `yaml:"-"`
-	CollectorSelector  *metav1.LabelSelector `yaml:"collector_selector,omitempty"`
-	PromConfig         *promconfig.Config    `yaml:"config"`
-	AllocationStrategy string                `yaml:"allocation_strategy,omitempty"`
-	FilterStrategy     string                `yaml:"filter_strategy,omitempty"`
-	PrometheusCR       PrometheusCRConfig    `yaml:"prometheus_cr,omitempty"`
-	HTTPS              HTTPSServerConfig     `yaml:"https,omitempty"`
+	ListenAddr         string                  `yaml:"listen_addr,omitempty"`
+	KubeConfigFilePath string                  `yaml:"kube_config_file_path,omitempty"`
+	ClusterConfig      *rest.Config            `yaml:"-"`
+	RootLogger         logr.Logger             `yaml:"-"`
+	CollectorSelector  *metav1.LabelSelector   `yaml:"collector_selector,omitempty"`
+	PromConfig         *promconfig.Config      `yaml:"config"`
+	AllocationStrategy string                  `yaml:"allocation_strategy,omitempty"`
+	TopologyKey        string                  `yaml:"topology_key,omitempty"`
+	FilterStrategy     string                  `yaml:"filter_strategy,omitempty"`
+	Filters            []FilterConfig          `yaml:"filters,omitempty"`
+	PrometheusCR       PrometheusCRConfig      `yaml:"prometheus_cr,omitempty"`
+	HTTPS              HTTPSServerConfig       `yaml:"https,omitempty"`
+	CollectorFeedback  CollectorFeedbackConfig `yaml:"collector_feedback,omitempty"`
+	HighAvailability   HighAvailabilityConfig  `yaml:"high_availability,omitempty"`
+	Snapshot           SnapshotConfig          `yaml:"snapshot,omitempty"`
 }
 
 type PrometheusCRConfig struct {
@@ -64,6 +76,10 @@ type PrometheusCRConfig struct {
 	ServiceMonitorSelector          *metav1.LabelSelector `yaml:"service_monitor_selector,omitempty"`
 	ServiceMonitorNamespaceSelector *metav1.LabelSelector `yaml:"service_monitor_namespace_selector,omitempty"`
 	PodMonitorNamespaceSelector     *metav1.LabelSelector `yaml:"pod_monitor_namespace_selector,omitempty"`
+	ProbeSelector                   *metav1.LabelSelector `yaml:"probe_selector,omitempty"`
+	ProbeNamespaceSelector          *metav1.LabelSelector `yaml:"probe_namespace_selector,omitempty"`
+	ScrapeConfigSelector            *metav1.LabelSelector `yaml:"scrape_config_selector,omitempty"`
+	ScrapeConfigNamespaceSelector   *metav1.LabelSelector `yaml:"scrape_config_namespace_selector,omitempty"`
 	ScrapeInterval                  model.Duration        `yaml:"scrape_interval,omitempty"`
 }
 
@@ -75,6 +91,45 @@ type HTTPSServerConfig struct {
 	TLSKeyFilePath  string `yaml:"tls_key_file_path,omitempty"`
 }
 
+// CollectorFeedbackConfig configures the endpoint collectors use to report how scraping their targets went.
+type CollectorFeedbackConfig struct {
+	Enabled bool `yaml:"enabled,omitempty"`
+	// TokenFilePath is the path to a file holding the bearer token collectors need to report feedback over plain
+	// HTTP. Without it, feedback is only accepted over the HTTPS server.
+	TokenFilePath string `yaml:"token_file_path,omitempty"`
+	// RebalanceInterval is how often target assignments are re-evaluated based on the reported costs.
+	RebalanceInterval time.Duration `yaml:"rebalance_interval,omitempty"`
+}
+
+// FilterConfig declares a step of the target filter chain. Filters are applied in the order they're declared in, and
+// replace the filter strategy when any are declared.
+type FilterConfig struct {
+	Name   string                 `yaml:"name"`
+	Config map[string]interface{} `yaml:"config,omitempty"`
+}
+
+// HighAvailabilityConfig configures leader election between target allocator replicas. Only the leader's assignments
+// are served, followers proxy assignment requests to it.
+type HighAvailabilityConfig struct {
+	Enabled bool `yaml:"enabled,omitempty"`
+	// LeaseName and LeaseNamespace identify the Lease used for the election. Every replica must use the same Lease.
+	LeaseName      string        `yaml:"lease_name,omitempty"`
+	LeaseNamespace string        `yaml:"lease_namespace,omitempty"`
+	LeaseDuration  time.Duration `yaml:"lease_duration,omitempty"`
+	RenewDeadline  time.Duration `yaml:"renew_deadline,omitempty"`
+	RetryPeriod    time.Duration `yaml:"retry_period,omitempty"`
+}
+
+// SnapshotConfig configures persisting target assignments, either to a file or to a ConfigMap. A restarted target
+// allocator serves the persisted assignments until it has caught up with discovery.
+type SnapshotConfig struct {
+	Enabled            bool          `yaml:"enabled,omitempty"`
+	FilePath           string        `yaml:"file_path,omitempty"`
+	ConfigMapName      string        `yaml:"config_map_name,omitempty"`
+	ConfigMapNamespace string        `yaml:"config_map_namespace,omitempty"`
+	Interval           time.Duration `yaml:"interval,omitempty"`
+}
+
 func LoadFromFile(file string, target *Config) error {
 	return unmarshal(target, file)
 }
@@ -157,10 +212,22 @@ func unmarshal(cfg *Config, configFile string) error {
 func CreateDefaultConfig() Config {
 	return Config{
 		AllocationStrategy: DefaultAllocationStrategy,
+		TopologyKey:        DefaultTopologyKey,
 		FilterStrategy:     DefaultFilterStrategy,
 		PrometheusCR: PrometheusCRConfig{
 			ScrapeInterval: DefaultCRScrapeInterval,
 		},
+		CollectorFeedback: CollectorFeedbackConfig{
+			RebalanceInterval: DefaultRebalanceInterval,
+		},
+		HighAvailability: HighAvailabilityConfig{
+			LeaseDuration: DefaultLeaseDuration,
+			RenewDeadline: DefaultRenewDeadline,
+			RetryPeriod:   DefaultRetryPeriod,
+		},
+		Snapshot: SnapshotConfig{
+			Interval: DefaultSnapshotInterval,
+		},
 	}
 }
 
@@ -199,9 +266,42 @@ func ValidateConfig(config *Config) error {
 	if !(config.PrometheusCR.Enabled || scrapeConfigsPresent) {
 		return fmt.Errorf("at least one scrape config must be defined, or Prometheus CR watching must be enabled")
 	}
+	if config.CollectorFeedback.Enabled && config.CollectorFeedback.TokenFilePath == "" && !config.HTTPS.Enabled {
+		return fmt.Errorf("collector feedback requires either a token file or the HTTPS server to be enabled")
+	}
+	if config.CollectorFeedback.Enabled && config.CollectorFeedback.RebalanceInterval <= 0 {
+		return fmt.Errorf("the collector feedback rebalance interval must be greater than zero")
+	}
+	if config.HighAvailability.Enabled && (config.HighAvailability.LeaseName == "" || config.HighAvailability.LeaseNamespace == "") {
+		return fmt.Errorf("high availability requires both a lease name and a lease namespace")
+	}
+	if config.HighAvailability.Enabled && config.HighAvailability.LeaseDuration <= config.HighAvailability.RenewDeadline {
+		return fmt.Errorf("the high availability lease duration must be greater than the renew deadline")
+	}
+	if config.Snapshot.Enabled && (config.Snapshot.FilePath == "") == (config.Snapshot.ConfigMapName == "") {
+		return fmt.Errorf("snapshots require either a file path or a ConfigMap name")
+	}
+	if config.Snapshot.Enabled && config.Snapshot.ConfigMapName != "" && config.Snapshot.ConfigMapNamespace == "" {
+		return fmt.Errorf("snapshots stored in a ConfigMap require a ConfigMap namespace")
+	}
+	if config.Snapshot.Enabled && config.Snapshot.Interval <= 0 {
+		return fmt.Errorf("the snapshot interval must be greater than zero")
+	}
 	return nil
 }
 
+// Token reads the bearer token collectors need to report feedback, if one is configured.
+func (c CollectorFeedbackConfig) Token() (string, error) {
+	if c.TokenFilePath == "" {
+		return "", nil
+	}
+	token, err := os.ReadFile(c.TokenFilePath)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(token)), nil
+}
+
 func (c HTTPSServerConfig) NewTLSConfig() (*tls.Config, error) {
 	cert, err := tls.LoadX509KeyPair(c.TLSCertFilePath, c.TLSKeyFilePath)
 	if err != nil {
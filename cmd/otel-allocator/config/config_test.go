@@ -51,6 +51,7 @@ func TestLoad(t *testing.T) {
 			},
 			want: Config{
 				AllocationStrategy: DefaultAllocationStrategy,
+				TopologyKey:        DefaultTopologyKey,
 				CollectorSelector: &metav1.LabelSelector{
 					MatchLabels: map[string]string{
 						"app.kubernetes.io/instance":   "default.test",
@@ -68,6 +69,28 @@ func TestLoad(t *testing.T) {
 					TLSCertFilePath: "/path/to/cert.pem",
 					TLSKeyFilePath:  "/path/to/key.pem",
 				},
+				Filters: []FilterConfig{
+					{Name: "relabel-config"},
+					{Name: "job-target-limit", Config: map[string]interface{}{"limit": 100}},
+				},
+				CollectorFeedback: CollectorFeedbackConfig{
+					Enabled:           true,
+					RebalanceInterval: 30 * time.Second,
+				},
+				HighAvailability: HighAvailabilityConfig{
+					Enabled:        true,
+					LeaseName:      "test-targetallocator",
+					LeaseNamespace: "default",
+					LeaseDuration:  30 * time.Second,
+					RenewDeadline:  DefaultRenewDeadline,
+					RetryPeriod:    DefaultRetryPeriod,
+				},
+				Snapshot: SnapshotConfig{
+					Enabled:            true,
+					ConfigMapName:      "test-targetallocator-snapshot",
+					ConfigMapNamespace: "default",
+					Interval:           DefaultSnapshotInterval,
+				},
 				PromConfig: &promconfig.Config{
 					GlobalConfig: promconfig.GlobalConfig{
 						ScrapeInterval:     model.Duration(60 * time.Second),
@@ -123,12 +146,13 @@ func TestLoad(t *testing.T) {
 			wantErr: assert.NoError,
 		},
 		{
-			name: "service monitor pod monitor selector",
+			name: "service monitor pod monitor probe scrape config selector",
 			args: args{
 				file: "./testdata/pod_service_selector_test.yaml",
 			},
 			want: Config{
 				AllocationStrategy: DefaultAllocationStrategy,
+				TopologyKey:        DefaultTopologyKey,
 				CollectorSelector: &metav1.LabelSelector{
 					MatchLabels: map[string]string{
 						"app.kubernetes.io/instance":   "default.test",
@@ -147,8 +171,34 @@ func TestLoad(t *testing.T) {
 							"release": "test",
 						},
 					},
+					ProbeSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							"release": "test",
+						},
+					},
+					ScrapeConfigSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							"release": "test",
+						},
+					},
+					ScrapeConfigNamespaceSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							"team": "test",
+						},
+					},
 					ScrapeInterval: DefaultCRScrapeInterval,
 				},
+				CollectorFeedback: CollectorFeedbackConfig{
+					RebalanceInterval: DefaultRebalanceInterval,
+				},
+				HighAvailability: HighAvailabilityConfig{
+					LeaseDuration: DefaultLeaseDuration,
+					RenewDeadline: DefaultRenewDeadline,
+					RetryPeriod:   DefaultRetryPeriod,
+				},
+				Snapshot: SnapshotConfig{
+					Interval: DefaultSnapshotInterval,
+				},
 				PromConfig: &promconfig.Config{
 					GlobalConfig: promconfig.GlobalConfig{
 						ScrapeInterval:     model.Duration(60 * time.Second),
@@ -240,6 +290,86 @@ func TestValidateConfig(t *testing.T) {
 			},
 			expectedErr: nil,
 		},
+		{
+			name: "collector feedback enabled without token or HTTPS",
+			fileConfig: Config{
+				PrometheusCR:      PrometheusCRConfig{Enabled: true},
+				CollectorFeedback: CollectorFeedbackConfig{Enabled: true},
+			},
+			expectedErr: fmt.Errorf("collector feedback requires either a token file or the HTTPS server to be enabled"),
+		},
+		{
+			name: "collector feedback enabled with token",
+			fileConfig: Config{
+				PrometheusCR:      PrometheusCRConfig{Enabled: true},
+				CollectorFeedback: CollectorFeedbackConfig{Enabled: true, TokenFilePath: "/path/to/token", RebalanceInterval: DefaultRebalanceInterval},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "collector feedback enabled without rebalance interval",
+			fileConfig: Config{
+				PrometheusCR:      PrometheusCRConfig{Enabled: true},
+				CollectorFeedback: CollectorFeedbackConfig{Enabled: true, TokenFilePath: "/path/to/token"},
+			},
+			expectedErr: fmt.Errorf("the collector feedback rebalance interval must be greater than zero"),
+		},
+		{
+			name: "high availability enabled without lease",
+			fileConfig: Config{
+				PrometheusCR:     PrometheusCRConfig{Enabled: true},
+				HighAvailability: HighAvailabilityConfig{Enabled: true, LeaseDuration: DefaultLeaseDuration, RenewDeadline: DefaultRenewDeadline},
+			},
+			expectedErr: fmt.Errorf("high availability requires both a lease name and a lease namespace"),
+		},
+		{
+			name: "high availability enabled with renew deadline above lease duration",
+			fileConfig: Config{
+				PrometheusCR:     PrometheusCRConfig{Enabled: true},
+				HighAvailability: HighAvailabilityConfig{Enabled: true, LeaseName: "ta", LeaseNamespace: "default", LeaseDuration: 5 * time.Second, RenewDeadline: DefaultRenewDeadline},
+			},
+			expectedErr: fmt.Errorf("the high availability lease duration must be greater than the renew deadline"),
+		},
+		{
+			name: "snapshots enabled with both a file and a ConfigMap",
+			fileConfig: Config{
+				PrometheusCR: PrometheusCRConfig{Enabled: true},
+				Snapshot:     SnapshotConfig{Enabled: true, FilePath: "/snapshot/assignments", ConfigMapName: "ta-snapshot", ConfigMapNamespace: "default"},
+			},
+			expectedErr: fmt.Errorf("snapshots require either a file path or a ConfigMap name"),
+		},
+		{
+			name: "snapshots enabled with a ConfigMap without namespace",
+			fileConfig: Config{
+				PrometheusCR: PrometheusCRConfig{Enabled: true},
+				Snapshot:     SnapshotConfig{Enabled: true, ConfigMapName: "ta-snapshot"},
+			},
+			expectedErr: fmt.Errorf("snapshots stored in a ConfigMap require a ConfigMap namespace"),
+		},
+		{
+			name: "snapshots enabled with a file",
+			fileConfig: Config{
+				PrometheusCR: PrometheusCRConfig{Enabled: true},
+				Snapshot:     SnapshotConfig{Enabled: true, FilePath: "/snapshot/assignments", Interval: DefaultSnapshotInterval},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "snapshots enabled without an interval",
+			fileConfig: Config{
+				PrometheusCR: PrometheusCRConfig{Enabled: true},
+				Snapshot:     SnapshotConfig{Enabled: true, FilePath: "/snapshot/assignments"},
+			},
+			expectedErr: fmt.Errorf("the snapshot interval must be greater than zero"),
+		},
+		{
+			name: "snapshots disabled without an interval",
+			fileConfig: Config{
+				PrometheusCR: PrometheusCRConfig{Enabled: true},
+				Snapshot:     SnapshotConfig{Enabled: false},
+			},
+			expectedErr: nil,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -0,0 +1,215 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package feedback
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/allocation"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/target"
+)
+
+var (
+	reportsReceived = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "opentelemetry_allocator_feedback_reports_received",
+		Help: "Number of scrape feedback reports received from each collector.",
+	}, []string{"collector_name"})
+	samplesScraped = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "opentelemetry_allocator_feedback_samples_scraped",
+		Help: "Number of samples scraped in the last scrape, summed over the targets of each job, as reported by collectors.",
+	}, []string{"collector_name", "job_name"})
+	scrapeDuration = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "opentelemetry_allocator_feedback_scrape_duration_seconds",
+		Help: "Duration of the last scrape, summed over the targets of each job, as reported by collectors.",
+	}, []string{"collector_name", "job_name"})
+	targetsFailing = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "opentelemetry_allocator_feedback_targets_failing",
+		Help: "Number of targets of each job whose last scrape failed, as reported by collectors.",
+	}, []string{"collector_name", "job_name"})
+	// TargetsUnreported records how many targets assigned to each collector were missing from its last report.
+	TargetsUnreported = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "opentelemetry_allocator_feedback_targets_unreported",
+		Help: "Number of targets assigned to each collector which were missing from its last feedback report.",
+	}, []string{"collector_name"})
+)
+
+var _ allocation.CostSource = &Store{}
+
+// TargetReport is what a collector reports about the last scrape of a single target.
+type TargetReport struct {
+	JobName               string  `json:"job_name"`
+	Target                string  `json:"target"`
+	ScrapeDurationSeconds float64 `json:"scrape_duration_seconds"`
+	SamplesScraped        float64 `json:"samples_scraped"`
+	LastError             string  `json:"last_error,omitempty"`
+}
+
+// Report is the body collectors post to the feedback endpoint. It holds the state of every target the collector
+// currently scrapes, and replaces any report previously received from the same collector.
+type Report struct {
+	Targets []TargetReport `json:"targets"`
+}
+
+// TargetStatus is a TargetReport along with the collector it came from.
+type TargetStatus struct {
+	TargetReport
+	CollectorName string    `json:"collector_name"`
+	ReceivedAt    time.Time `json:"received_at"`
+}
+
+// Store keeps the latest scrape feedback reported by each collector. It's safe for concurrent use.
+type Store struct {
+	mtx sync.RWMutex
+	// collector name -> target key -> status
+	statuses map[string]map[string]*TargetStatus
+	// defaultCost is the average sample count over all reported targets, used for targets without any reports
+	defaultCost float64
+}
+
+func NewStore() *Store {
+	return &Store{
+		statuses:    make(map[string]map[string]*TargetStatus),
+		defaultCost: 1,
+	}
+}
+
+func key(jobName, targetURL string) string {
+	return jobName + "/" + targetURL
+}
+
+// Update replaces the state reported by the given collector.
+func (s *Store) Update(collectorName string, report Report) {
+	now := time.Now()
+	statuses := make(map[string]*TargetStatus, len(report.Targets))
+	samples := map[string]float64{}
+	durations := map[string]float64{}
+	failing := map[string]float64{}
+	for _, tr := range report.Targets {
+		statuses[key(tr.JobName, tr.Target)] = &TargetStatus{
+			TargetReport:  tr,
+			CollectorName: collectorName,
+			ReceivedAt:    now,
+		}
+		samples[tr.JobName] += tr.SamplesScraped
+		durations[tr.JobName] += tr.ScrapeDurationSeconds
+		if tr.LastError != "" {
+			failing[tr.JobName]++
+		}
+	}
+
+	s.mtx.Lock()
+	s.statuses[collectorName] = statuses
+	s.updateDefaultCost()
+	s.mtx.Unlock()
+
+	reportsReceived.WithLabelValues(collectorName).Inc()
+	collectorLabels := prometheus.Labels{"collector_name": collectorName}
+	samplesScraped.DeletePartialMatch(collectorLabels)
+	scrapeDuration.DeletePartialMatch(collectorLabels)
+	targetsFailing.DeletePartialMatch(collectorLabels)
+	for job, value := range samples {
+		samplesScraped.WithLabelValues(collectorName, job).Set(value)
+		scrapeDuration.WithLabelValues(collectorName, job).Set(durations[job])
+		targetsFailing.WithLabelValues(collectorName, job).Set(failing[job])
+	}
+}
+
+// SetCollectors drops the state reported by collectors which are no longer present.
+func (s *Store) SetCollectors(collectors map[string]*allocation.Collector) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	for name := range s.statuses {
+		if _, ok := collectors[name]; ok {
+			continue
+		}
+		delete(s.statuses, name)
+		collectorLabels := prometheus.Labels{"collector_name": name}
+		reportsReceived.DeletePartialMatch(collectorLabels)
+		samplesScraped.DeletePartialMatch(collectorLabels)
+		scrapeDuration.DeletePartialMatch(collectorLabels)
+		targetsFailing.DeletePartialMatch(collectorLabels)
+		TargetsUnreported.DeletePartialMatch(collectorLabels)
+	}
+	s.updateDefaultCost()
+}
+
+// updateDefaultCost must be called with the lock held.
+func (s *Store) updateDefaultCost() {
+	var total, count float64
+	for _, statuses := range s.statuses {
+		for _, status := range statuses {
+			total += status.SamplesScraped
+			count++
+		}
+	}
+	if count == 0 || total == 0 {
+		s.defaultCost = 1
+		return
+	}
+	s.defaultCost = total / count
+}
+
+// Get returns the latest state reported for the given target, if any. If more than one collector reported the same
+// target, the most recent report wins.
+func (s *Store) Get(jobName, targetURL string) (*TargetStatus, bool) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	return s.get(key(jobName, targetURL))
+}
+
+func (s *Store) get(k string) (*TargetStatus, bool) {
+	var latest *TargetStatus
+	for _, statuses := range s.statuses {
+		if status, ok := statuses[k]; ok && (latest == nil || status.ReceivedAt.After(latest.ReceivedAt)) {
+			latest = status
+		}
+	}
+	return latest, latest != nil
+}
+
+// IsReported returns whether the given collector included the target in its last report.
+func (s *Store) IsReported(collectorName string, item *target.Item) bool {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	for _, targetURL := range item.TargetURL {
+		if _, ok := s.statuses[collectorName][key(item.JobName, targetURL)]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// TargetCost returns the number of samples last scraped from the target. Targets without reports are assumed to cost
+// as much as the average reported target.
+func (s *Store) TargetCost(item *target.Item) float64 {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	var cost float64
+	found := false
+	for _, targetURL := range item.TargetURL {
+		if status, ok := s.get(key(item.JobName, targetURL)); ok {
+			cost += status.SamplesScraped
+			found = true
+		}
+	}
+	if !found {
+		return s.defaultCost
+	}
+	return cost
+}
@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package feedback
+
+import (
+	"testing"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/allocation"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/target"
+)
+
+func TestStoreUpdateReplacesPreviousReport(t *testing.T) {
+	s := NewStore()
+	s.Update("collector-0", Report{Targets: []TargetReport{
+		{JobName: "job", Target: "a:80", SamplesScraped: 10},
+		{JobName: "job", Target: "b:80", SamplesScraped: 20},
+	}})
+	s.Update("collector-0", Report{Targets: []TargetReport{
+		{JobName: "job", Target: "a:80", SamplesScraped: 30},
+	}})
+
+	status, ok := s.Get("job", "a:80")
+	assert.True(t, ok)
+	assert.Equal(t, float64(30), status.SamplesScraped)
+	assert.Equal(t, "collector-0", status.CollectorName)
+	_, ok = s.Get("job", "b:80")
+	assert.False(t, ok)
+}
+
+func TestStoreTargetCost(t *testing.T) {
+	s := NewStore()
+	known := target.NewItem("job", "a:80", model.LabelSet{}, "")
+	unknown := target.NewItem("job", "c:80", model.LabelSet{}, "")
+
+	// without any reports, every target costs the same
+	assert.Equal(t, float64(1), s.TargetCost(known))
+	assert.Equal(t, float64(1), s.TargetCost(unknown))
+
+	s.Update("collector-0", Report{Targets: []TargetReport{
+		{JobName: "job", Target: "a:80", SamplesScraped: 100},
+		{JobName: "job", Target: "b:80", SamplesScraped: 300},
+	}})
+	assert.Equal(t, float64(100), s.TargetCost(known))
+	// targets without reports cost as much as the average target
+	assert.Equal(t, float64(200), s.TargetCost(unknown))
+}
+
+func TestStoreSetCollectorsDropsRemovedCollectors(t *testing.T) {
+	s := NewStore()
+	item := target.NewItem("job", "a:80", model.LabelSet{}, "")
+	s.Update("collector-0", Report{Targets: []TargetReport{{JobName: "job", Target: "a:80"}}})
+	s.Update("collector-1", Report{Targets: []TargetReport{{JobName: "job", Target: "b:80"}}})
+	assert.True(t, s.IsReported("collector-0", item))
+	assert.False(t, s.IsReported("collector-1", item))
+
+	s.SetCollectors(map[string]*allocation.Collector{"collector-1": allocation.NewCollector("collector-1", "")})
+	assert.False(t, s.IsReported("collector-0", item))
+	_, ok := s.Get("job", "b:80")
+	assert.True(t, ok)
+}
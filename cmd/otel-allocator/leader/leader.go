@@ -0,0 +1,211 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package leader
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/config"
+)
+
+var (
+	isLeader = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "opentelemetry_allocator_is_leader",
+		Help: "Whether this target allocator replica is the leader, 1 if it is and 0 otherwise.",
+	})
+	leaderTransitions = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "opentelemetry_allocator_leader_transitions",
+		Help: "Number of times this target allocator replica observed a new leader.",
+	})
+)
+
+// identitySeparator separates the pod name from the address in a replica's identity. Pod names can't contain it.
+const identitySeparator = "_"
+
+// Elector elects a single leader among the target allocator replicas sharing a Lease, and keeps track of where the
+// current leader can be reached. It's safe for concurrent use.
+type Elector struct {
+	log      logr.Logger
+	lock     *resourcelock.LeaseLock
+	cfg      config.HighAvailabilityConfig
+	identity string
+
+	closed    chan struct{}
+	closeOnce sync.Once
+
+	mtx     sync.RWMutex
+	leading bool
+	leader  string
+	term    int64
+}
+
+// Identity returns the identity a replica holds the Lease with. It includes the address other replicas can use to
+// reach it, so that followers don't need access to the leader's Pod.
+func Identity(podName, address string) string {
+	return podName + identitySeparator + address
+}
+
+func NewElector(log logr.Logger, clusterConfig *rest.Config, cfg config.HighAvailabilityConfig, identity string) (*Elector, error) {
+	client, err := kubernetes.NewForConfig(clusterConfig)
+	if err != nil {
+		return nil, err
+	}
+	return newElector(log, client, cfg, identity), nil
+}
+
+func newElector(log logr.Logger, client kubernetes.Interface, cfg config.HighAvailabilityConfig, identity string) *Elector {
+	return &Elector{
+		log: log,
+		lock: &resourcelock.LeaseLock{
+			LeaseMeta: metav1.ObjectMeta{
+				Name:      cfg.LeaseName,
+				Namespace: cfg.LeaseNamespace,
+			},
+			Client:     client.CoordinationV1(),
+			LockConfig: resourcelock.ResourceLockConfig{Identity: identity},
+		},
+		cfg:      cfg,
+		identity: identity,
+		closed:   make(chan struct{}),
+	}
+}
+
+// Run takes part in the election until Close is called. A replica which loses the leadership goes back to being a
+// candidate.
+func (e *Elector) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-e.closed:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	elector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock:            e.lock,
+		LeaseDuration:   e.cfg.LeaseDuration,
+		RenewDeadline:   e.cfg.RenewDeadline,
+		RetryPeriod:     e.cfg.RetryPeriod,
+		ReleaseOnCancel: true,
+		Name:            e.cfg.LeaseName,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: e.startedLeading,
+			OnStoppedLeading: e.stoppedLeading,
+			OnNewLeader:      e.newLeader,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	for {
+		elector.Run(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		e.log.Info("Lost the leadership, rejoining the election")
+	}
+}
+
+// Close stops taking part in the election, releasing the Lease if this replica holds it.
+func (e *Elector) Close() {
+	e.closeOnce.Do(func() {
+		close(e.closed)
+	})
+}
+
+func (e *Elector) startedLeading(ctx context.Context) {
+	// the number of leader transitions recorded in the Lease increases with every new leader, which makes it a
+	// suitable term for the generations this replica hands out
+	record, _, err := e.lock.Get(ctx)
+	if err != nil {
+		e.log.Error(err, "Unable to read the leader election record")
+	}
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	if record != nil {
+		e.term = int64(record.LeaderTransitions)
+	}
+	e.leading = true
+	e.leader = e.identity
+	isLeader.Set(1)
+	e.log.Info("Started leading", "term", e.term)
+}
+
+func (e *Elector) stoppedLeading() {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	// this is also called when the election stops without this replica ever leading
+	if !e.leading {
+		return
+	}
+	e.leading = false
+	e.leader = ""
+	isLeader.Set(0)
+	e.log.Info("Stopped leading")
+}
+
+func (e *Elector) newLeader(identity string) {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	e.leader = identity
+	leaderTransitions.Inc()
+	e.log.Info("Observed a new leader", "leader", identity)
+}
+
+// IsLeader returns whether this replica currently holds the Lease.
+func (e *Elector) IsLeader() bool {
+	e.mtx.RLock()
+	defer e.mtx.RUnlock()
+	return e.leading
+}
+
+// Term returns the term this replica started leading in. It's only meaningful on the leader.
+func (e *Elector) Term() int64 {
+	e.mtx.RLock()
+	defer e.mtx.RUnlock()
+	return e.term
+}
+
+// LeaderAddress returns the address the current leader can be reached at, if a leader is known.
+func (e *Elector) LeaderAddress() (string, bool) {
+	e.mtx.RLock()
+	defer e.mtx.RUnlock()
+	address, err := addressFromIdentity(e.leader)
+	if err != nil {
+		return "", false
+	}
+	return address, true
+}
+
+func addressFromIdentity(identity string) (string, error) {
+	_, address, found := strings.Cut(identity, identitySeparator)
+	if !found || address == "" {
+		return "", errors.New("no address in leader identity")
+	}
+	return address, nil
+}
@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package leader
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/config"
+)
+
+var testConfig = config.HighAvailabilityConfig{
+	Enabled:        true,
+	LeaseName:      "test-targetallocator",
+	LeaseNamespace: "default",
+	LeaseDuration:  time.Second,
+	RenewDeadline:  500 * time.Millisecond,
+	RetryPeriod:    100 * time.Millisecond,
+}
+
+func TestIdentity(t *testing.T) {
+	identity := Identity("test-targetallocator-5d8f9b-x7k2q", "10.0.0.1:8080")
+	address, err := addressFromIdentity(identity)
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.1:8080", address)
+
+	_, err = addressFromIdentity("")
+	assert.Error(t, err)
+	_, err = addressFromIdentity("test-targetallocator-5d8f9b-x7k2q")
+	assert.Error(t, err)
+}
+
+func TestElection(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	first := newElector(logr.Discard(), client, testConfig, Identity("first", "10.0.0.1:8080"))
+	second := newElector(logr.Discard(), client, testConfig, Identity("second", "10.0.0.2:8080"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	firstDone := make(chan error)
+	go func() {
+		firstDone <- first.Run(ctx)
+	}()
+	require.Eventually(t, first.IsLeader, 5*time.Second, 10*time.Millisecond)
+
+	go func() {
+		_ = second.Run(ctx)
+	}()
+	require.Eventually(t, func() bool {
+		address, ok := second.LeaderAddress()
+		return ok && address == "10.0.0.1:8080"
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.False(t, second.IsLeader())
+
+	// the first leader releases the lease when it stops, and the second one takes over in a new term
+	firstTerm := first.Term()
+	first.Close()
+	require.NoError(t, <-firstDone)
+	assert.False(t, first.IsLeader())
+	require.Eventually(t, second.IsLeader, 5*time.Second, 10*time.Millisecond)
+	assert.Greater(t, second.Term(), firstTerm)
+	address, ok := second.LeaderAddress()
+	assert.True(t, ok)
+	assert.Equal(t, "10.0.0.2:8080", address)
+
+	lease, err := client.CoordinationV1().Leases("default").Get(ctx, "test-targetallocator", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, Identity("second", "10.0.0.2:8080"), *lease.Spec.HolderIdentity)
+}
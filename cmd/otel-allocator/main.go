@@ -16,24 +16,31 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	gokitlog "github.com/go-kit/log"
 	"github.com/oklog/run"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/prometheus/prometheus/discovery"
+	"k8s.io/client-go/kubernetes"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/allocation"
 	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/collector"
 	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/config"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/feedback"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/leader"
 	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/prehook"
 	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/server"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/snapshot"
 	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/target"
 	allocatorWatcher "github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/watcher"
 )
@@ -48,13 +55,16 @@ var (
 
 func main() {
 	var (
-		// allocatorPrehook will be nil if filterStrategy is not set or
-		// unrecognized. No filtering will be used in this case.
+		// allocatorPrehook will be nil if no filters are declared and filterStrategy
+		// is not set or unrecognized. No filtering will be used in this case.
 		allocatorPrehook prehook.Hook
 		allocator        allocation.Allocator
 		discoveryManager *discovery.Manager
 		collectorWatcher *collector.Watcher
+		elector          *leader.Elector
+		feedbackStore    *feedback.Store
 		promWatcher      allocatorWatcher.Watcher
+		snapshotter      *snapshot.Snapshotter
 		targetDiscoverer *target.Discoverer
 
 		discoveryCancel context.CancelFunc
@@ -80,14 +90,88 @@ func main() {
 	ctx := context.Background()
 	log := ctrl.Log.WithName("allocator")
 
-	allocatorPrehook = prehook.New(cfg.FilterStrategy, log)
-	allocator, err = allocation.New(cfg.AllocationStrategy, log, allocation.WithFilter(allocatorPrehook))
+	if len(cfg.Filters) > 0 {
+		allocatorPrehook, err = prehook.NewChain(log, cfg.Filters)
+		if err != nil {
+			setupLog.Error(err, "Unable to initialize the target filters")
+			os.Exit(1)
+		}
+	} else {
+		allocatorPrehook = prehook.New(cfg.FilterStrategy, log)
+	}
+	allocationOptions := []allocation.AllocationOption{allocation.WithFilter(allocatorPrehook)}
+	httpOptions := []server.Option{}
+	if cfg.CollectorFeedback.Enabled {
+		feedbackToken, tokenErr := cfg.CollectorFeedback.Token()
+		if tokenErr != nil {
+			setupLog.Error(tokenErr, "Unable to read the collector feedback token")
+			os.Exit(1)
+		}
+		feedbackStore = feedback.NewStore()
+		allocationOptions = append(allocationOptions, allocation.WithCostSource(feedbackStore))
+		httpOptions = append(httpOptions, server.WithFeedback(feedbackStore, feedbackToken))
+	}
+	var collectorWatcherOptions []collector.Option
+	if cfg.AllocationStrategy == allocation.TopologyAwareStrategyName {
+		collectorWatcherOptions = append(collectorWatcherOptions, collector.WithTopologyKeys(cfg.TopologyKey))
+	}
+	collectorWatcher, err = collector.NewCollectorWatcher(log, cfg.ClusterConfig, collectorWatcherOptions...)
+	if err != nil {
+		setupLog.Error(err, "Unable to initialize collector watcher")
+		os.Exit(1)
+	}
+	if cfg.AllocationStrategy == allocation.TopologyAwareStrategyName {
+		allocationOptions = append(allocationOptions, allocation.WithTopology(cfg.TopologyKey, collectorWatcher))
+	}
+	allocator, err = allocation.New(cfg.AllocationStrategy, log, allocationOptions...)
 	if err != nil {
 		setupLog.Error(err, "Unable to initialize allocation strategy")
 		os.Exit(1)
 	}
 
-	httpOptions := []server.Option{}
+	if cfg.HighAvailability.Enabled {
+		// the identity includes an address the other replicas can forward requests to
+		podName, podIP := os.Getenv("POD_NAME"), os.Getenv("POD_IP")
+		if podName == "" || podIP == "" {
+			setupLog.Error(errors.New("POD_NAME and POD_IP must be set"), "Unable to determine the identity for leader election")
+			os.Exit(1)
+		}
+		_, port, splitErr := net.SplitHostPort(cfg.ListenAddr)
+		if splitErr != nil {
+			setupLog.Error(splitErr, "Unable to determine the port for leader election")
+			os.Exit(1)
+		}
+		identity := leader.Identity(podName, net.JoinHostPort(podIP, port))
+		elector, err = leader.NewElector(log.WithName("leader-election"), cfg.ClusterConfig, cfg.HighAvailability, identity)
+		if err != nil {
+			setupLog.Error(err, "Unable to initialize leader election")
+			os.Exit(1)
+		}
+		httpOptions = append(httpOptions, server.WithLeadership(elector))
+	}
+
+	if cfg.Snapshot.Enabled {
+		var store snapshot.Store = snapshot.NewFileStore(cfg.Snapshot.FilePath)
+		if cfg.Snapshot.ConfigMapName != "" {
+			clientset, clientErr := kubernetes.NewForConfig(cfg.ClusterConfig)
+			if clientErr != nil {
+				setupLog.Error(clientErr, "Unable to initialize the snapshot store")
+				os.Exit(1)
+			}
+			store = snapshot.NewConfigMapStore(clientset, cfg.Snapshot.ConfigMapNamespace, cfg.Snapshot.ConfigMapName)
+		}
+		var snapshotOptions []snapshot.Option
+		if elector != nil {
+			snapshotOptions = append(snapshotOptions, snapshot.WithLeadership(elector.IsLeader))
+		}
+		snapshotter = snapshot.NewSnapshotter(log.WithName("snapshot"), store, allocator, cfg.Snapshot.Interval, snapshotOptions...)
+		// a missing or unreadable snapshot only means starting from scratch
+		if restoreErr := snapshotter.Restore(ctx); restoreErr != nil {
+			setupLog.Error(restoreErr, "Unable to restore assignments from the snapshot")
+		}
+		httpOptions = append(httpOptions, server.WithStaleness(snapshotter))
+	}
+
 	if cfg.HTTPS.Enabled {
 		tlsConfig, confErr := cfg.HTTPS.NewTLSConfig()
 		if confErr != nil {
@@ -107,11 +191,6 @@ func main() {
 	discoveryManager = discovery.NewManager(discoveryCtx, gokitlog.NewNopLogger(), prometheus.DefaultRegisterer, sdMetrics)
 
 	targetDiscoverer = target.NewDiscoverer(log, discoveryManager, allocatorPrehook, srv)
-	collectorWatcher, collectorWatcherErr := collector.NewCollectorWatcher(log, cfg.ClusterConfig)
-	if collectorWatcherErr != nil {
-		setupLog.Error(collectorWatcherErr, "Unable to initialize collector watcher")
-		os.Exit(1)
-	}
 	signal.Notify(interrupts, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
 	defer close(interrupts)
 
@@ -169,7 +248,14 @@ func main() {
 				setupLog.Info("Prometheus config empty, skipping initial discovery configuration")
 			}
 
-			err := targetDiscoverer.Watch(allocator.SetTargets)
+			setTargets := allocator.SetTargets
+			if snapshotter != nil {
+				setTargets = func(targets map[string]*target.Item) {
+					allocator.SetTargets(targets)
+					snapshotter.TargetsSynced()
+				}
+			}
+			err := targetDiscoverer.Watch(setTargets)
 			setupLog.Info("Target discoverer exited")
 			return err
 		},
@@ -179,7 +265,16 @@ func main() {
 		})
 	runGroup.Add(
 		func() error {
-			err := collectorWatcher.Watch(cfg.CollectorSelector, allocator.SetCollectors)
+			setCollectors := func(collectors map[string]*allocation.Collector) {
+				allocator.SetCollectors(collectors)
+				if feedbackStore != nil {
+					feedbackStore.SetCollectors(collectors)
+				}
+				if snapshotter != nil {
+					snapshotter.CollectorsSynced()
+				}
+			}
+			err := collectorWatcher.Watch(cfg.CollectorSelector, setCollectors)
 			setupLog.Info("Collector watcher exited")
 			return err
 		},
@@ -213,6 +308,63 @@ func main() {
 				}
 			})
 	}
+	if elector != nil {
+		runGroup.Add(
+			func() error {
+				err := elector.Run(ctx)
+				setupLog.Info("Leader election exited")
+				return err
+			},
+			func(_ error) {
+				setupLog.Info("Leaving leader election")
+				elector.Close()
+			})
+	}
+	if snapshotter != nil {
+		runGroup.Add(
+			func() error {
+				err := snapshotter.Run(ctx)
+				setupLog.Info("Snapshotter exited")
+				return err
+			},
+			func(_ error) {
+				setupLog.Info("Saving a final snapshot")
+				snapshotter.Close()
+			})
+	}
+	// only the strategies which take the costs into account move targets when rebalancing
+	if reloader, ok := allocatorPrehook.(prehook.Reloader); ok {
+		reloadCtx, reloadCancel := context.WithCancel(ctx)
+		runGroup.Add(
+			func() error {
+				// the discovered targets are filtered again when the configuration of a filter changes
+				err := reloader.Watch(reloadCtx, targetDiscoverer.Resync)
+				setupLog.Info("Filter watcher exited")
+				return err
+			},
+			func(_ error) {
+				setupLog.Info("Closing filter watcher")
+				reloadCancel()
+			})
+	}
+	if feedbackStore != nil && allocator.CostAware() {
+		runGroup.Add(
+			func() error {
+				ticker := time.NewTicker(cfg.CollectorFeedback.RebalanceInterval)
+				defer ticker.Stop()
+				for {
+					select {
+					case <-ticker.C:
+						allocator.Rebalance()
+					case <-eventCloser:
+						return nil
+					}
+				}
+			},
+			func(_ error) {
+				setupLog.Info("Closing rebalance loop")
+			})
+	}
 	runGroup.Add(
 		func() error {
 			for {
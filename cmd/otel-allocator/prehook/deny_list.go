@@ -0,0 +1,190 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prehook
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/go-logr/logr"
+	"github.com/prometheus/common/model"
+	"gopkg.in/yaml.v2"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/target"
+)
+
+const (
+	denyListFilterName = "deny-list"
+)
+
+type denyListConfig struct {
+	// FilePath is the path to the deny-list, usually mounted from a ConfigMap.
+	FilePath string `yaml:"file_path"`
+}
+
+// denyList is the content of the deny-list file.
+type denyList struct {
+	Jobs    []string `yaml:"jobs,omitempty"`
+	Targets []string `yaml:"targets,omitempty"`
+	// Labels drops targets with any of the listed values for a label.
+	Labels map[string][]string `yaml:"labels,omitempty"`
+}
+
+var _ Reloader = &DenyListFilter{}
+
+// DenyListFilter drops the jobs, targets and label values listed in a file. The file is reloaded whenever it changes,
+// and the targets are filtered again with the new list.
+type DenyListFilter struct {
+	log      logr.Logger
+	filePath string
+
+	// m protects modTime and the lists, which are reloaded while targets are filtered.
+	m       sync.RWMutex
+	modTime time.Time
+
+	jobs    map[string]struct{}
+	targets map[string]struct{}
+	labels  map[model.LabelName]map[model.LabelValue]struct{}
+}
+
+func newDenyListFilter(log logr.Logger, unmarshal func(interface{}) error) (Filter, error) {
+	cfg := denyListConfig{}
+	if err := unmarshal(&cfg); err != nil {
+		return nil, err
+	}
+	if cfg.FilePath == "" {
+		return nil, errors.New("file_path is required")
+	}
+	f := &DenyListFilter{log: log, filePath: cfg.FilePath}
+	if _, err := f.reload(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Watch reloads the deny-list whenever the file changes, and calls onChange once the new list is loaded. The
+// directory of the file is watched, as a mounted ConfigMap is updated by swapping a symlink rather than writing the
+// file.
+func (f *DenyListFilter) Watch(ctx context.Context, onChange func()) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+	if err = watcher.Add(filepath.Dir(f.filePath)); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case _, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			changed, reloadErr := f.reload()
+			if reloadErr != nil {
+				f.log.Error(reloadErr, "Unable to reload the deny-list, keeping the previous one")
+				continue
+			}
+			if changed {
+				onChange()
+			}
+		case watchErr, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			f.log.Error(watchErr, "Error watching the deny-list")
+		}
+	}
+}
+
+// reload reads the deny-list again if the file was modified since it was last read, and returns whether it was.
+func (f *DenyListFilter) reload() (bool, error) {
+	info, err := os.Stat(f.filePath)
+	if err != nil {
+		return false, err
+	}
+	f.m.Lock()
+	defer f.m.Unlock()
+	if info.ModTime().Equal(f.modTime) {
+		return false, nil
+	}
+	content, err := os.ReadFile(f.filePath)
+	if err != nil {
+		return false, err
+	}
+	list := denyList{}
+	if err = yaml.UnmarshalStrict(content, &list); err != nil {
+		return false, err
+	}
+
+	f.jobs = make(map[string]struct{}, len(list.Jobs))
+	for _, job := range list.Jobs {
+		f.jobs[job] = struct{}{}
+	}
+	f.targets = make(map[string]struct{}, len(list.Targets))
+	for _, targetURL := range list.Targets {
+		f.targets[targetURL] = struct{}{}
+	}
+	f.labels = make(map[model.LabelName]map[model.LabelValue]struct{}, len(list.Labels))
+	for name, values := range list.Labels {
+		f.labels[model.LabelName(name)] = make(map[model.LabelValue]struct{}, len(values))
+		for _, value := range values {
+			f.labels[model.LabelName(name)][model.LabelValue(value)] = struct{}{}
+		}
+	}
+	f.modTime = info.ModTime()
+	f.log.Info("Loaded the deny-list", "jobs", len(f.jobs), "targets", len(f.targets), "labels", len(f.labels))
+	return true, nil
+}
+
+func (f *DenyListFilter) denied(item *target.Item) bool {
+	if _, ok := f.jobs[item.JobName]; ok {
+		return true
+	}
+	for _, targetURL := range item.TargetURL {
+		if _, ok := f.targets[targetURL]; ok {
+			return true
+		}
+	}
+	for name, values := range f.labels {
+		if value, ok := item.Labels[name]; ok {
+			if _, denied := values[value]; denied {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (f *DenyListFilter) Apply(targets map[string]*target.Item) map[string]*target.Item {
+	if _, err := f.reload(); err != nil {
+		f.log.Error(err, "Unable to reload the deny-list, keeping the previous one")
+	}
+	f.m.RLock()
+	defer f.m.RUnlock()
+	for key, item := range targets {
+		if f.denied(item) {
+			delete(targets, key)
+		}
+	}
+	return targets
+}
@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prehook
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/config"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/target"
+)
+
+func TestDenyList(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "deny-list.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`jobs:
+- denied-job
+targets:
+- 10.0.0.2:8080
+labels:
+  namespace:
+  - kube-system
+`), 0600))
+	filter, err := NewChain(logger, []config.FilterConfig{{Name: "deny-list", Config: map[string]interface{}{"file_path": path}}})
+	require.NoError(t, err)
+
+	newTargets := func() map[string]*target.Item {
+		targets := map[string]*target.Item{}
+		for _, item := range []*target.Item{
+			target.NewItem("denied-job", "10.0.0.1:8080", model.LabelSet{}, ""),
+			target.NewItem("job", "10.0.0.2:8080", model.LabelSet{}, ""),
+			target.NewItem("job", "10.0.0.3:8080", model.LabelSet{"namespace": "kube-system"}, ""),
+			target.NewItem("job", "10.0.0.4:8080", model.LabelSet{"namespace": "default"}, ""),
+		} {
+			targets[item.Hash()] = item
+		}
+		return targets
+	}
+
+	remaining := filter.Apply(newTargets())
+	require.Len(t, remaining, 1)
+	for _, item := range remaining {
+		assert.Equal(t, []string{"10.0.0.4:8080"}, item.TargetURL)
+	}
+
+	// the file is reloaded when it changes
+	require.NoError(t, os.WriteFile(path, []byte("jobs:\n- job\n"), 0600))
+	require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Minute)))
+	remaining = filter.Apply(newTargets())
+	require.Len(t, remaining, 1)
+	for _, item := range remaining {
+		assert.Equal(t, "denied-job", item.JobName)
+	}
+
+	// an invalid file keeps the previous list
+	require.NoError(t, os.WriteFile(path, []byte("unknown: field\n"), 0600))
+	require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(2*time.Minute)))
+	assert.Len(t, filter.Apply(newTargets()), 1)
+}
+
+func TestDenyListWatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "deny-list.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("jobs:\n- denied-job\n"), 0600))
+	filter, err := NewChain(logger, []config.FilterConfig{{Name: "deny-list", Config: map[string]interface{}{"file_path": path}}})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	changed := make(chan struct{}, 1)
+	done := make(chan error)
+	go func() {
+		done <- filter.(Reloader).Watch(ctx, func() {
+			select {
+			case changed <- struct{}{}:
+			default:
+			}
+		})
+	}()
+
+	item := target.NewItem("job", "10.0.0.1:8080", model.LabelSet{}, "")
+	assert.Len(t, filter.Apply(map[string]*target.Item{item.Hash(): item}), 1)
+
+	// the targets are filtered again once the new list is loaded, without waiting for new targets
+	assert.Eventually(t, func() bool {
+		require.NoError(t, os.WriteFile(path, []byte("jobs:\n- job\n"), 0600))
+		require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Minute)))
+		select {
+		case <-changed:
+			return true
+		case <-time.After(100 * time.Millisecond):
+			return false
+		}
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Empty(t, filter.Apply(map[string]*target.Item{item.Hash(): item}))
+
+	cancel()
+	assert.NoError(t, <-done)
+}
+
+func TestDenyListErrors(t *testing.T) {
+	_, err := NewChain(logger, []config.FilterConfig{{Name: "deny-list"}})
+	assert.ErrorContains(t, err, "file_path is required")
+
+	_, err = NewChain(logger, []config.FilterConfig{{Name: "deny-list", Config: map[string]interface{}{"file_path": filepath.Join(t.TempDir(), "missing.yaml")}}})
+	assert.Error(t, err)
+}
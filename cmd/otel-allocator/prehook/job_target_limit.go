@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prehook
+
+import (
+	"errors"
+	"hash/fnv"
+	"sort"
+
+	"github.com/go-logr/logr"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/target"
+)
+
+const (
+	jobTargetLimitFilterName = "job-target-limit"
+)
+
+type jobTargetLimitConfig struct {
+	// Limit is the maximum number of targets kept for each job. Zero means no limit.
+	Limit int `yaml:"limit"`
+	// Jobs overrides the limit for specific jobs.
+	Jobs map[string]int `yaml:"jobs,omitempty"`
+}
+
+// JobTargetLimitFilter keeps at most a fixed number of targets for each job. Targets are sampled by their hash, so
+// that the same targets are kept for as long as they exist, whichever replica does the filtering.
+type JobTargetLimitFilter struct {
+	log logr.Logger
+	cfg jobTargetLimitConfig
+}
+
+func newJobTargetLimitFilter(log logr.Logger, unmarshal func(interface{}) error) (Filter, error) {
+	cfg := jobTargetLimitConfig{}
+	if err := unmarshal(&cfg); err != nil {
+		return nil, err
+	}
+	if cfg.Limit < 0 {
+		return nil, errors.New("limit can't be negative")
+	}
+	for _, limit := range cfg.Jobs {
+		if limit < 0 {
+			return nil, errors.New("limit can't be negative")
+		}
+	}
+	return &JobTargetLimitFilter{log: log, cfg: cfg}, nil
+}
+
+func (f *JobTargetLimitFilter) limit(job string) int {
+	if limit, ok := f.cfg.Jobs[job]; ok {
+		return limit
+	}
+	return f.cfg.Limit
+}
+
+func (f *JobTargetLimitFilter) Apply(targets map[string]*target.Item) map[string]*target.Item {
+	type rankedTarget struct {
+		key  string
+		rank uint64
+	}
+	perJob := map[string][]rankedTarget{}
+	for key, item := range targets {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(item.Hash()))
+		perJob[item.JobName] = append(perJob[item.JobName], rankedTarget{key: key, rank: h.Sum64()})
+	}
+
+	for job, ranked := range perJob {
+		limit := f.limit(job)
+		if limit == 0 || len(ranked) <= limit {
+			continue
+		}
+		sort.Slice(ranked, func(i, j int) bool {
+			if ranked[i].rank != ranked[j].rank {
+				return ranked[i].rank < ranked[j].rank
+			}
+			return ranked[i].key < ranked[j].key
+		})
+		for _, dropped := range ranked[limit:] {
+			delete(targets, dropped.key)
+		}
+		f.log.V(2).Info("Job over its target limit", "job", job, "targets", len(ranked), "limit", limit)
+	}
+	return targets
+}
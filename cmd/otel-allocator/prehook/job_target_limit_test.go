@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prehook
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/config"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/target"
+)
+
+func makeJobTargets(job string, n int) map[string]*target.Item {
+	targets := map[string]*target.Item{}
+	for i := 0; i < n; i++ {
+		item := target.NewItem(job, fmt.Sprintf("10.0.0.%d:8080", i), model.LabelSet{}, "")
+		targets[item.Hash()] = item
+	}
+	return targets
+}
+
+func TestJobTargetLimit(t *testing.T) {
+	filter, err := NewChain(logger, []config.FilterConfig{{
+		Name: "job-target-limit",
+		Config: map[string]interface{}{
+			"limit": 10,
+			"jobs": map[interface{}]interface{}{
+				"small":     2,
+				"unlimited": 0,
+			},
+		},
+	}})
+	require.NoError(t, err)
+
+	targets := makeJobTargets("default", 20)
+	for k, v := range makeJobTargets("small", 5) {
+		targets[k] = v
+	}
+	for k, v := range makeJobTargets("unlimited", 30) {
+		targets[k] = v
+	}
+	for k, v := range makeJobTargets("under", 3) {
+		targets[k] = v
+	}
+
+	perJob := map[string]int{}
+	for _, item := range filter.Apply(targets) {
+		perJob[item.JobName]++
+	}
+	assert.Equal(t, map[string]int{"default": 10, "small": 2, "unlimited": 30, "under": 3}, perJob)
+}
+
+func TestJobTargetLimitIsStable(t *testing.T) {
+	filter, err := NewChain(logger, []config.FilterConfig{{Name: "job-target-limit", Config: map[string]interface{}{"limit": 5}}})
+	require.NoError(t, err)
+
+	kept := filter.Apply(makeJobTargets("job", 20))
+	require.Len(t, kept, 5)
+	// filtering the same targets again keeps the same ones
+	assert.Equal(t, kept, filter.Apply(makeJobTargets("job", 20)))
+
+	// a new target displaces at most one of the kept ones
+	targets := makeJobTargets("job", 21)
+	keptAgain := filter.Apply(targets)
+	unchanged := 0
+	for hash := range keptAgain {
+		if _, ok := kept[hash]; ok {
+			unchanged++
+		}
+	}
+	assert.GreaterOrEqual(t, unchanged, 4)
+}
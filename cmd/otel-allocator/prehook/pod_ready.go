@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prehook
+
+import (
+	"github.com/go-logr/logr"
+	"github.com/prometheus/common/model"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/target"
+)
+
+const (
+	podReadyFilterName = "pod-ready"
+
+	// podReadyLabel is set by Kubernetes service discovery for targets backed by a pod.
+	podReadyLabel model.LabelName = "__meta_kubernetes_pod_ready"
+)
+
+// PodReadyFilter drops targets whose pod isn't Ready. Targets which aren't backed by a pod are kept.
+type PodReadyFilter struct {
+	log logr.Logger
+}
+
+func newPodReadyFilter(log logr.Logger, unmarshal func(interface{}) error) (Filter, error) {
+	// there are no options, this rejects any given
+	if err := unmarshal(&struct{}{}); err != nil {
+		return nil, err
+	}
+	return &PodReadyFilter{log: log}, nil
+}
+
+func (f *PodReadyFilter) Apply(targets map[string]*target.Item) map[string]*target.Item {
+	for key, item := range targets {
+		if ready, ok := item.Labels[podReadyLabel]; ok && ready != "true" {
+			delete(targets, key)
+		}
+	}
+	return targets
+}
@@ -15,11 +15,18 @@
 package prehook
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"sync"
 
 	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/prometheus/prometheus/model/relabel"
+	"gopkg.in/yaml.v2"
 
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/config"
 	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/target"
 )
 
@@ -27,28 +34,86 @@ const (
 	relabelConfigTargetFilterName = "relabel-config"
 )
 
+var (
+	targetsKept = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "opentelemetry_allocator_filter_targets_kept",
+		Help: "Number of targets kept by each filter of the filter chain.",
+	}, []string{"filter"})
+	targetsDropped = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "opentelemetry_allocator_filter_targets_dropped",
+		Help: "Number of targets dropped by each filter of the filter chain.",
+	}, []string{"filter"})
+)
+
+// Hook filters targets before they are allocated. It's given the relabel configuration of every job, whether it uses
+// it or not.
 type Hook interface {
 	Apply(map[string]*target.Item) map[string]*target.Item
 	SetConfig(map[string][]*relabel.Config)
 	GetConfig() map[string][]*relabel.Config
 }
 
-type HookProvider func(log logr.Logger) Hook
+// Filter is a single step of the filter chain. Filters which need the relabel configuration of every job should
+// implement Hook.
+type Filter interface {
+	// Apply returns the targets to keep. It may modify the map it's given.
+	Apply(map[string]*target.Item) map[string]*target.Item
+}
+
+// Reloader is implemented by the filters whose configuration can change while the allocator runs.
+type Reloader interface {
+	// Watch reloads the configuration of the filter whenever it changes, and calls onChange once it's reloaded so the
+	// targets are filtered again. It returns when ctx is done.
+	Watch(ctx context.Context, onChange func()) error
+}
+
+// FilterProvider creates a filter. Its configuration, if any, is decoded with unmarshal.
+type FilterProvider func(log logr.Logger, unmarshal func(interface{}) error) (Filter, error)
 
 var (
-	registry = map[string]HookProvider{}
+	registry = map[string]FilterProvider{}
 )
 
+// New creates a chain with the single given filter, or returns nil if the filter doesn't exist.
 func New(name string, log logr.Logger) Hook {
-	if p, ok := registry[name]; ok {
-		return p(log.WithName("Prehook").WithName(name))
+	chain, err := NewChain(log, []config.FilterConfig{{Name: name}})
+	if err != nil {
+		log.Info("Unrecognized filter strategy; filtering disabled")
+		return nil
 	}
+	return chain
+}
 
-	log.Info("Unrecognized filter strategy; filtering disabled")
-	return nil
+// NewChain creates a chain applying the given filters in order.
+func NewChain(log logr.Logger, filters []config.FilterConfig) (Hook, error) {
+	chain := &Chain{}
+	for _, filterCfg := range filters {
+		p, ok := registry[filterCfg.Name]
+		if !ok {
+			return nil, fmt.Errorf("unregistered filter: %s", filterCfg.Name)
+		}
+		options := filterCfg.Config
+		filter, err := p(log.WithName("Prehook").WithName(filterCfg.Name), func(out interface{}) error {
+			if options == nil {
+				return nil
+			}
+			// the options were decoded generically along with the rest of the configuration
+			raw, err := yaml.Marshal(options)
+			if err != nil {
+				return err
+			}
+			return yaml.UnmarshalStrict(raw, out)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("invalid configuration for filter %s: %w", filterCfg.Name, err)
+		}
+		chain.names = append(chain.names, filterCfg.Name)
+		chain.filters = append(chain.filters, filter)
+	}
+	return chain, nil
 }
 
-func Register(name string, provider HookProvider) error {
+func Register(name string, provider FilterProvider) error {
 	if _, ok := registry[name]; ok {
 		return errors.New("already registered")
 	}
@@ -56,9 +121,86 @@ func Register(name string, provider HookProvider) error {
 	return nil
 }
 
+var (
+	_ Hook     = &Chain{}
+	_ Reloader = &Chain{}
+)
+
+// Chain applies a list of filters in order, counting how many targets each one keeps and drops.
+type Chain struct {
+	names   []string
+	filters []Filter
+}
+
+func (c *Chain) Apply(targets map[string]*target.Item) map[string]*target.Item {
+	for i, filter := range c.filters {
+		before := len(targets)
+		targets = filter.Apply(targets)
+		targetsKept.WithLabelValues(c.names[i]).Add(float64(len(targets)))
+		targetsDropped.WithLabelValues(c.names[i]).Add(float64(before - len(targets)))
+	}
+	return targets
+}
+
+// Watch runs the watches of the filters which can be reloaded, until ctx is done or one of them fails.
+func (c *Chain) Watch(ctx context.Context, onChange func()) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var (
+		wg   sync.WaitGroup
+		once sync.Once
+		err  error
+	)
+	for _, filter := range c.filters {
+		reloader, ok := filter.(Reloader)
+		if !ok {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if watchErr := reloader.Watch(ctx, onChange); watchErr != nil {
+				once.Do(func() { err = watchErr })
+				cancel()
+			}
+		}()
+	}
+	<-ctx.Done()
+	wg.Wait()
+	return err
+}
+
+// SetConfig passes the relabel configuration on to the filters which use it.
+func (c *Chain) SetConfig(cfgs map[string][]*relabel.Config) {
+	for _, filter := range c.filters {
+		if hook, ok := filter.(Hook); ok {
+			hook.SetConfig(cfgs)
+		}
+	}
+}
+
+// GetConfig returns the relabel configuration of the first filter using it.
+func (c *Chain) GetConfig() map[string][]*relabel.Config {
+	for _, filter := range c.filters {
+		if hook, ok := filter.(Hook); ok {
+			return hook.GetConfig()
+		}
+	}
+	return nil
+}
+
 func init() {
-	err := Register(relabelConfigTargetFilterName, NewRelabelConfigTargetFilter)
-	if err != nil {
-		panic(err)
+	for name, provider := range map[string]FilterProvider{
+		relabelConfigTargetFilterName: func(log logr.Logger, _ func(interface{}) error) (Filter, error) {
+			return NewRelabelConfigTargetFilter(log), nil
+		},
+		jobTargetLimitFilterName: newJobTargetLimitFilter,
+		denyListFilterName:       newDenyListFilter,
+		podReadyFilterName:       newPodReadyFilter,
+	} {
+		err := Register(name, provider)
+		if err != nil {
+			panic(err)
+		}
 	}
 }
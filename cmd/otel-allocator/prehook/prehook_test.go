@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prehook
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/relabel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/config"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/target"
+)
+
+func TestChain(t *testing.T) {
+	chain, err := NewChain(logger, []config.FilterConfig{
+		{Name: "relabel-config"},
+		{Name: "pod-ready"},
+		{Name: "job-target-limit", Config: map[string]interface{}{"limit": 1}},
+	})
+	require.NoError(t, err)
+
+	targets := map[string]*target.Item{}
+	for _, item := range []*target.Item{
+		target.NewItem("job", "10.0.0.1:8080", model.LabelSet{"__meta_kubernetes_pod_ready": "true", "env": "prod"}, ""),
+		target.NewItem("job", "10.0.0.2:8080", model.LabelSet{"__meta_kubernetes_pod_ready": "true", "env": "prod"}, ""),
+		target.NewItem("job", "10.0.0.3:8080", model.LabelSet{"__meta_kubernetes_pod_ready": "false", "env": "prod"}, ""),
+		target.NewItem("job", "10.0.0.4:8080", model.LabelSet{"__meta_kubernetes_pod_ready": "true", "env": "dev"}, ""),
+	} {
+		targets[item.Hash()] = item
+	}
+	chain.SetConfig(map[string][]*relabel.Config{
+		"job": {{
+			SourceLabels: model.LabelNames{"env"},
+			Regex:        relabel.MustNewRegexp("dev"),
+			Action:       "drop",
+			Separator:    ";",
+			Replacement:  "$1",
+		}},
+	})
+	assert.Len(t, chain.GetConfig()["job"], 1)
+
+	keptBefore := testutil.ToFloat64(targetsKept.WithLabelValues("pod-ready"))
+	droppedBefore := testutil.ToFloat64(targetsDropped.WithLabelValues("pod-ready"))
+
+	remaining := chain.Apply(targets)
+	assert.Len(t, remaining, 1)
+	for _, item := range remaining {
+		assert.Equal(t, model.LabelValue("true"), item.Labels["__meta_kubernetes_pod_ready"])
+		assert.Equal(t, model.LabelValue("prod"), item.Labels["env"])
+	}
+	// relabeling dropped the dev target before it got to the pod-ready filter
+	assert.Equal(t, float64(2), testutil.ToFloat64(targetsKept.WithLabelValues("pod-ready"))-keptBefore)
+	assert.Equal(t, float64(1), testutil.ToFloat64(targetsDropped.WithLabelValues("pod-ready"))-droppedBefore)
+}
+
+func TestNewChainErrors(t *testing.T) {
+	_, err := NewChain(logger, []config.FilterConfig{{Name: "unknown"}})
+	assert.ErrorContains(t, err, "unregistered filter: unknown")
+
+	_, err = NewChain(logger, []config.FilterConfig{{Name: "job-target-limit", Config: map[string]interface{}{"limit": -1}}})
+	assert.ErrorContains(t, err, "limit can't be negative")
+
+	_, err = NewChain(logger, []config.FilterConfig{{Name: "pod-ready", Config: map[string]interface{}{"unexpected": true}}})
+	assert.ErrorContains(t, err, "invalid configuration for filter pod-ready")
+}
+
+func TestNewUnrecognizedFilterStrategy(t *testing.T) {
+	assert.Nil(t, New("unknown", logger))
+	assert.Nil(t, New("", logger))
+}
@@ -31,8 +31,51 @@ func (m *mockAllocator) SetCollectors(_ map[string]*allocation.Collector)
 func (m *mockAllocator) SetTargets(_ map[string]*target.Item)                           {}
 func (m *mockAllocator) Collectors() map[string]*allocation.Collector                   { return nil }
 func (m *mockAllocator) GetTargetsForCollectorAndJob(_ string, _ string) []*target.Item { return nil }
-func (m *mockAllocator) SetFilter(_ allocation.Filter)                                  {}
+func (m *mockAllocator) CollectorTargets(_ string) (map[string][]*target.Item, uint64, <-chan struct{}) {
+	return nil, 0, nil
+}
+func (m *mockAllocator) SetFilter(_ allocation.Filter)                     {}
+func (m *mockAllocator) SetCostSource(_ allocation.CostSource)             {}
+func (m *mockAllocator) SetTopology(_ string, _ allocation.TopologySource) {}
+func (m *mockAllocator) RestoreAssignments(_ map[string]*allocation.Collector, _ map[string]*target.Item) {
+}
+func (m *mockAllocator) CostAware() bool          { return false }
+func (m *mockAllocator) Rebalance()               {}
+func (m *mockAllocator) Generation() uint64       { return 0 }
+func (m *mockAllocator) Changed() <-chan struct{} { return nil }
 
 func (m *mockAllocator) TargetItems() map[string]*target.Item {
 	return m.targetItems
 }
+
+func (m *mockAllocator) TargetAssignments() []allocation.TargetAssignment {
+	var assignments []allocation.TargetAssignment
+	for _, item := range m.targetItems {
+		assignments = append(assignments, allocation.TargetAssignment{Item: item, Collector: item.CollectorName})
+	}
+	return assignments
+}
+
+var _ Leadership = &mockLeadership{}
+
+// mockLeadership is a fixed view of the leader election.
+type mockLeadership struct {
+	leader  bool
+	term    int64
+	address string
+}
+
+func (m *mockLeadership) IsLeader() bool { return m.leader }
+func (m *mockLeadership) Term() int64    { return m.term }
+func (m *mockLeadership) LeaderAddress() (string, bool) {
+	return m.address, m.address != ""
+}
+
+var _ Staleness = &mockStaleness{}
+
+// mockStaleness is a fixed view of whether assignments are stale.
+type mockStaleness struct {
+	stale bool
+}
+
+func (m *mockStaleness) Stale() bool { return m.stale }
@@ -16,12 +16,17 @@ package server
 
 import (
 	"context"
+	"crypto/subtle"
 	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"hash/fnv"
 	"net/http"
+	"net/http/httputil"
 	"net/http/pprof"
 	"net/url"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -38,6 +43,7 @@ import (
 	"gopkg.in/yaml.v2"
 
 	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/allocation"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/feedback"
 	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/target"
 )
 
@@ -48,6 +54,21 @@ var (
 	}, []string{"path"})
 )
 
+const (
+	// GenerationHeader carries the generation of the assignments a response is based on, as "<term>.<generation>".
+	// Collectors can compare the pair to discard answers older than ones they already received.
+	GenerationHeader = "X-Allocation-Generation"
+	// StaleHeader is set on responses based on assignments restored from a snapshot, which haven't been reconciled
+	// with the live targets and collectors yet.
+	StaleHeader = "X-Allocation-Stale"
+	// forwardedHeader marks requests a follower forwarded to the leader, so that they're never forwarded twice.
+	forwardedHeader = "X-Allocation-Forwarded"
+	// maxWait is the longest a long polling request is held.
+	maxWait = 5 * time.Minute
+	// termKey is the context key of the leadership term assignment responses are stamped with.
+	termKey = "allocation-term"
+)
+
 var (
 	jsonConfig = jsoniter.Config{
 		EscapeHTML:                    false,
@@ -61,6 +82,26 @@ type collectorJSON struct {
 	Jobs []*target.Item `json:"targets"`
 }
 
+type targetFeedbackJSON struct {
+	JobName       string                 `json:"job_name"`
+	Target        string                 `json:"target"`
+	CollectorName string                 `json:"collector_name"`
+	LastReport    *feedback.TargetStatus `json:"last_report"`
+}
+
+// Leadership tells whether this replica is the one serving assignments, and where the leader can be reached if not.
+type Leadership interface {
+	IsLeader() bool
+	// Term increases every time a new leader is elected.
+	Term() int64
+	LeaderAddress() (string, bool)
+}
+
+// Staleness tells whether the assignments being served were restored from a snapshot and aren't up-to-date yet.
+type Staleness interface {
+	Stale() bool
+}
+
 type Server struct {
 	logger         logr.Logger
 	allocator      allocation.Allocator
@@ -68,6 +109,16 @@ type Server struct {
 	httpsServer    *http.Server
 	jsonMarshaller jsoniter.API
 
+	// feedback is nil unless collectors are allowed to report scrape feedback.
+	feedback      *feedback.Store
+	feedbackToken string
+
+	// leadership is nil unless multiple replicas elect a leader.
+	leadership Leadership
+
+	// staleness is nil unless assignments are restored from snapshots.
+	staleness Staleness
+
 	// Use RWMutex to protect scrapeConfigResponse, since it
 	// will be predominantly read and only written when config
 	// is applied.
@@ -82,10 +133,31 @@ type Option func(*Server)
 // Used for getting the scrape config with real secret values.
 func WithTLSConfig(tlsConfig *tls.Config, httpsListenAddr string) Option {
 	return func(s *Server) {
-		httpsRouter := gin.New()
-		s.setRouter(httpsRouter)
+		s.httpsServer = &http.Server{Addr: httpsListenAddr, ReadHeaderTimeout: 90 * time.Second, TLSConfig: tlsConfig}
+	}
+}
 
-		s.httpsServer = &http.Server{Addr: httpsListenAddr, Handler: httpsRouter, ReadHeaderTimeout: 90 * time.Second, TLSConfig: tlsConfig}
+// WithFeedback enables the endpoints collectors use to report scrape feedback. Writes are accepted over the mTLS
+// server, or over plain HTTP if they carry the given bearer token. An empty token only allows writes over mTLS.
+func WithFeedback(store *feedback.Store, token string) Option {
+	return func(s *Server) {
+		s.feedback = store
+		s.feedbackToken = token
+	}
+}
+
+// WithLeadership makes the server only answer assignment requests when it's the leader. Followers forward them to the
+// leader over plain HTTP, so feedback reports need the bearer token to be accepted through a follower.
+func WithLeadership(leadership Leadership) Option {
+	return func(s *Server) {
+		s.leadership = leadership
+	}
+}
+
+// WithStaleness marks assignment responses with the StaleHeader while staleness reports them as stale.
+func WithStaleness(staleness Staleness) Option {
+	return func(s *Server) {
+		s.staleness = staleness
 	}
 }
 
@@ -96,8 +168,13 @@ func (s *Server) setRouter(router *gin.Engine) {
 	router.Use(s.PrometheusMiddleware)
 
 	router.GET("/scrape_configs", s.ScrapeConfigsHandler)
-	router.GET("/jobs", s.JobHandler)
-	router.GET("/jobs/:job_id/targets", s.TargetsHandler)
+	router.GET("/jobs", s.LeaderMiddleware, s.JobHandler)
+	router.GET("/jobs/:job_id/targets", s.LeaderMiddleware, s.TargetsHandler)
+	router.GET("/targets", s.LeaderMiddleware, s.CollectorTargetsHandler)
+	if s.feedback != nil {
+		router.POST("/feedback", s.LeaderMiddleware, s.FeedbackAuthMiddleware, s.FeedbackHandler)
+		router.GET("/feedback", s.LeaderMiddleware, s.FeedbackStatusHandler)
+	}
 	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
 	router.GET("/livez", s.LivenessProbeHandler)
 	router.GET("/readyz", s.ReadinessProbeHandler)
@@ -111,14 +188,20 @@ func NewServer(log logr.Logger, allocator allocation.Allocator, listenAddr strin
 		jsonMarshaller: jsonConfig,
 	}
 
+	for _, opt := range options {
+		opt(s)
+	}
+
 	gin.SetMode(gin.ReleaseMode)
 	router := gin.New()
 	s.setRouter(router)
 
 	s.server = &http.Server{Addr: listenAddr, Handler: router, ReadHeaderTimeout: 90 * time.Second}
 
-	for _, opt := range options {
-		opt(s)
+	if s.httpsServer != nil {
+		httpsRouter := gin.New()
+		s.setRouter(httpsRouter)
+		s.httpsServer.Handler = httpsRouter
 	}
 
 	return s
@@ -281,6 +364,90 @@ func (s *Server) PrometheusMiddleware(c *gin.Context) {
 	timer.ObserveDuration()
 }
 
+// LeaderMiddleware lets the leader answer assignment requests, stamping them with the generation they're based on and
+// whether they're stale, and forwards them to the leader on followers.
+func (s *Server) LeaderMiddleware(c *gin.Context) {
+	if s.leadership == nil {
+		s.stamp(c, 0)
+		c.Next()
+		return
+	}
+	if s.leadership.IsLeader() {
+		s.stamp(c, s.leadership.Term())
+		c.Next()
+		return
+	}
+	address, ok := s.leadership.LeaderAddress()
+	if !ok || c.GetHeader(forwardedHeader) != "" {
+		// either there's no leader yet, or the replica we forwarded to isn't the leader anymore
+		c.Abort()
+		s.statusErrorHandler(c.Writer, http.StatusServiceUnavailable, errors.New("no leader elected"))
+		return
+	}
+	c.Request.Header.Set(forwardedHeader, "true")
+	proxy := httputil.NewSingleHostReverseProxy(&url.URL{Scheme: "http", Host: address})
+	proxy.ErrorHandler = func(w http.ResponseWriter, _ *http.Request, err error) {
+		s.statusErrorHandler(w, http.StatusBadGateway, err)
+	}
+	proxy.ServeHTTP(c.Writer, c.Request)
+	c.Abort()
+}
+
+// stamp makes the response carry the headers describing the assignments it's based on. They're set when the response
+// is written, so that a held request isn't stamped with the generation it started at. Handlers which read the
+// assignments as a snapshot set the generation of the snapshot themselves with setGeneration.
+func (s *Server) stamp(c *gin.Context, term int64) {
+	c.Set(termKey, term)
+	c.Writer = &stampingWriter{ResponseWriter: c.Writer, stamp: func() {
+		if c.Writer.Header().Get(GenerationHeader) == "" {
+			s.setGeneration(c, s.allocator.Generation())
+		}
+		if s.staleness != nil && s.staleness.Stale() {
+			c.Writer.Header().Set(StaleHeader, "true")
+		}
+	}}
+}
+
+// setGeneration sets the generation header of a stamped response.
+func (s *Server) setGeneration(c *gin.Context, generation uint64) {
+	term, ok := c.Get(termKey)
+	if !ok {
+		return
+	}
+	c.Header(GenerationHeader, fmt.Sprintf("%d.%d", term, generation))
+}
+
+// stampingWriter stamps the response once its status is set, or right before its headers are written.
+type stampingWriter struct {
+	gin.ResponseWriter
+	stamp func()
+	once  sync.Once
+}
+
+func (w *stampingWriter) WriteHeader(code int) {
+	if !w.Written() {
+		w.once.Do(w.stamp)
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *stampingWriter) WriteHeaderNow() {
+	if !w.Written() {
+		w.once.Do(w.stamp)
+	}
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *stampingWriter) Write(data []byte) (int, error) {
+	w.WriteHeaderNow()
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *stampingWriter) WriteString(data string) (int, error) {
+	w.WriteHeaderNow()
+	return w.ResponseWriter.WriteString(data)
+}
+
 func (s *Server) TargetsHandler(c *gin.Context) {
 	q := c.Request.URL.Query()["collector_id"]
 
@@ -306,6 +473,168 @@ func (s *Server) TargetsHandler(c *gin.Context) {
 	}
 }
 
+// CollectorTargetsHandler returns the targets of every job assigned to a collector in a single response, keyed by job
+// name. It supports conditional requests with If-None-Match, and long polling with the wait parameter: if the
+// collector's targets still match the given ETag, the response is held until they change or the wait is over.
+func (s *Server) CollectorTargetsHandler(c *gin.Context) {
+	q := c.Request.URL.Query()
+	collectorName := q.Get("collector_id")
+	if collectorName == "" {
+		s.statusErrorHandler(c.Writer, http.StatusBadRequest, errors.New("collector_id is required"))
+		return
+	}
+	var wait time.Duration
+	if waitParam := q.Get("wait"); waitParam != "" {
+		var err error
+		wait, err = time.ParseDuration(waitParam)
+		if err != nil || wait < 0 {
+			s.statusErrorHandler(c.Writer, http.StatusBadRequest, fmt.Errorf("invalid wait duration %q", waitParam))
+			return
+		}
+		wait = min(wait, maxWait)
+	}
+
+	timeout := time.NewTimer(wait)
+	defer timeout.Stop()
+	for {
+		targets, generation, changed := s.allocator.CollectorTargets(collectorName)
+		etag := targetsETag(targets)
+		c.Header("ETag", etag)
+		s.setGeneration(c, generation)
+		if !etagMatches(c.GetHeader("If-None-Match"), etag) {
+			s.jsonHandler(c.Writer, targets)
+			return
+		}
+		if wait == 0 {
+			c.Status(http.StatusNotModified)
+			return
+		}
+		select {
+		case <-changed:
+			// the change may not concern this collector, in which case we keep waiting
+		case <-timeout.C:
+			c.Status(http.StatusNotModified)
+			return
+		case <-c.Request.Context().Done():
+			return
+		}
+	}
+}
+
+// targetsETag sorts the targets of every job, and returns an ETag identifying them.
+func targetsETag(targets map[string][]*target.Item) string {
+	jobNames := make([]string, 0, len(targets))
+	for job := range targets {
+		jobNames = append(jobNames, job)
+	}
+	sort.Strings(jobNames)
+
+	h := fnv.New64a()
+	for _, job := range jobNames {
+		items := targets[job]
+		sort.Slice(items, func(i, j int) bool {
+			return items[i].Hash() < items[j].Hash()
+		})
+		for _, item := range items {
+			// the hash includes the job name, target URL and labels, which is all we return
+			_, _ = h.Write([]byte(item.Hash()))
+			_, _ = h.Write([]byte{0})
+		}
+	}
+	return fmt.Sprintf("\"%x\"", h.Sum64())
+}
+
+// etagMatches returns whether the If-None-Match header value matches the ETag.
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// FeedbackAuthMiddleware only lets through requests made over the mTLS server, or carrying the feedback bearer token.
+func (s *Server) FeedbackAuthMiddleware(c *gin.Context) {
+	// the HTTPS server requires and verifies client certificates
+	if c.Request.TLS != nil {
+		c.Next()
+		return
+	}
+	token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if ok && s.feedbackToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(s.feedbackToken)) == 1 {
+		c.Next()
+		return
+	}
+	c.Abort()
+	s.statusErrorHandler(c.Writer, http.StatusUnauthorized, errors.New("unauthorized"))
+}
+
+// FeedbackHandler stores the scrape feedback a collector reports for the targets it scrapes.
+func (s *Server) FeedbackHandler(c *gin.Context) {
+	q := c.Request.URL.Query()["collector_id"]
+	if len(q) == 0 {
+		s.statusErrorHandler(c.Writer, http.StatusBadRequest, errors.New("collector_id is required"))
+		return
+	}
+	collectorName := q[0]
+	if _, ok := s.allocator.Collectors()[collectorName]; !ok {
+		s.statusErrorHandler(c.Writer, http.StatusNotFound, fmt.Errorf("unknown collector %s", collectorName))
+		return
+	}
+
+	var report feedback.Report
+	if err := s.jsonMarshaller.NewDecoder(c.Request.Body).Decode(&report); err != nil {
+		s.statusErrorHandler(c.Writer, http.StatusBadRequest, err)
+		return
+	}
+	s.feedback.Update(collectorName, report)
+
+	unreported := 0
+	for _, assignment := range s.allocator.TargetAssignments() {
+		if assignment.Collector == collectorName && !s.feedback.IsReported(collectorName, assignment.Item) {
+			unreported++
+		}
+	}
+	feedback.TargetsUnreported.WithLabelValues(collectorName).Set(float64(unreported))
+	c.Status(http.StatusNoContent)
+}
+
+// FeedbackStatusHandler returns every allocated target along with the last feedback reported for it, optionally
+// filtered to the targets assigned to a single collector. Targets without a report have a null last_report.
+func (s *Server) FeedbackStatusHandler(c *gin.Context) {
+	q := c.Request.URL.Query()["collector_id"]
+
+	displayData := []targetFeedbackJSON{}
+	for _, assignment := range s.allocator.TargetAssignments() {
+		if len(q) > 0 && assignment.Collector != q[0] {
+			continue
+		}
+		item := assignment.Item
+		for _, targetURL := range item.TargetURL {
+			entry := targetFeedbackJSON{JobName: item.JobName, Target: targetURL, CollectorName: assignment.Collector}
+			if status, ok := s.feedback.Get(item.JobName, targetURL); ok {
+				entry.LastReport = status
+			}
+			displayData = append(displayData, entry)
+		}
+	}
+	sort.Slice(displayData, func(i, j int) bool {
+		if displayData[i].JobName != displayData[j].JobName {
+			return displayData[i].JobName < displayData[j].JobName
+		}
+		return displayData[i].Target < displayData[j].Target
+	})
+	s.jsonHandler(c.Writer, displayData)
+}
+
+func (s *Server) statusErrorHandler(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	s.jsonHandler(w, map[string]string{"error": err.Error()})
+}
+
 func (s *Server) errorHandler(w http.ResponseWriter, err error) {
 	w.WriteHeader(http.StatusInternalServerError)
 	s.jsonHandler(w, err)
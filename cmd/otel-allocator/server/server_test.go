@@ -22,6 +22,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strings"
 	"testing"
 	"time"
 
@@ -36,6 +37,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/allocation"
 	allocatorconfig "github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/config"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/feedback"
 	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/target"
 )
 
@@ -740,3 +742,299 @@ func TestServer_ScrapeConfigRespose(t *testing.T) {
 func newLink(jobName string) target.LinkJSON {
 	return target.LinkJSON{Link: fmt.Sprintf("/jobs/%s/targets", url.QueryEscape(jobName))}
 }
+
+func TestServer_FeedbackHandler(t *testing.T) {
+	report := `{"targets":[{"job_name":"test-job","target":"test-url","scrape_duration_seconds":0.5,"samples_scraped":100}]}`
+	tests := []struct {
+		description  string
+		collector    string
+		token        string
+		tls          bool
+		body         string
+		expectedCode int
+	}{
+		{
+			description:  "valid token",
+			collector:    "test-collector",
+			token:        "secret",
+			body:         report,
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			description:  "mTLS without token",
+			collector:    "test-collector",
+			tls:          true,
+			body:         report,
+			expectedCode: http.StatusNoContent,
+		},
+		{
+			description:  "missing token",
+			collector:    "test-collector",
+			body:         report,
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			description:  "wrong token",
+			collector:    "test-collector",
+			token:        "wrong",
+			body:         report,
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			description:  "missing collector id",
+			token:        "secret",
+			body:         report,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			description:  "unknown collector",
+			collector:    "unknown-collector",
+			token:        "secret",
+			body:         report,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			description:  "malformed report",
+			collector:    "test-collector",
+			token:        "secret",
+			body:         `{"targets":`,
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			allocator, _ := allocation.New("least-weighted", logger)
+			allocator.SetCollectors(map[string]*allocation.Collector{"test-collector": {Name: "test-collector"}})
+			store := feedback.NewStore()
+			s := NewServer(logger, allocator, ":8080", WithFeedback(store, "secret"))
+
+			path := "/feedback"
+			if tc.collector != "" {
+				path += "?collector_id=" + tc.collector
+			}
+			request := httptest.NewRequest("POST", path, strings.NewReader(tc.body))
+			if tc.token != "" {
+				request.Header.Set("Authorization", "Bearer "+tc.token)
+			}
+			if tc.tls {
+				request.TLS = &tls.ConnectionState{}
+			}
+			w := httptest.NewRecorder()
+
+			s.server.Handler.ServeHTTP(w, request)
+			result := w.Result()
+
+			assert.Equal(t, tc.expectedCode, result.StatusCode)
+			_, reported := store.Get("test-job", "test-url")
+			assert.Equal(t, tc.expectedCode == http.StatusNoContent, reported)
+		})
+	}
+}
+
+func TestServer_FeedbackStatusHandler(t *testing.T) {
+	allocator, _ := allocation.New("least-weighted", logger)
+	allocator.SetCollectors(map[string]*allocation.Collector{"test-collector": {Name: "test-collector"}})
+	allocator.SetTargets(map[string]*target.Item{
+		baseTargetItem.Hash():       target.NewItem("test-job", "test-url", baseLabelSet, ""),
+		testJobTargetItemTwo.Hash(): target.NewItem("test-job", "test-url2", testJobLabelSetTwo, ""),
+	})
+	store := feedback.NewStore()
+	store.Update("test-collector", feedback.Report{Targets: []feedback.TargetReport{
+		{JobName: "test-job", Target: "test-url", SamplesScraped: 100, LastError: "connection refused"},
+	}})
+	s := NewServer(logger, allocator, ":8080", WithFeedback(store, ""))
+
+	request := httptest.NewRequest("GET", "/feedback?collector_id=test-collector", nil)
+	w := httptest.NewRecorder()
+	s.server.Handler.ServeHTTP(w, request)
+	result := w.Result()
+	assert.Equal(t, http.StatusOK, result.StatusCode)
+
+	bodyBytes, err := io.ReadAll(result.Body)
+	require.NoError(t, err)
+	var statuses []targetFeedbackJSON
+	require.NoError(t, json.Unmarshal(bodyBytes, &statuses))
+	require.Len(t, statuses, 2)
+	assert.Equal(t, "test-url", statuses[0].Target)
+	require.NotNil(t, statuses[0].LastReport)
+	assert.Equal(t, "connection refused", statuses[0].LastReport.LastError)
+	assert.Equal(t, "test-url2", statuses[1].Target)
+	assert.Nil(t, statuses[1].LastReport, "targets which were never scraped should have no report")
+}
+
+func TestServer_FeedbackDisabled(t *testing.T) {
+	allocator, _ := allocation.New("least-weighted", logger)
+	s := NewServer(logger, allocator, ":8080")
+	request := httptest.NewRequest("GET", "/feedback", nil)
+	w := httptest.NewRecorder()
+	s.server.Handler.ServeHTTP(w, request)
+	assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+}
+
+func TestServer_Leadership(t *testing.T) {
+	allocator, _ := allocation.New("least-weighted", logger)
+	allocator.SetCollectors(map[string]*allocation.Collector{"test-collector": {Name: "test-collector"}})
+	allocator.SetTargets(map[string]*target.Item{
+		baseTargetItem.Hash(): target.NewItem("test-job", "test-url", baseLabelSet, ""),
+	})
+	leader := NewServer(logger, allocator, ":8080", WithLeadership(&mockLeadership{leader: true, term: 3}))
+	leaderServer := httptest.NewServer(leader.server.Handler)
+	defer leaderServer.Close()
+	leaderURL, err := url.Parse(leaderServer.URL)
+	require.NoError(t, err)
+
+	// the follower's own allocator has no targets, everything it returns comes from the leader
+	followerAllocator, _ := allocation.New("least-weighted", logger)
+
+	t.Run("leader answers with its generation", func(t *testing.T) {
+		request := httptest.NewRequest("GET", "/jobs/test-job/targets?collector_id=test-collector", nil)
+		w := httptest.NewRecorder()
+		leader.server.Handler.ServeHTTP(w, request)
+		result := w.Result()
+		assert.Equal(t, http.StatusOK, result.StatusCode)
+		assert.Equal(t, fmt.Sprintf("3.%d", allocator.Generation()), result.Header.Get(GenerationHeader))
+	})
+	t.Run("follower forwards to the leader", func(t *testing.T) {
+		follower := NewServer(logger, followerAllocator, ":8080", WithLeadership(&mockLeadership{address: leaderURL.Host}))
+		// proxying needs a real connection rather than a response recorder
+		followerServer := httptest.NewServer(follower.server.Handler)
+		defer followerServer.Close()
+		result, err := http.Get(followerServer.URL + "/jobs/test-job/targets?collector_id=test-collector")
+		require.NoError(t, err)
+		defer result.Body.Close()
+		assert.Equal(t, http.StatusOK, result.StatusCode)
+		assert.Equal(t, fmt.Sprintf("3.%d", allocator.Generation()), result.Header.Get(GenerationHeader))
+		bodyBytes, err := io.ReadAll(result.Body)
+		require.NoError(t, err)
+		var items []*target.Item
+		require.NoError(t, json.Unmarshal(bodyBytes, &items))
+		require.Len(t, items, 1)
+		assert.Equal(t, "test-url", items[0].TargetURL[0])
+	})
+	t.Run("follower without a leader is unavailable", func(t *testing.T) {
+		follower := NewServer(logger, followerAllocator, ":8080", WithLeadership(&mockLeadership{}))
+		request := httptest.NewRequest("GET", "/jobs", nil)
+		w := httptest.NewRecorder()
+		follower.server.Handler.ServeHTTP(w, request)
+		assert.Equal(t, http.StatusServiceUnavailable, w.Result().StatusCode)
+	})
+	t.Run("forwarded requests are not forwarded again", func(t *testing.T) {
+		follower := NewServer(logger, followerAllocator, ":8080", WithLeadership(&mockLeadership{address: leaderURL.Host}))
+		request := httptest.NewRequest("GET", "/jobs", nil)
+		request.Header.Set(forwardedHeader, "true")
+		w := httptest.NewRecorder()
+		follower.server.Handler.ServeHTTP(w, request)
+		assert.Equal(t, http.StatusServiceUnavailable, w.Result().StatusCode)
+	})
+	t.Run("scrape configs are served locally", func(t *testing.T) {
+		follower := NewServer(logger, followerAllocator, ":8080", WithLeadership(&mockLeadership{}))
+		require.NoError(t, follower.UpdateScrapeConfigResponse(map[string]*promconfig.ScrapeConfig{}))
+		request := httptest.NewRequest("GET", "/scrape_configs", nil)
+		w := httptest.NewRecorder()
+		follower.server.Handler.ServeHTTP(w, request)
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	})
+}
+
+func TestServer_Staleness(t *testing.T) {
+	allocator, _ := allocation.New("least-weighted", logger)
+	staleness := &mockStaleness{stale: true}
+	s := NewServer(logger, allocator, ":8080", WithStaleness(staleness))
+
+	request := httptest.NewRequest("GET", "/jobs", nil)
+	w := httptest.NewRecorder()
+	s.server.Handler.ServeHTTP(w, request)
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	assert.Equal(t, "true", w.Result().Header.Get(StaleHeader))
+
+	staleness.stale = false
+	w = httptest.NewRecorder()
+	s.server.Handler.ServeHTTP(w, request)
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	assert.Empty(t, w.Result().Header.Get(StaleHeader))
+}
+
+func TestServer_CollectorTargetsHandler(t *testing.T) {
+	newAllocator := func() allocation.Allocator {
+		allocator, _ := allocation.New("least-weighted", logger)
+		allocator.SetCollectors(map[string]*allocation.Collector{"test-collector": {Name: "test-collector"}})
+		allocator.SetTargets(map[string]*target.Item{
+			baseTargetItem.Hash():       target.NewItem("test-job", "test-url", baseLabelSet, ""),
+			testJobTargetItemTwo.Hash(): target.NewItem("test-job-2", "test-url2", testJobLabelSetTwo, ""),
+		})
+		return allocator
+	}
+	get := func(s *Server, path string, etag string) *http.Response {
+		request := httptest.NewRequest("GET", path, nil)
+		if etag != "" {
+			request.Header.Set("If-None-Match", etag)
+		}
+		w := httptest.NewRecorder()
+		s.server.Handler.ServeHTTP(w, request)
+		return w.Result()
+	}
+
+	t.Run("returns the targets of every job", func(t *testing.T) {
+		s := NewServer(logger, newAllocator(), ":8080")
+		result := get(s, "/targets?collector_id=test-collector", "")
+		assert.Equal(t, http.StatusOK, result.StatusCode)
+		assert.NotEmpty(t, result.Header.Get("ETag"))
+		bodyBytes, err := io.ReadAll(result.Body)
+		require.NoError(t, err)
+		var targets map[string][]*target.Item
+		require.NoError(t, json.Unmarshal(bodyBytes, &targets))
+		require.Len(t, targets, 2)
+		assert.Equal(t, []string{"test-url"}, targets["test-job"][0].TargetURL)
+		assert.Equal(t, []string{"test-url2"}, targets["test-job-2"][0].TargetURL)
+	})
+	t.Run("unknown collectors have no targets", func(t *testing.T) {
+		s := NewServer(logger, newAllocator(), ":8080")
+		result := get(s, "/targets?collector_id=unknown", "")
+		assert.Equal(t, http.StatusOK, result.StatusCode)
+		bodyBytes, err := io.ReadAll(result.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, "{}", string(bodyBytes))
+	})
+	t.Run("matching etag", func(t *testing.T) {
+		s := NewServer(logger, newAllocator(), ":8080")
+		etag := get(s, "/targets?collector_id=test-collector", "").Header.Get("ETag")
+		result := get(s, "/targets?collector_id=test-collector", etag)
+		assert.Equal(t, http.StatusNotModified, result.StatusCode)
+		assert.Equal(t, etag, result.Header.Get("ETag"))
+		assert.NotEmpty(t, result.Header.Get(GenerationHeader))
+	})
+	t.Run("long poll times out without changes", func(t *testing.T) {
+		s := NewServer(logger, newAllocator(), ":8080")
+		etag := get(s, "/targets?collector_id=test-collector", "").Header.Get("ETag")
+		result := get(s, "/targets?collector_id=test-collector&wait=50ms", etag)
+		assert.Equal(t, http.StatusNotModified, result.StatusCode)
+	})
+	t.Run("long poll returns on change", func(t *testing.T) {
+		allocator := newAllocator()
+		s := NewServer(logger, allocator, ":8080")
+		etag := get(s, "/targets?collector_id=test-collector", "").Header.Get("ETag")
+
+		results := make(chan *http.Response)
+		go func() {
+			results <- get(s, "/targets?collector_id=test-collector&wait=1m", etag)
+		}()
+		allocator.SetTargets(map[string]*target.Item{
+			baseTargetItem.Hash(): target.NewItem("test-job", "test-url", baseLabelSet, ""),
+		})
+
+		select {
+		case result := <-results:
+			assert.Equal(t, http.StatusOK, result.StatusCode)
+			assert.NotEqual(t, etag, result.Header.Get("ETag"))
+			// the response is stamped with the generation it's based on, not the one the request started at
+			assert.Equal(t, fmt.Sprintf("0.%d", allocator.Generation()), result.Header.Get(GenerationHeader))
+		case <-time.After(10 * time.Second):
+			assert.Fail(t, "the long poll should return once the targets change")
+		}
+	})
+	t.Run("invalid requests", func(t *testing.T) {
+		s := NewServer(logger, newAllocator(), ":8080")
+		assert.Equal(t, http.StatusBadRequest, get(s, "/targets", "").StatusCode)
+		assert.Equal(t, http.StatusBadRequest, get(s, "/targets?collector_id=test-collector&wait=soon", "").StatusCode)
+	})
+}
@@ -0,0 +1,220 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/discovery/targetgroup"
+	"gopkg.in/yaml.v2"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/allocation"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/target"
+)
+
+// capturedCollector is an entry of the response of the target allocator's /jobs/{jobID}/targets endpoint.
+type capturedCollector struct {
+	Targets []struct {
+		Targets []string       `json:"targets"`
+		Labels  model.LabelSet `json:"labels"`
+	} `json:"targets"`
+}
+
+// input is a set of targets, along with the collector each of them is currently assigned to, if known. It's also the
+// source of the costs of the targets and of the labels of Nodes for the simulated allocators.
+type input struct {
+	targets map[string]*target.Item
+	// captured maps target hashes to the collector they were assigned to when the targets were captured.
+	captured map[string]string
+	// costs maps job names to target addresses to the cost of the target.
+	costs map[string]map[string]float64
+	// defaultCost is the cost of the targets without one.
+	defaultCost float64
+	// nodes maps Node names to their labels.
+	nodes map[string]map[string]string
+	// topologyKey is the Node label whose values are topology domains.
+	topologyKey string
+}
+
+var (
+	_ allocation.CostSource     = &input{}
+	_ allocation.TopologySource = &input{}
+)
+
+// loadTargets reads the targets of the given files. Each file is given as [job=]path, the job name defaults to the name
+// of the file without its extension.
+func loadTargets(files []string) (*input, error) {
+	in := &input{
+		targets:     map[string]*target.Item{},
+		captured:    map[string]string{},
+		defaultCost: 1,
+	}
+	for _, file := range files {
+		jobName, path, found := strings.Cut(file, "=")
+		if !found {
+			path = file
+			jobName = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		}
+		if err := in.load(jobName, path); err != nil {
+			return nil, fmt.Errorf("failed to load targets from %s: %w", path, err)
+		}
+	}
+	return in, nil
+}
+
+// load reads a file holding either the response of the /jobs/{jobID}/targets endpoint, or Prometheus file service
+// discovery target groups in JSON or YAML.
+func (in *input) load(jobName, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		var collectors map[string]capturedCollector
+		if err = json.Unmarshal(data, &collectors); err != nil {
+			return err
+		}
+		for collectorName, col := range collectors {
+			for _, group := range col.Targets {
+				for _, targetURL := range group.Targets {
+					item := target.NewItem(jobName, targetURL, group.Labels, "")
+					in.targets[item.Hash()] = item
+					in.captured[item.Hash()] = collectorName
+				}
+			}
+		}
+		return nil
+	}
+
+	var groups []*targetgroup.Group
+	if err = yaml.UnmarshalStrict(data, &groups); err != nil {
+		return err
+	}
+	for _, group := range groups {
+		for _, t := range group.Targets {
+			item := target.NewItem(jobName, string(t[model.AddressLabel]), t.Merge(group.Labels), "")
+			in.targets[item.Hash()] = item
+		}
+	}
+	return nil
+}
+
+// loadCosts reads the costs of the targets from a YAML or JSON file mapping job names to target addresses to costs, like
+// the number of samples they expose. As with collector feedback, the targets without a cost are given the average
+// cost.
+func (in *input) loadCosts(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	costs := map[string]map[string]float64{}
+	if err = yaml.UnmarshalStrict(data, &costs); err != nil {
+		return err
+	}
+	var total float64
+	count := 0
+	for jobName, targets := range costs {
+		for targetURL, cost := range targets {
+			if cost < 0 {
+				return fmt.Errorf("the cost of %s in job %s is negative", targetURL, jobName)
+			}
+			total += cost
+			count++
+		}
+	}
+	in.costs = costs
+	if count > 0 {
+		in.defaultCost = total / float64(count)
+	}
+	return nil
+}
+
+// loadNodes reads the labels of Nodes from a YAML or JSON file mapping Node names to their labels.
+func (in *input) loadNodes(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	nodes := map[string]map[string]string{}
+	if err = yaml.UnmarshalStrict(data, &nodes); err != nil {
+		return err
+	}
+	in.nodes = nodes
+	return nil
+}
+
+// TargetCost returns the cost of the given target.
+func (in *input) TargetCost(item *target.Item) float64 {
+	for _, targetURL := range item.TargetURL {
+		if cost, ok := in.costs[item.JobName][targetURL]; ok {
+			return cost
+		}
+	}
+	return in.defaultCost
+}
+
+// NodeTopologyLabels returns the labels of the given Node, or nil if they aren't known.
+func (in *input) NodeTopologyLabels(nodeName string) map[string]string {
+	return in.nodes[nodeName]
+}
+
+// capturedCollectors returns the names of the collectors targets were assigned to when they were captured.
+func (in *input) capturedCollectors() []string {
+	seen := map[string]bool{}
+	var names []string
+	for _, name := range in.captured {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// parseCollectors reads the collectors to allocate targets to. The spec is either a number of collectors, or a comma
+// separated list of collector names, each optionally followed by @ and the name of the Node the collector runs on.
+func parseCollectors(spec string) (map[string]*allocation.Collector, error) {
+	collectors := map[string]*allocation.Collector{}
+	if n, err := strconv.Atoi(spec); err == nil {
+		if n <= 0 {
+			return nil, fmt.Errorf("the number of collectors must be positive, got %d", n)
+		}
+		for i := 0; i < n; i++ {
+			name := fmt.Sprintf("collector-%d", i)
+			collectors[name] = allocation.NewCollector(name, "")
+		}
+		return collectors, nil
+	}
+	for _, entry := range strings.Split(spec, ",") {
+		name, node, _ := strings.Cut(strings.TrimSpace(entry), "@")
+		if name == "" {
+			return nil, fmt.Errorf("invalid collector %q", entry)
+		}
+		if _, ok := collectors[name]; ok {
+			return nil, fmt.Errorf("duplicate collector %q", name)
+		}
+		collectors[name] = allocation.NewCollector(name, node)
+	}
+	return collectors, nil
+}
@@ -0,0 +1,132 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command simulate runs allocation strategies offline against a captured set of targets, and reports how evenly they
+// distribute the targets and how many targets move when collectors are added or removed.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/pflag"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/allocation"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/config"
+)
+
+const (
+	targetsFlagName          = "targets"
+	collectorsFlagName       = "collectors"
+	strategiesFlagName       = "strategies"
+	collectorChangesFlagName = "collector-changes"
+	costsFlagName            = "costs"
+	nodesFlagName            = "nodes"
+	topologyKeyFlagName      = "topology-key"
+	outputFlagName           = "output"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, out io.Writer) error {
+	flagSet := pflag.NewFlagSet("simulate", pflag.ContinueOnError)
+	flagSet.StringArray(targetsFlagName, nil, "A file holding targets, as [job=]path. Either Prometheus file service discovery target groups, or the response of /jobs/{jobID}/targets. Can be repeated.")
+	flagSet.String(collectorsFlagName, "", "Either a number of collectors, or a comma separated list of collector names, each optionally followed by @ and a Node name. Defaults to the collectors of the captured targets.")
+	flagSet.StringSlice(strategiesFlagName, nil, "The allocation strategies to simulate. Defaults to all of them.")
+	flagSet.IntSlice(collectorChangesFlagName, []int{1, -1}, "The numbers of collectors to add, or remove if negative, to measure how many targets move.")
+	flagSet.String(costsFlagName, "", "A YAML or JSON file mapping job names to target addresses to the cost of the target, like the number of samples it exposes. Targets without a cost are given the average cost.")
+	flagSet.String(nodesFlagName, "", "A YAML or JSON file mapping Node names to their labels, which give the topology domains of the collectors and targets on them.")
+	flagSet.String(topologyKeyFlagName, config.DefaultTopologyKey, "The Node label whose values are the topology domains of the topology-aware strategy.")
+	flagSet.String(outputFlagName, "text", "The output format, either text or json.")
+	if err := flagSet.Parse(args); err != nil {
+		return err
+	}
+	files, _ := flagSet.GetStringArray(targetsFlagName)
+	collectorSpec, _ := flagSet.GetString(collectorsFlagName)
+	strategies, _ := flagSet.GetStringSlice(strategiesFlagName)
+	changes, _ := flagSet.GetIntSlice(collectorChangesFlagName)
+	output, _ := flagSet.GetString(outputFlagName)
+	costsFile, _ := flagSet.GetString(costsFlagName)
+	nodesFile, _ := flagSet.GetString(nodesFlagName)
+	topologyKey, _ := flagSet.GetString(topologyKeyFlagName)
+
+	if len(files) == 0 {
+		return errors.New("at least one targets file is required")
+	}
+	if output != "text" && output != "json" {
+		return fmt.Errorf("unknown output format %q", output)
+	}
+	in, err := loadTargets(files)
+	if err != nil {
+		return err
+	}
+	in.topologyKey = topologyKey
+	if costsFile != "" {
+		if err = in.loadCosts(costsFile); err != nil {
+			return fmt.Errorf("failed to load costs from %s: %w", costsFile, err)
+		}
+	}
+	if nodesFile != "" {
+		if err = in.loadNodes(nodesFile); err != nil {
+			return fmt.Errorf("failed to load nodes from %s: %w", nodesFile, err)
+		}
+	}
+	if collectorSpec == "" {
+		captured := in.capturedCollectors()
+		if len(captured) == 0 {
+			return errors.New("the targets don't record any collectors, they must be given explicitly")
+		}
+		collectorSpec = strings.Join(captured, ",")
+	}
+	collectors, err := parseCollectors(collectorSpec)
+	if err != nil {
+		return err
+	}
+	for _, col := range collectors {
+		col.TopologyLabels = in.NodeTopologyLabels(col.NodeName)
+	}
+	if len(strategies) == 0 {
+		strategies = allocation.GetRegisteredAllocatorNames()
+		sort.Strings(strategies)
+	}
+
+	var results []*result
+	for _, strategy := range strategies {
+		res, simulateErr := simulate(strategy, in, collectors, changes)
+		if simulateErr != nil {
+			return fmt.Errorf("failed to simulate strategy %s: %w", strategy, simulateErr)
+		}
+		results = append(results, res)
+	}
+
+	if output == "json" {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(results)
+	}
+	for _, res := range results {
+		res.print(out)
+	}
+	return nil
+}
@@ -0,0 +1,258 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+
+	"github.com/go-logr/logr"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/allocation"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/target"
+)
+
+// result describes how a strategy distributes the targets, and how many of them move when collectors change.
+type result struct {
+	Strategy   string `json:"strategy"`
+	Collectors int    `json:"collectors"`
+	Targets    int    `json:"targets"`
+	Unassigned int    `json:"unassigned"`
+	// Distribution is the number of targets assigned to each collector.
+	Distribution map[string]int `json:"distribution"`
+	Min          int            `json:"min"`
+	Max          int            `json:"max"`
+	Mean         float64        `json:"mean"`
+	StdDev       float64        `json:"stddev"`
+	// Imbalance is the ratio between the most loaded collector and the mean, 1 means perfectly even.
+	Imbalance float64 `json:"imbalance"`
+	// Load is the total cost of the targets assigned to each collector, when the costs of the targets are given.
+	Load map[string]float64 `json:"load,omitempty"`
+	// LoadImbalance is the ratio between the highest load of a collector and the mean load.
+	LoadImbalance float64 `json:"load_imbalance,omitempty"`
+	// MovedFromCaptured is the number of targets assigned to a different collector than when they were captured.
+	MovedFromCaptured *int    `json:"moved_from_captured,omitempty"`
+	Churn             []churn `json:"churn"`
+}
+
+// churn is the number of targets which move, or become unassigned, when collectors are added or removed.
+type churn struct {
+	CollectorChange int     `json:"collector_change"`
+	Moved           int     `json:"moved"`
+	MovedRatio      float64 `json:"moved_ratio"`
+}
+
+// simulate allocates the targets to the collectors with the given strategy, and then once more for every change to
+// the number of collectors, to find out how many targets move.
+func simulate(strategy string, in *input, collectors map[string]*allocation.Collector, changes []int) (*result, error) {
+	allocator, err := newAllocator(strategy, in, collectors)
+	if err != nil {
+		return nil, err
+	}
+	baseline := assignments(allocator)
+	res := &result{
+		Strategy:     strategy,
+		Collectors:   len(collectors),
+		Targets:      len(baseline),
+		Distribution: map[string]int{},
+	}
+	for name := range collectors {
+		res.Distribution[name] = 0
+	}
+	if len(in.costs) > 0 {
+		res.Load = map[string]float64{}
+		for name := range collectors {
+			res.Load[name] = 0
+		}
+	}
+	for hash, collectorName := range baseline {
+		if collectorName == "" {
+			res.Unassigned++
+			continue
+		}
+		res.Distribution[collectorName]++
+		if res.Load != nil {
+			res.Load[collectorName] += in.TargetCost(in.targets[hash])
+		}
+	}
+	res.computeStats()
+
+	if len(in.captured) > 0 {
+		moved := 0
+		for hash, collectorName := range baseline {
+			if captured, ok := in.captured[hash]; ok && captured != collectorName {
+				moved++
+			}
+		}
+		res.MovedFromCaptured = &moved
+	}
+
+	for _, change := range changes {
+		changed, err := resize(collectors, change)
+		if err != nil {
+			return nil, err
+		}
+		// strategies aren't necessarily deterministic, so compare against a fresh allocation rather than the baseline
+		allocator, err = newAllocator(strategy, in, collectors)
+		if err != nil {
+			return nil, err
+		}
+		before := assignments(allocator)
+		allocator.SetCollectors(changed)
+		moved := 0
+		for hash, collectorName := range assignments(allocator) {
+			if before[hash] != collectorName {
+				moved++
+			}
+		}
+		c := churn{CollectorChange: change, Moved: moved}
+		if res.Targets > 0 {
+			c.MovedRatio = float64(moved) / float64(res.Targets)
+		}
+		res.Churn = append(res.Churn, c)
+	}
+	return res, nil
+}
+
+// newAllocator returns an allocator which has assigned fresh copies of the targets to the collectors.
+func newAllocator(strategy string, in *input, collectors map[string]*allocation.Collector) (allocation.Allocator, error) {
+	allocator, err := allocation.New(strategy, logr.Discard(), allocation.WithCostSource(in), allocation.WithTopology(in.topologyKey, in))
+	if err != nil {
+		return nil, err
+	}
+	allocator.SetCollectors(copyCollectors(collectors))
+	targets := make(map[string]*target.Item, len(in.targets))
+	for hash, item := range in.targets {
+		targets[hash] = target.NewItem(item.JobName, item.TargetURL[0], item.Labels, "")
+	}
+	allocator.SetTargets(targets)
+	return allocator, nil
+}
+
+func assignments(allocator allocation.Allocator) map[string]string {
+	result := map[string]string{}
+	for hash, item := range allocator.TargetItems() {
+		result[hash] = item.CollectorName
+	}
+	return result
+}
+
+func copyCollectors(collectors map[string]*allocation.Collector) map[string]*allocation.Collector {
+	result := make(map[string]*allocation.Collector, len(collectors))
+	for name, col := range collectors {
+		result[name] = allocation.NewCollector(col.Name, col.NodeName)
+		result[name].TopologyLabels = col.TopologyLabels
+	}
+	return result
+}
+
+// resize adds collectors to, or removes the last collectors by name from, the given ones.
+func resize(collectors map[string]*allocation.Collector, change int) (map[string]*allocation.Collector, error) {
+	result := copyCollectors(collectors)
+	if change >= 0 {
+		for i := 0; i < change; i++ {
+			name := fmt.Sprintf("added-collector-%d", i)
+			result[name] = allocation.NewCollector(name, "")
+		}
+		return result, nil
+	}
+	if -change >= len(collectors) {
+		return nil, fmt.Errorf("can't remove %d of %d collectors", -change, len(collectors))
+	}
+	for _, name := range sortedNames(collectors)[len(collectors)+change:] {
+		delete(result, name)
+	}
+	return result, nil
+}
+
+func sortedNames(collectors map[string]*allocation.Collector) []string {
+	names := make([]string, 0, len(collectors))
+	for name := range collectors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (r *result) computeStats() {
+	if len(r.Distribution) == 0 {
+		return
+	}
+	r.Min = math.MaxInt
+	total := 0
+	for _, count := range r.Distribution {
+		r.Min = min(r.Min, count)
+		r.Max = max(r.Max, count)
+		total += count
+	}
+	r.Mean = float64(total) / float64(len(r.Distribution))
+	var variance float64
+	for _, count := range r.Distribution {
+		variance += math.Pow(float64(count)-r.Mean, 2)
+	}
+	r.StdDev = math.Sqrt(variance / float64(len(r.Distribution)))
+	if r.Mean > 0 {
+		r.Imbalance = float64(r.Max) / r.Mean
+	}
+	if len(r.Load) > 0 {
+		var totalLoad, maxLoad float64
+		for _, load := range r.Load {
+			totalLoad += load
+			maxLoad = max(maxLoad, load)
+		}
+		if meanLoad := totalLoad / float64(len(r.Load)); meanLoad > 0 {
+			r.LoadImbalance = maxLoad / meanLoad
+		}
+	}
+}
+
+func (r *result) print(w io.Writer) {
+	fmt.Fprintf(w, "Strategy: %s\n", r.Strategy)
+	fmt.Fprintf(w, "  Targets: %d (%d unassigned) across %d collectors\n", r.Targets, r.Unassigned, r.Collectors)
+	fmt.Fprintf(w, "  Targets per collector: min %d, max %d, mean %.1f, stddev %.1f, imbalance %.2f\n", r.Min, r.Max, r.Mean, r.StdDev, r.Imbalance)
+	names := make([]string, 0, len(r.Distribution))
+	for name := range r.Distribution {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "    %s: %d\n", name, r.Distribution[name])
+	}
+	if r.Load != nil {
+		fmt.Fprintf(w, "  Load per collector: imbalance %.2f\n", r.LoadImbalance)
+		for _, name := range names {
+			fmt.Fprintf(w, "    %s: %.1f\n", name, r.Load[name])
+		}
+	}
+	if r.MovedFromCaptured != nil {
+		fmt.Fprintf(w, "  Moved compared to the captured assignment: %d (%.1f%%)\n", *r.MovedFromCaptured, percentage(*r.MovedFromCaptured, r.Targets))
+	}
+	for _, c := range r.Churn {
+		verb, count := "Adding", c.CollectorChange
+		if count < 0 {
+			verb, count = "Removing", -count
+		}
+		fmt.Fprintf(w, "  %s %d collector(s): %d targets moved (%.1f%%)\n", verb, count, c.Moved, 100*c.MovedRatio)
+	}
+}
+
+func percentage(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return 100 * float64(part) / float64(total)
+}
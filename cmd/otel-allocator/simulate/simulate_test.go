@@ -0,0 +1,151 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadTargets(t *testing.T) {
+	in, err := loadTargets([]string{"testdata/node-exporter.yaml", "kubelet=testdata/kubelet.json"})
+	require.NoError(t, err)
+	assert.Len(t, in.targets, 9)
+	assert.Len(t, in.captured, 3)
+	assert.Equal(t, []string{"collector-a", "collector-b"}, in.capturedCollectors())
+
+	jobs := map[string]int{}
+	for _, item := range in.targets {
+		jobs[item.JobName]++
+		if item.JobName == "node-exporter" {
+			assert.NotEmpty(t, item.Labels["env"], "group labels should be merged into the target labels")
+			assert.Equal(t, string(item.Labels["__address__"]), item.TargetURL[0])
+		}
+	}
+	assert.Equal(t, map[string]int{"node-exporter": 6, "kubelet": 3}, jobs)
+
+	_, err = loadTargets([]string{"testdata/missing.yaml"})
+	assert.Error(t, err)
+}
+
+func TestParseCollectors(t *testing.T) {
+	collectors, err := parseCollectors("3")
+	require.NoError(t, err)
+	assert.Len(t, collectors, 3)
+	assert.Contains(t, collectors, "collector-2")
+
+	collectors, err = parseCollectors("a@node-1, b")
+	require.NoError(t, err)
+	require.Len(t, collectors, 2)
+	assert.Equal(t, "node-1", collectors["a"].NodeName)
+	assert.Equal(t, "", collectors["b"].NodeName)
+
+	for _, spec := range []string{"0", "a,,b", "a,a"} {
+		_, err = parseCollectors(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	in, err := loadTargets([]string{"testdata/node-exporter.yaml", "testdata/kubelet.json"})
+	require.NoError(t, err)
+	collectors, err := parseCollectors("3")
+	require.NoError(t, err)
+
+	res, err := simulate("least-weighted", in, collectors, []int{2, -1})
+	require.NoError(t, err)
+	assert.Equal(t, 9, res.Targets)
+	assert.Equal(t, 0, res.Unassigned)
+	assert.Equal(t, map[string]int{"collector-0": 3, "collector-1": 3, "collector-2": 3}, res.Distribution)
+	assert.Equal(t, 1.0, res.Imbalance)
+	assert.Equal(t, 0.0, res.StdDev)
+	require.NotNil(t, res.MovedFromCaptured)
+	assert.Equal(t, 3, *res.MovedFromCaptured, "none of the captured collectors exist anymore")
+	require.Len(t, res.Churn, 2)
+	assert.Equal(t, churn{CollectorChange: 2}, res.Churn[0], "least-weighted never moves targets to new collectors")
+	assert.Equal(t, churn{CollectorChange: -1, Moved: 3, MovedRatio: 3.0 / 9}, res.Churn[1])
+
+	_, err = simulate("least-weighted", in, collectors, []int{-3})
+	assert.Error(t, err)
+	_, err = simulate("unknown", in, collectors, nil)
+	assert.Error(t, err)
+}
+
+func TestSimulateWithCostsAndTopology(t *testing.T) {
+	in, err := loadTargets([]string{"testdata/kubelet.json"})
+	require.NoError(t, err)
+	require.NoError(t, in.loadCosts("testdata/costs.yaml"))
+	require.NoError(t, in.loadNodes("testdata/nodes.yaml"))
+	in.topologyKey = "topology.kubernetes.io/zone"
+	collectors, err := parseCollectors("a@node-1,b@node-2")
+	require.NoError(t, err)
+	for _, col := range collectors {
+		col.TopologyLabels = in.NodeTopologyLabels(col.NodeName)
+	}
+
+	// the target without a cost is given the average cost
+	for _, item := range in.targets {
+		if item.TargetURL[0] == "10.0.0.3:10250" {
+			assert.Equal(t, 20.0, in.TargetCost(item))
+		}
+	}
+
+	res, err := simulate("topology-aware", in, collectors, []int{1})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, res.Distribution, "targets should stay within the zone of their Node")
+	assert.Equal(t, map[string]float64{"a": 30, "b": 30}, res.Load)
+	assert.Equal(t, 1.0, res.LoadImbalance)
+	require.Len(t, res.Churn, 1)
+	assert.Equal(t, 0, res.Churn[0].Moved, "a collector without a zone shouldn't take targets from the zoned ones")
+
+	resized, err := resize(collectors, -1)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"topology.kubernetes.io/zone": "zone-a"}, resized["a"].TopologyLabels)
+
+	assert.Error(t, in.loadCosts("testdata/missing.yaml"))
+	assert.Error(t, in.loadNodes("testdata/missing.yaml"))
+}
+
+func TestRun(t *testing.T) {
+	var out bytes.Buffer
+	err := run([]string{"--targets", "testdata/kubelet.json", "--strategies", "consistent-hashing,per-node", "--output", "json"}, &out)
+	require.NoError(t, err)
+	var results []*result
+	require.NoError(t, json.Unmarshal(out.Bytes(), &results))
+	require.Len(t, results, 2)
+	assert.Equal(t, "consistent-hashing", results[0].Strategy)
+	assert.Equal(t, 2, results[0].Collectors, "collectors should default to the captured ones")
+	assert.Equal(t, 3, results[1].Unassigned, "collectors without a Node can't be used by per-node")
+
+	out.Reset()
+	err = run([]string{"--targets", "testdata/node-exporter.yaml", "--collectors", "2", "--strategies", "consistent-hashing"}, &out)
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "Strategy: consistent-hashing\n")
+	assert.Contains(t, out.String(), "Adding 1 collector(s)")
+	assert.Contains(t, out.String(), "Removing 1 collector(s)")
+
+	out.Reset()
+	err = run([]string{"--targets", "testdata/kubelet.json", "--costs", "testdata/costs.yaml", "--nodes", "testdata/nodes.yaml", "--strategies", "topology-aware", "--collectors", "a@node-1,b@node-2"}, &out)
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "Load per collector: imbalance 1.00\n")
+
+	assert.Error(t, run([]string{"--collectors", "2"}, &out))
+	assert.Error(t, run([]string{"--targets", "testdata/node-exporter.yaml"}, &out), "collectors are required without captured assignments")
+	assert.Error(t, run([]string{"--targets", "testdata/node-exporter.yaml", "--collectors", "2", "--output", "xml"}, &out))
+}
@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"context"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// configMapKey is the key of the ConfigMap's binary data holding the snapshot.
+const configMapKey = "snapshot.json.gz"
+
+var _ Store = &ConfigMapStore{}
+
+// ConfigMapStore keeps the snapshot in a ConfigMap, which is created if it doesn't exist.
+type ConfigMapStore struct {
+	client    kubernetes.Interface
+	namespace string
+	name      string
+}
+
+func NewConfigMapStore(client kubernetes.Interface, namespace, name string) *ConfigMapStore {
+	return &ConfigMapStore{client: client, namespace: namespace, name: name}
+}
+
+func (c *ConfigMapStore) Load(ctx context.Context) (*Snapshot, error) {
+	cm, err := c.client.CoreV1().ConfigMaps(c.namespace).Get(ctx, c.name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	data, ok := cm.BinaryData[configMapKey]
+	if !ok {
+		return nil, nil
+	}
+	return decode(data)
+}
+
+func (c *ConfigMapStore) Save(ctx context.Context, snapshot *Snapshot) error {
+	data, err := encode(snapshot)
+	if err != nil {
+		return err
+	}
+	configMaps := c.client.CoreV1().ConfigMaps(c.namespace)
+	cm, err := configMaps.Get(ctx, c.name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		_, err = configMaps.Create(ctx, &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: c.name, Namespace: c.namespace},
+			BinaryData: map[string][]byte{configMapKey: data},
+		}, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	if cm.BinaryData == nil {
+		cm.BinaryData = map[string][]byte{}
+	}
+	cm.BinaryData[configMapKey] = data
+	_, err = configMaps.Update(ctx, cm, metav1.UpdateOptions{})
+	return err
+}
@@ -0,0 +1,262 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package snapshot persists the assignments of targets to collectors, so that a restarted target allocator can serve
+// them until it has caught up with service discovery.
+package snapshot
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/common/model"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/allocation"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/target"
+)
+
+var (
+	snapshotsSaved = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "opentelemetry_allocator_snapshots_saved",
+		Help: "Number of assignment snapshots saved, by result.",
+	}, []string{"result"})
+	snapshotStale = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "opentelemetry_allocator_snapshot_stale",
+		Help: "Whether the assignments being served were restored from a snapshot and not yet reconciled.",
+	})
+)
+
+// Snapshot holds the collectors and the targets assigned to them at some point in time.
+type Snapshot struct {
+	Timestamp  time.Time   `json:"timestamp"`
+	Collectors []Collector `json:"collectors"`
+	Targets    []Target    `json:"targets"`
+}
+
+type Collector struct {
+	Name           string            `json:"name"`
+	NodeName       string            `json:"node_name,omitempty"`
+	TopologyLabels map[string]string `json:"topology_labels,omitempty"`
+}
+
+type Target struct {
+	JobName       string         `json:"job_name"`
+	TargetURL     string         `json:"target_url"`
+	Labels        model.LabelSet `json:"labels"`
+	CollectorName string         `json:"collector_name"`
+}
+
+// Store reads and writes snapshots.
+type Store interface {
+	// Load returns the last saved snapshot, or nil if there is none.
+	Load(ctx context.Context) (*Snapshot, error)
+	Save(ctx context.Context, snapshot *Snapshot) error
+}
+
+// encode serializes the snapshot as gzipped JSON, which keeps large snapshots within the size limit of a ConfigMap.
+func encode(snapshot *Snapshot) ([]byte, error) {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(w).Encode(snapshot); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func decode(data []byte) (*Snapshot, error) {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	snapshot := &Snapshot{}
+	if err = json.NewDecoder(r).Decode(snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// Take captures the current assignments of the allocator.
+func Take(allocator allocation.Allocator) *Snapshot {
+	snapshot := &Snapshot{Timestamp: time.Now()}
+	jobs := map[string]struct{}{}
+	for _, item := range allocator.TargetItems() {
+		jobs[item.JobName] = struct{}{}
+	}
+	for _, col := range allocator.Collectors() {
+		snapshot.Collectors = append(snapshot.Collectors, Collector{
+			Name:           col.Name,
+			NodeName:       col.NodeName,
+			TopologyLabels: col.TopologyLabels,
+		})
+		for job := range jobs {
+			for _, item := range allocator.GetTargetsForCollectorAndJob(col.Name, job) {
+				snapshot.Targets = append(snapshot.Targets, Target{
+					JobName:       item.JobName,
+					TargetURL:     item.TargetURL[0],
+					Labels:        item.Labels,
+					CollectorName: col.Name,
+				})
+			}
+		}
+	}
+	return snapshot
+}
+
+// Restore seeds the allocator with the assignments of the snapshot.
+func Restore(allocator allocation.Allocator, snapshot *Snapshot) {
+	collectors := make(map[string]*allocation.Collector, len(snapshot.Collectors))
+	for _, c := range snapshot.Collectors {
+		col := allocation.NewCollector(c.Name, c.NodeName)
+		col.TopologyLabels = c.TopologyLabels
+		collectors[c.Name] = col
+	}
+	targets := make(map[string]*target.Item, len(snapshot.Targets))
+	for _, t := range snapshot.Targets {
+		item := target.NewItem(t.JobName, t.TargetURL, t.Labels, t.CollectorName)
+		targets[item.Hash()] = item
+	}
+	allocator.RestoreAssignments(collectors, targets)
+}
+
+// Snapshotter restores the allocator from the last snapshot on startup, and then periodically saves new ones. Restored
+// assignments are stale until both targets and collectors have been synced.
+type Snapshotter struct {
+	log       logr.Logger
+	store     Store
+	allocator allocation.Allocator
+	interval  time.Duration
+	// isLeader is nil unless multiple replicas elect a leader, only the leader saves snapshots.
+	isLeader func() bool
+
+	restored         atomic.Bool
+	targetsSynced    atomic.Bool
+	collectorsSynced atomic.Bool
+	lastGeneration   uint64
+
+	close     chan struct{}
+	closeOnce sync.Once
+}
+
+type Option func(*Snapshotter)
+
+// WithLeadership makes the Snapshotter only save snapshots while isLeader returns true.
+func WithLeadership(isLeader func() bool) Option {
+	return func(s *Snapshotter) {
+		s.isLeader = isLeader
+	}
+}
+
+func NewSnapshotter(log logr.Logger, store Store, allocator allocation.Allocator, interval time.Duration, opts ...Option) *Snapshotter {
+	s := &Snapshotter{
+		log:       log,
+		store:     store,
+		allocator: allocator,
+		interval:  interval,
+		close:     make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Restore loads the last snapshot, if any, into the allocator.
+func (s *Snapshotter) Restore(ctx context.Context) error {
+	snapshot, err := s.store.Load(ctx)
+	if err != nil || snapshot == nil {
+		return err
+	}
+	Restore(s.allocator, snapshot)
+	s.lastGeneration = s.allocator.Generation()
+	s.restored.Store(true)
+	snapshotStale.Set(1)
+	s.log.Info("Restored assignments from snapshot", "timestamp", snapshot.Timestamp, "collectors", len(snapshot.Collectors), "targets", len(snapshot.Targets))
+	return nil
+}
+
+// TargetsSynced records that the allocator received targets from service discovery.
+func (s *Snapshotter) TargetsSynced() {
+	s.targetsSynced.Store(true)
+	s.updateStale()
+}
+
+// CollectorsSynced records that the allocator received the live set of collectors.
+func (s *Snapshotter) CollectorsSynced() {
+	s.collectorsSynced.Store(true)
+	s.updateStale()
+}
+
+// Stale returns whether the allocator is serving restored assignments which haven't been reconciled yet.
+func (s *Snapshotter) Stale() bool {
+	return s.restored.Load() && !(s.targetsSynced.Load() && s.collectorsSynced.Load())
+}
+
+func (s *Snapshotter) updateStale() {
+	if !s.Stale() {
+		snapshotStale.Set(0)
+	}
+}
+
+// Run saves a snapshot every interval if the assignments changed, and once more when the Snapshotter is closed.
+func (s *Snapshotter) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.save(ctx)
+		case <-s.close:
+			s.save(ctx)
+			return nil
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (s *Snapshotter) Close() {
+	s.closeOnce.Do(func() {
+		close(s.close)
+	})
+}
+
+// save writes a snapshot unless the assignments are stale or didn't change since the last one.
+func (s *Snapshotter) save(ctx context.Context) {
+	if s.Stale() || (s.isLeader != nil && !s.isLeader()) {
+		return
+	}
+	generation := s.allocator.Generation()
+	if generation == s.lastGeneration {
+		return
+	}
+	if err := s.store.Save(ctx, Take(s.allocator)); err != nil {
+		s.log.Error(err, "Failed to save assignment snapshot")
+		snapshotsSaved.WithLabelValues("failure").Inc()
+		return
+	}
+	s.lastGeneration = generation
+	snapshotsSaved.WithLabelValues("success").Inc()
+}
@@ -0,0 +1,149 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/allocation"
+)
+
+var logger = logf.Log.WithName("unit-tests")
+
+func assignments(allocator allocation.Allocator) map[string]string {
+	result := map[string]string{}
+	for hash, item := range allocator.TargetItems() {
+		result[hash] = item.CollectorName
+	}
+	return result
+}
+
+func TestStores(t *testing.T) {
+	stores := map[string]Store{
+		"file":      NewFileStore(filepath.Join(t.TempDir(), "snapshot")),
+		"configmap": NewConfigMapStore(fake.NewSimpleClientset(), "default", "allocator-snapshot"),
+	}
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			loaded, err := store.Load(ctx)
+			require.NoError(t, err)
+			assert.Nil(t, loaded)
+
+			snapshot := &Snapshot{
+				Timestamp:  time.Now().UTC().Truncate(time.Second),
+				Collectors: []Collector{{Name: "collector-0", NodeName: "node-0"}},
+				Targets:    []Target{{JobName: "job", TargetURL: "target:8080", CollectorName: "collector-0"}},
+			}
+			require.NoError(t, store.Save(ctx, snapshot))
+			// saving again replaces the previous snapshot
+			snapshot.Collectors[0].NodeName = "node-1"
+			require.NoError(t, store.Save(ctx, snapshot))
+
+			loaded, err = store.Load(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, snapshot, loaded)
+		})
+	}
+}
+
+func TestSnapshotterRestore(t *testing.T) {
+	ctx := context.Background()
+	store := NewFileStore(filepath.Join(t.TempDir(), "snapshot"))
+
+	before, err := allocation.New("least-weighted", logger)
+	require.NoError(t, err)
+	before.SetCollectors(allocation.MakeNCollectors(3, 0))
+	before.SetTargets(allocation.MakeNNewTargetsWithEmptyCollectors(30, 0))
+	require.NoError(t, store.Save(ctx, Take(before)))
+
+	after, err := allocation.New("least-weighted", logger)
+	require.NoError(t, err)
+	snapshotter := NewSnapshotter(logger, store, after, time.Minute)
+	assert.False(t, snapshotter.Stale())
+	require.NoError(t, snapshotter.Restore(ctx))
+	assert.True(t, snapshotter.Stale())
+	assert.Equal(t, assignments(before), assignments(after))
+	for name, col := range after.Collectors() {
+		assert.Equal(t, 10, col.NumTargets, name)
+	}
+
+	// live state which is the same as the snapshot doesn't move any target
+	after.SetTargets(allocation.MakeNNewTargetsWithEmptyCollectors(30, 0))
+	snapshotter.TargetsSynced()
+	assert.True(t, snapshotter.Stale())
+	after.SetCollectors(allocation.MakeNCollectors(3, 0))
+	snapshotter.CollectorsSynced()
+	assert.False(t, snapshotter.Stale())
+	assert.Equal(t, assignments(before), assignments(after))
+
+	// targets of a collector which is gone get reassigned, the others stay
+	after.SetCollectors(allocation.MakeNCollectors(2, 0))
+	for hash, collectorName := range assignments(after) {
+		if assignments(before)[hash] != "collector-2" {
+			assert.Equal(t, assignments(before)[hash], collectorName)
+		}
+		assert.NotEqual(t, "collector-2", collectorName)
+	}
+}
+
+func TestSnapshotterSave(t *testing.T) {
+	ctx := context.Background()
+	store := NewFileStore(filepath.Join(t.TempDir(), "snapshot"))
+	allocator, err := allocation.New("least-weighted", logger)
+	require.NoError(t, err)
+	leader := false
+	snapshotter := NewSnapshotter(logger, store, allocator, time.Minute, WithLeadership(func() bool { return leader }))
+
+	// nothing to save yet
+	snapshotter.save(ctx)
+	loaded, err := store.Load(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, loaded)
+
+	allocator.SetCollectors(allocation.MakeNCollectors(2, 0))
+	allocator.SetTargets(allocation.MakeNNewTargetsWithEmptyCollectors(4, 0))
+
+	// only the leader saves
+	snapshotter.save(ctx)
+	loaded, err = store.Load(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, loaded)
+
+	leader = true
+	snapshotter.save(ctx)
+	loaded, err = store.Load(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, loaded)
+	assert.Len(t, loaded.Collectors, 2)
+	assert.Len(t, loaded.Targets, 4)
+
+	go func() {
+		allocator.SetTargets(allocation.MakeNNewTargetsWithEmptyCollectors(6, 0))
+		snapshotter.Close()
+	}()
+	require.NoError(t, snapshotter.Run(ctx))
+	loaded, err = store.Load(ctx)
+	require.NoError(t, err)
+	assert.Len(t, loaded.Targets, 6)
+}
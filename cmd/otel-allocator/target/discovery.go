@@ -17,6 +17,7 @@ package target
 import (
 	"hash"
 	"hash/fnv"
+	"maps"
 
 	"github.com/go-logr/logr"
 	"github.com/prometheus/client_golang/prometheus"
@@ -41,6 +42,7 @@ type Discoverer struct {
 	log                  logr.Logger
 	manager              *discovery.Manager
 	close                chan struct{}
+	resync               chan struct{}
 	configsMap           map[allocatorWatcher.EventSource][]*promconfig.ScrapeConfig
 	hook                 discoveryHook
 	scrapeConfigsHash    hash.Hash
@@ -60,6 +62,7 @@ func NewDiscoverer(log logr.Logger, manager *discovery.Manager, hook discoveryHo
 		log:                  log,
 		manager:              manager,
 		close:                make(chan struct{}),
+		resync:               make(chan struct{}, 1),
 		configsMap:           make(map[allocatorWatcher.EventSource][]*promconfig.ScrapeConfig),
 		hook:                 hook,
 		scrapeConfigsHash:    nil, // we want the first update to succeed even if the config is empty
@@ -104,6 +107,8 @@ func (m *Discoverer) ApplyConfig(source allocatorWatcher.EventSource, scrapeConf
 }
 
 func (m *Discoverer) Watch(fn func(targets map[string]*Item)) error {
+	// the targets are passed on again on resync, fn may modify the map it's given
+	var discovered map[string]*Item
 	for {
 		select {
 		case <-m.close:
@@ -123,11 +128,25 @@ func (m *Discoverer) Watch(fn func(targets map[string]*Item)) error {
 				}
 				targetsDiscovered.WithLabelValues(jobName).Set(count)
 			}
+			discovered = maps.Clone(targets)
 			fn(targets)
+		case <-m.resync:
+			if discovered != nil {
+				fn(maps.Clone(discovered))
+			}
 		}
 	}
 }
 
+// Resync passes the targets discovered last to the watch function again, e.g. after the filters changed.
+func (m *Discoverer) Resync() {
+	select {
+	case m.resync <- struct{}{}:
+	default:
+		// a resync is already pending
+	}
+}
+
 func (m *Discoverer) Close() {
 	close(m.close)
 }
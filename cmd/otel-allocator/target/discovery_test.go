@@ -115,6 +115,12 @@ func TestDiscovery(t *testing.T) {
 			assert.Equal(t, expectedScrapeConfigs, scu.mockCfg)
 		})
 	}
+
+	// a resync passes the targets discovered last on again
+	manager.Resync()
+	gotTargets := <-results
+	sort.Strings(gotTargets)
+	assert.Equal(t, tests[len(tests)-1].want, gotTargets)
 }
 
 func TestDiscovery_ScrapeConfigHashing(t *testing.T) {
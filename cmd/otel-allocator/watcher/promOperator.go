@@ -63,7 +63,7 @@ func NewPrometheusCRWatcher(ctx context.Context, logger logr.Logger, cfg allocat
 
 	factory := informers.NewMonitoringInformerFactories(map[string]struct{}{v1.NamespaceAll: {}}, map[string]struct{}{}, mClient, allocatorconfig.DefaultResyncTime, nil) //TODO decide what strategy to use regarding namespaces
 
-	monitoringInformers, err := getInformers(factory)
+	monitoringInformers, err := getInformers(factory, cfg.PrometheusCR)
 	if err != nil {
 		return nil, err
 	}
@@ -75,8 +75,12 @@ func NewPrometheusCRWatcher(ctx context.Context, logger logr.Logger, cfg allocat
 				ScrapeInterval:                  monitoringv1.Duration(cfg.PrometheusCR.ScrapeInterval.String()),
 				ServiceMonitorSelector:          cfg.PrometheusCR.ServiceMonitorSelector,
 				PodMonitorSelector:              cfg.PrometheusCR.PodMonitorSelector,
+				ProbeSelector:                   cfg.PrometheusCR.ProbeSelector,
+				ScrapeConfigSelector:            cfg.PrometheusCR.ScrapeConfigSelector,
 				ServiceMonitorNamespaceSelector: cfg.PrometheusCR.ServiceMonitorNamespaceSelector,
 				PodMonitorNamespaceSelector:     cfg.PrometheusCR.PodMonitorNamespaceSelector,
+				ProbeNamespaceSelector:          cfg.PrometheusCR.ProbeNamespaceSelector,
+				ScrapeConfigNamespaceSelector:   cfg.PrometheusCR.ScrapeConfigNamespaceSelector,
 			},
 		},
 	}
@@ -113,6 +117,8 @@ func NewPrometheusCRWatcher(ctx context.Context, logger logr.Logger, cfg allocat
 		kubeConfigPath:                  cfg.KubeConfigFilePath,
 		podMonitorNamespaceSelector:     cfg.PrometheusCR.PodMonitorNamespaceSelector,
 		serviceMonitorNamespaceSelector: cfg.PrometheusCR.ServiceMonitorNamespaceSelector,
+		probeNamespaceSelector:          cfg.PrometheusCR.ProbeNamespaceSelector,
+		scrapeConfigNamespaceSelector:   cfg.PrometheusCR.ScrapeConfigNamespaceSelector,
 		resourceSelector:                resourceSelector,
 		store:                           store,
 	}, nil
@@ -130,6 +136,8 @@ type PrometheusCRWatcher struct {
 	kubeConfigPath                  string
 	podMonitorNamespaceSelector     *metav1.LabelSelector
 	serviceMonitorNamespaceSelector *metav1.LabelSelector
+	probeNamespaceSelector          *metav1.LabelSelector
+	scrapeConfigNamespaceSelector   *metav1.LabelSelector
 	resourceSelector                *prometheus.ResourceSelector
 	store                           *assets.Store
 }
@@ -160,8 +168,9 @@ func getNamespaceInformer(ctx context.Context, allowList map[string]struct{}, pr
 
 }
 
-// getInformers returns a map of informers for the given resources.
-func getInformers(factory informers.FactoriesForNamespaces) (map[string]*informers.ForResource, error) {
+// getInformers returns a map of informers for the given resources. Probes and ScrapeConfigs are only watched if a
+// selector is configured for them, as their CRDs aren't always installed.
+func getInformers(factory informers.FactoriesForNamespaces, cfg allocatorconfig.PrometheusCRConfig) (map[string]*informers.ForResource, error) {
 	serviceMonitorInformers, err := informers.NewInformersForResource(factory, monitoringv1.SchemeGroupVersion.WithResource(monitoringv1.ServiceMonitorName))
 	if err != nil {
 		return nil, err
@@ -172,10 +181,28 @@ func getInformers(factory informers.FactoriesForNamespaces) (map[string]*informe
 		return nil, err
 	}
 
-	return map[string]*informers.ForResource{
+	monitoringInformers := map[string]*informers.ForResource{
 		monitoringv1.ServiceMonitorName: serviceMonitorInformers,
 		monitoringv1.PodMonitorName:     podMonitorInformers,
-	}, nil
+	}
+
+	if cfg.ProbeSelector != nil {
+		probeInformers, err := informers.NewInformersForResource(factory, monitoringv1.SchemeGroupVersion.WithResource(monitoringv1.ProbeName))
+		if err != nil {
+			return nil, err
+		}
+		monitoringInformers[monitoringv1.ProbeName] = probeInformers
+	}
+
+	if cfg.ScrapeConfigSelector != nil {
+		scrapeConfigInformers, err := informers.NewInformersForResource(factory, promv1alpha1.SchemeGroupVersion.WithResource(promv1alpha1.ScrapeConfigName))
+		if err != nil {
+			return nil, err
+		}
+		monitoringInformers[promv1alpha1.ScrapeConfigName] = scrapeConfigInformers
+	}
+
+	return monitoringInformers, nil
 }
 
 // Watch wrapped informers and wait for an initial sync.
@@ -204,6 +231,8 @@ func (w *PrometheusCRWatcher) Watch(upstreamEvents chan Event, upstreamErrors ch
 				for name, selector := range map[string]*metav1.LabelSelector{
 					"PodMonitorNamespaceSelector":     w.podMonitorNamespaceSelector,
 					"ServiceMonitorNamespaceSelector": w.serviceMonitorNamespaceSelector,
+					"ProbeNamespaceSelector":          w.probeNamespaceSelector,
+					"ScrapeConfigNamespaceSelector":   w.scrapeConfigNamespaceSelector,
 				} {
 					sync, err := k8sutil.LabelSelectionHasChanged(old.Labels, cur.Labels, selector)
 					if err != nil {
@@ -318,6 +347,22 @@ func (w *PrometheusCRWatcher) LoadConfig(ctx context.Context) (*promconfig.Confi
 			return nil, err
 		}
 
+		probeInstances := map[string]*monitoringv1.Probe{}
+		if informer, ok := w.informers[monitoringv1.ProbeName]; ok {
+			probeInstances, err = w.resourceSelector.SelectProbes(ctx, informer.ListAllByNamespace)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		scrapeConfigInstances := map[string]*promv1alpha1.ScrapeConfig{}
+		if informer, ok := w.informers[promv1alpha1.ScrapeConfigName]; ok {
+			scrapeConfigInstances, err = w.resourceSelector.SelectScrapeConfigs(ctx, informer.ListAllByNamespace)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		generatedConfig, err := w.configGenerator.GenerateServerConfiguration(
 			ctx,
 			"30s",
@@ -329,8 +374,8 @@ func (w *PrometheusCRWatcher) LoadConfig(ctx context.Context) (*promconfig.Confi
 			nil,
 			serviceMonitorInstances,
 			podMonitorInstances,
-			map[string]*monitoringv1.Probe{},
-			map[string]*promv1alpha1.ScrapeConfig{},
+			probeInstances,
+			scrapeConfigInstances,
 			w.store,
 			nil,
 			nil,
@@ -16,6 +16,7 @@ package watcher
 
 import (
 	"context"
+	"net/url"
 	"os"
 	"testing"
 	"time"
@@ -23,6 +24,7 @@ import (
 	"github.com/go-kit/log"
 	"github.com/go-kit/log/level"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	promv1alpha1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1alpha1"
 	"github.com/prometheus-operator/prometheus-operator/pkg/assets"
 	fakemonitoringclient "github.com/prometheus-operator/prometheus-operator/pkg/client/versioned/fake"
 	"github.com/prometheus-operator/prometheus-operator/pkg/informers"
@@ -57,6 +59,8 @@ func TestLoadConfig(t *testing.T) {
 		name            string
 		serviceMonitors []*monitoringv1.ServiceMonitor
 		podMonitors     []*monitoringv1.PodMonitor
+		probes          []*monitoringv1.Probe
+		scrapeConfigs   []*promv1alpha1.ScrapeConfig
 		want            *promconfig.Config
 		wantErr         bool
 		cfg             allocatorconfig.Config
@@ -800,10 +804,108 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "probe and scrape config selectors",
+			probes: []*monitoringv1.Probe{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "blackbox",
+						Namespace: "test",
+					},
+					Spec: monitoringv1.ProbeSpec{
+						JobName: "blackbox",
+						ProberSpec: monitoringv1.ProberSpec{
+							URL:  "blackbox-exporter:9115",
+							Path: "/probe",
+						},
+						Module: "http_2xx",
+						Targets: monitoringv1.ProbeTargets{
+							StaticConfig: &monitoringv1.ProbeTargetStaticConfig{
+								Targets: []string{"prometheus.io"},
+							},
+						},
+					},
+				},
+			},
+			scrapeConfigs: []*promv1alpha1.ScrapeConfig{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "federation",
+						Namespace: "test",
+					},
+					Spec: promv1alpha1.ScrapeConfigSpec{
+						StaticConfigs: []promv1alpha1.StaticConfig{
+							{
+								Targets: []promv1alpha1.Target{"prometheus.other-cluster:9090"},
+							},
+						},
+					},
+				},
+			},
+			cfg: allocatorconfig.Config{
+				PrometheusCR: allocatorconfig.PrometheusCRConfig{
+					ServiceMonitorSelector: &metav1.LabelSelector{},
+					PodMonitorSelector:     &metav1.LabelSelector{},
+					ProbeSelector:          &metav1.LabelSelector{},
+					ScrapeConfigSelector:   &metav1.LabelSelector{},
+				},
+			},
+			want: &promconfig.Config{
+				ScrapeConfigs: []*promconfig.ScrapeConfig{
+					{
+						JobName:         "probe/test/blackbox",
+						ScrapeInterval:  model.Duration(30 * time.Second),
+						ScrapeProtocols: defaultScrapeProtocols,
+						ScrapeTimeout:   model.Duration(10 * time.Second),
+						HonorTimestamps: true,
+						HonorLabels:     false,
+						Scheme:          "http",
+						MetricsPath:     "/probe",
+						Params:          url.Values{"module": []string{"http_2xx"}},
+						ServiceDiscoveryConfigs: []discovery.Config{
+							discovery.StaticConfig{
+								{
+									Targets: []model.LabelSet{
+										{model.AddressLabel: "prometheus.io"},
+									},
+									Labels: model.LabelSet{"namespace": "test"},
+									Source: "0",
+								},
+							},
+						},
+						HTTPClientConfig:  config.DefaultHTTPClientConfig,
+						EnableCompression: true,
+					},
+					{
+						JobName:         "scrapeConfig/test/federation",
+						ScrapeInterval:  model.Duration(30 * time.Second),
+						ScrapeProtocols: defaultScrapeProtocols,
+						ScrapeTimeout:   model.Duration(10 * time.Second),
+						HonorTimestamps: true,
+						HonorLabels:     false,
+						Scheme:          "http",
+						MetricsPath:     "/metrics",
+						ServiceDiscoveryConfigs: []discovery.Config{
+							discovery.StaticConfig{
+								{
+									Targets: []model.LabelSet{
+										{model.AddressLabel: "prometheus.other-cluster:9090"},
+									},
+									Labels: model.LabelSet{},
+									Source: "0",
+								},
+							},
+						},
+						HTTPClientConfig:  config.DefaultHTTPClientConfig,
+						EnableCompression: true,
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			w, _ := getTestPrometheusCRWatcher(t, tt.serviceMonitors, tt.podMonitors, tt.cfg)
+			w, _ := getTestPrometheusCRWatcher(t, tt.serviceMonitors, tt.podMonitors, tt.probes, tt.scrapeConfigs, tt.cfg)
 
 			// Start namespace informers in order to populate cache.
 			go w.nsInformer.Run(w.stopChannel)
@@ -908,7 +1010,7 @@ func TestNamespaceLabelUpdate(t *testing.T) {
 		ScrapeConfigs: []*promconfig.ScrapeConfig{},
 	}
 
-	w, source := getTestPrometheusCRWatcher(t, nil, podMonitors, cfg)
+	w, source := getTestPrometheusCRWatcher(t, nil, podMonitors, nil, nil, cfg)
 	events := make(chan Event, 1)
 	eventInterval := 5 * time.Millisecond
 
@@ -954,6 +1056,26 @@ func TestNamespaceLabelUpdate(t *testing.T) {
 	assert.Equal(t, want_after.ScrapeConfigs, got.ScrapeConfigs)
 }
 
+func TestGetInformers(t *testing.T) {
+	factory := informers.NewMonitoringInformerFactories(map[string]struct{}{v1.NamespaceAll: {}}, map[string]struct{}{}, fakemonitoringclient.NewSimpleClientset(), 0, nil)
+
+	// Probes and ScrapeConfigs are only watched when selected, their CRDs might not be installed
+	monitoringInformers, err := getInformers(factory, allocatorconfig.PrometheusCRConfig{})
+	require.NoError(t, err)
+	assert.Len(t, monitoringInformers, 2)
+	assert.Contains(t, monitoringInformers, monitoringv1.ServiceMonitorName)
+	assert.Contains(t, monitoringInformers, monitoringv1.PodMonitorName)
+
+	monitoringInformers, err = getInformers(factory, allocatorconfig.PrometheusCRConfig{
+		ProbeSelector:        &metav1.LabelSelector{},
+		ScrapeConfigSelector: &metav1.LabelSelector{},
+	})
+	require.NoError(t, err)
+	assert.Len(t, monitoringInformers, 4)
+	assert.Contains(t, monitoringInformers, monitoringv1.ProbeName)
+	assert.Contains(t, monitoringInformers, promv1alpha1.ScrapeConfigName)
+}
+
 func TestRateLimit(t *testing.T) {
 	var err error
 	serviceMonitor := &monitoringv1.ServiceMonitor{
@@ -974,7 +1096,7 @@ func TestRateLimit(t *testing.T) {
 	eventInterval := 5 * time.Millisecond
 	cfg := allocatorconfig.Config{}
 
-	w, _ := getTestPrometheusCRWatcher(t, nil, nil, cfg)
+	w, _ := getTestPrometheusCRWatcher(t, nil, nil, nil, nil, cfg)
 	defer w.Close()
 	w.eventInterval = eventInterval
 
@@ -1037,7 +1159,7 @@ func TestRateLimit(t *testing.T) {
 
 // getTestPrometheusCRWatcher creates a test instance of PrometheusCRWatcher with fake clients
 // and test secrets.
-func getTestPrometheusCRWatcher(t *testing.T, svcMonitors []*monitoringv1.ServiceMonitor, podMonitors []*monitoringv1.PodMonitor, cfg allocatorconfig.Config) (*PrometheusCRWatcher, *fcache.FakeControllerSource) {
+func getTestPrometheusCRWatcher(t *testing.T, svcMonitors []*monitoringv1.ServiceMonitor, podMonitors []*monitoringv1.PodMonitor, probes []*monitoringv1.Probe, scrapeConfigs []*promv1alpha1.ScrapeConfig, cfg allocatorconfig.Config) (*PrometheusCRWatcher, *fcache.FakeControllerSource) {
 	mClient := fakemonitoringclient.NewSimpleClientset()
 	for _, sm := range svcMonitors {
 		if sm != nil {
@@ -1055,6 +1177,22 @@ func getTestPrometheusCRWatcher(t *testing.T, svcMonitors []*monitoringv1.Servic
 			}
 		}
 	}
+	for _, prb := range probes {
+		if prb != nil {
+			_, err := mClient.MonitoringV1().Probes(prb.Namespace).Create(context.Background(), prb, metav1.CreateOptions{})
+			if err != nil {
+				t.Fatal(t, err)
+			}
+		}
+	}
+	for _, scc := range scrapeConfigs {
+		if scc != nil {
+			_, err := mClient.MonitoringV1alpha1().ScrapeConfigs(scc.Namespace).Create(context.Background(), scc, metav1.CreateOptions{})
+			if err != nil {
+				t.Fatal(t, err)
+			}
+		}
+	}
 
 	k8sClient := fake.NewSimpleClientset()
 	_, err := k8sClient.CoreV1().Secrets("test").Create(context.Background(), &v1.Secret{
@@ -1079,7 +1217,7 @@ func getTestPrometheusCRWatcher(t *testing.T, svcMonitors []*monitoringv1.Servic
 	}
 
 	factory := informers.NewMonitoringInformerFactories(map[string]struct{}{v1.NamespaceAll: {}}, map[string]struct{}{}, mClient, 0, nil)
-	informers, err := getInformers(factory)
+	informers, err := getInformers(factory, cfg.PrometheusCR)
 	if err != nil {
 		t.Fatal(t, err)
 	}
@@ -1090,8 +1228,12 @@ func getTestPrometheusCRWatcher(t *testing.T, svcMonitors []*monitoringv1.Servic
 				ScrapeInterval:                  monitoringv1.Duration("30s"),
 				ServiceMonitorSelector:          cfg.PrometheusCR.ServiceMonitorSelector,
 				PodMonitorSelector:              cfg.PrometheusCR.PodMonitorSelector,
+				ProbeSelector:                   cfg.PrometheusCR.ProbeSelector,
+				ScrapeConfigSelector:            cfg.PrometheusCR.ScrapeConfigSelector,
 				ServiceMonitorNamespaceSelector: cfg.PrometheusCR.ServiceMonitorNamespaceSelector,
 				PodMonitorNamespaceSelector:     cfg.PrometheusCR.PodMonitorNamespaceSelector,
+				ProbeNamespaceSelector:          cfg.PrometheusCR.ProbeNamespaceSelector,
+				ScrapeConfigNamespaceSelector:   cfg.PrometheusCR.ScrapeConfigNamespaceSelector,
 			},
 		},
 	}
@@ -1130,6 +1272,8 @@ func getTestPrometheusCRWatcher(t *testing.T, svcMonitors []*monitoringv1.Servic
 		configGenerator:                 generator,
 		podMonitorNamespaceSelector:     cfg.PrometheusCR.PodMonitorNamespaceSelector,
 		serviceMonitorNamespaceSelector: cfg.PrometheusCR.ServiceMonitorNamespaceSelector,
+		probeNamespaceSelector:          cfg.PrometheusCR.ProbeNamespaceSelector,
+		scrapeConfigNamespaceSelector:   cfg.PrometheusCR.ScrapeConfigNamespaceSelector,
 		resourceSelector:                resourceSelector,
 		store:                           store,
 	}, source
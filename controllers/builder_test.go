@@ -2007,3 +2007,60 @@ prometheus_cr:
 		})
 	}
 }
+
+func TestBuildCollectorTargetAllocatorCR(t *testing.T) {
+	goodConfig := v1beta1.Config{}
+	err := go_yaml.Unmarshal([]byte(`
+receivers:
+  prometheus:
+    config:
+      scrape_configs: []
+exporters:
+  debug:
+service:
+  pipelines:
+    metrics:
+      receivers: [prometheus]
+      exporters: [debug]
+`), &goodConfig)
+	require.NoError(t, err)
+
+	require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.CollectorUsesTargetAllocatorCR.ID(), true))
+	defer func() {
+		require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.CollectorUsesTargetAllocatorCR.ID(), false))
+	}()
+
+	params := manifests.Params{
+		Log:    logr.Discard(),
+		Config: config.New(),
+		OtelCol: v1beta1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "test",
+			},
+			Spec: v1beta1.OpenTelemetryCollectorSpec{
+				Mode:   "statefulset",
+				Config: goodConfig,
+				TargetAllocator: v1beta1.TargetAllocatorEmbedded{
+					Enabled: true,
+				},
+			},
+		},
+	}
+	targetAllocator, err := collector.TargetAllocator(params)
+	require.NoError(t, err)
+	params.TargetAllocator = *targetAllocator
+
+	got, err := BuildCollector(params)
+	require.NoError(t, err)
+
+	var targetAllocators []*v1alpha1.TargetAllocator
+	for _, obj := range got {
+		if ta, ok := obj.(*v1alpha1.TargetAllocator); ok {
+			targetAllocators = append(targetAllocators, ta)
+		}
+		require.NotEqual(t, "test-targetallocator", obj.GetName(), "target allocator manifests should be left to the TargetAllocator reconciler")
+	}
+	require.Len(t, targetAllocators, 1)
+	require.Equal(t, selectorLabels, targetAllocators[0].Spec.CollectorSelector.MatchLabels)
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 
 	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -35,6 +36,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/opampbridge"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/targetallocator"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
 
 func isNamespaceScoped(obj client.Object) bool {
@@ -59,7 +61,12 @@ func BuildCollector(params manifests.Params) ([]client.Object, error) {
 		}
 		resources = append(resources, objs...)
 	}
-	if params.OtelCol.Spec.TargetAllocator.Enabled {
+	if featuregate.CollectorUsesTargetAllocatorCR.IsEnabled() {
+		// the TargetAllocator reconciler takes care of the manifests
+		if params.OtelCol.Spec.TargetAllocator.Enabled {
+			resources = append(resources, params.TargetAllocator.DeepCopy())
+		}
+	} else if params.OtelCol.Spec.TargetAllocator.Enabled {
 		taParams := targetallocator.Params{
 			Client:          params.Client,
 			Scheme:          params.Scheme,
@@ -156,7 +163,7 @@ func reconcileDesiredObjects(ctx context.Context, kubeClient client.Client, logg
 		})
 		if crudErr != nil && errors.Is(crudErr, manifests.ImmutableChangeErr) {
 			l.Error(crudErr, "detected immutable field change, trying to delete, new object will be created on next reconcile", "existing", existing.GetName())
-			delErr := kubeClient.Delete(ctx, existing)
+			delErr := kubeClient.Delete(ctx, existing, deleteOptions(existing)...)
 			if delErr != nil {
 				return delErr
 			}
@@ -182,6 +189,16 @@ func reconcileDesiredObjects(ctx context.Context, kubeClient client.Client, logg
 	return nil
 }
 
+// deleteOptions returns the options deleting the object. Jobs orphan their pods unless the propagation is set, the
+// other objects keep the default propagation.
+func deleteOptions(obj client.Object) []client.DeleteOption {
+	_, isJob := obj.(*batchv1.Job)
+	if isJob || obj.GetObjectKind().GroupVersionKind().GroupKind() == batchv1.SchemeGroupVersion.WithKind("Job").GroupKind() {
+		return []client.DeleteOption{client.PropagationPolicy(metav1.DeletePropagationBackground)}
+	}
+	return nil
+}
+
 func deleteObjects(ctx context.Context, kubeClient client.Client, logger logr.Logger, objects map[types.UID]client.Object) error {
 	// Pruning owned objects in the cluster which are not should not be present after the reconciliation.
 	pruneErrs := []error{}
@@ -192,7 +209,7 @@ func deleteObjects(ctx context.Context, kubeClient client.Client, logger logr.Lo
 		)
 
 		l.Info("pruning unmanaged resource")
-		err := kubeClient.Delete(ctx, obj)
+		err := kubeClient.Delete(ctx, obj, deleteOptions(obj)...)
 		if err != nil {
 			l.Error(err, "failed to delete resource")
 			pruneErrs = append(pruneErrs, err)
@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestDeleteOptions(t *testing.T) {
+	unstructuredOf := func(apiVersion, kind string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion(apiVersion)
+		obj.SetKind(kind)
+		return obj
+	}
+	for _, tc := range []struct {
+		name       string
+		obj        client.Object
+		background bool
+	}{
+		{name: "job", obj: &batchv1.Job{}, background: true},
+		{name: "listed job", obj: unstructuredOf("batch/v1", "Job"), background: true},
+		{name: "cronjob", obj: &batchv1.CronJob{}},
+		{name: "deployment", obj: &appsv1.Deployment{}},
+		{name: "listed deployment", obj: unstructuredOf("apps/v1", "Deployment")},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := &client.DeleteOptions{}
+			opts.ApplyOptions(deleteOptions(tc.obj))
+			if tc.background {
+				assert.NotNil(t, opts.PropagationPolicy)
+				assert.Equal(t, "Background", string(*opts.PropagationPolicy))
+			} else {
+				assert.Nil(t, opts.PropagationPolicy)
+			}
+		})
+	}
+}
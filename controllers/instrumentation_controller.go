@@ -0,0 +1,146 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlbuilder "sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	instrumentationStatus "github.com/open-telemetry/opentelemetry-operator/internal/status/instrumentation"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/instrumentation"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/instrumentation/rollout"
+)
+
+// InstrumentationReconciler reconciles the status of the Instrumentation objects from the pods they were injected
+// into, and restarts the workloads injected with an outdated spec of them when asked to.
+type InstrumentationReconciler struct {
+	client.Client
+	scheme   *runtime.Scheme
+	log      logr.Logger
+	recorder record.EventRecorder
+	rollout  *rollout.Rollout
+}
+
+// InstrumentationReconcilerParams is the set of options to build a new InstrumentationReconciler.
+type InstrumentationReconcilerParams struct {
+	client.Client
+	Recorder record.EventRecorder
+	Scheme   *runtime.Scheme
+	Log      logr.Logger
+	Config   config.Config
+}
+
+func NewInstrumentationReconciler(params InstrumentationReconcilerParams) *InstrumentationReconciler {
+	return &InstrumentationReconciler{
+		Client:   params.Client,
+		scheme:   params.Scheme,
+		log:      params.Log,
+		recorder: params.Recorder,
+		rollout: rollout.NewRollout(params.Client, params.Log.WithName("rollout"), params.Recorder,
+			params.Config.InstrumentationAdminNamespaces()),
+	}
+}
+
+//+kubebuilder:rbac:groups=opentelemetry.io,resources=instrumentations,verbs=get;list;watch
+//+kubebuilder:rbac:groups=opentelemetry.io,resources=instrumentations/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+//+kubebuilder:rbac:groups=apps,resources=replicasets,verbs=get;list;watch
+//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch
+
+// Reconcile updates the status of the instrumentation with the workloads whose pods it was injected into, and
+// restarts those injected with an outdated spec of it when its rollout policy asks for it.
+func (r *InstrumentationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("instrumentation", req.NamespacedName)
+	var instance v1alpha1.Instrumentation
+	if err := r.Client.Get(ctx, req.NamespacedName, &instance); err != nil {
+		if !apierrors.IsNotFound(err) {
+			log.Error(err, "unable to fetch Instrumentation")
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if deletionTimestamp := instance.GetDeletionTimestamp(); deletionTimestamp != nil {
+		return ctrl.Result{}, nil
+	}
+
+	log.V(2).Info("updating instrumentation status")
+	changed := instance.DeepCopy()
+	if err := instrumentationStatus.UpdateInstrumentationStatus(ctx, r.Client, changed); err != nil {
+		r.recorder.Event(changed, corev1.EventTypeWarning, "StatusFailure", err.Error())
+		return ctrl.Result{}, err
+	}
+	if !apiequality.Semantic.DeepEqual(instance.Status, changed.Status) {
+		if err := r.Client.Status().Patch(ctx, changed, client.MergeFrom(&instance)); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	pending, err := r.rollout.Restart(ctx, &instance)
+	if err != nil {
+		r.recorder.Event(&instance, corev1.EventTypeWarning, "RolloutFailure", err.Error())
+		return ctrl.Result{}, err
+	}
+	if pending {
+		return ctrl.Result{RequeueAfter: rollout.RequeueAfter}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *InstrumentationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	err := mgr.GetFieldIndexer().IndexField(context.Background(), instrumentation.PodMetadata(), instrumentation.InjectedPodsIndex, instrumentation.InjectedPodsIndexValues)
+	if err != nil {
+		return err
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.Instrumentation{}).
+		// only the metadata of the pods is cached, and only the pods with an injection status are queued
+		Watches(&corev1.Pod{}, handler.EnqueueRequestsFromMapFunc(injectedPodToInstrumentations), ctrlbuilder.OnlyMetadata, ctrlbuilder.WithPredicates(predicate.NewPredicateFuncs(hasInjectionStatus))).
+		Complete(r)
+}
+
+// hasInjectionStatus returns whether the outcome of the injection of instrumentations is recorded on the pod.
+func hasInjectionStatus(obj client.Object) bool {
+	_, ok := obj.GetAnnotations()[instrumentation.InjectionStatusAnnotation]
+	return ok
+}
+
+// injectedPodToInstrumentations maps a pod to the instrumentations injected into it, or failing to be.
+func injectedPodToInstrumentations(_ context.Context, obj client.Object) []reconcile.Request {
+	var requests []reconcile.Request
+	for _, value := range instrumentation.InjectedPodsIndexValues(obj) {
+		namespace, name, found := strings.Cut(value, "/")
+		if !found || name == "" {
+			continue
+		}
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: namespace, Name: name}})
+	}
+	return requests
+}
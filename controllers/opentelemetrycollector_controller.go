@@ -17,27 +17,39 @@ package controllers
 
 import (
 	"context"
+	"crypto/sha256"
 	"fmt"
+	"io"
+	"slices"
 	"sort"
+	"strings"
 
 	"github.com/go-logr/logr"
 	routev1 "github.com/openshift/api/route/v1"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	policyV1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlbuilder "sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/yaml"
 
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/openshift"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/prometheus"
@@ -46,8 +58,10 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
 	collectorStatus "github.com/open-telemetry/opentelemetry-operator/internal/status/collector"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/sidecar"
 )
 
 var (
@@ -60,19 +74,22 @@ var (
 // OpenTelemetryCollectorReconciler reconciles a OpenTelemetryCollector object.
 type OpenTelemetryCollectorReconciler struct {
 	client.Client
-	recorder record.EventRecorder
-	scheme   *runtime.Scheme
-	log      logr.Logger
-	config   config.Config
+	apiReader client.Reader
+	recorder  record.EventRecorder
+	scheme    *runtime.Scheme
+	log       logr.Logger
+	config    config.Config
 }
 
 // Params is the set of options to build a new OpenTelemetryCollectorReconciler.
 type Params struct {
 	client.Client
-	Recorder record.EventRecorder
-	Scheme   *runtime.Scheme
-	Log      logr.Logger
-	Config   config.Config
+	// APIReader reads the objects the operator doesn't cache straight from the API server, it defaults to the Client.
+	APIReader client.Reader
+	Recorder  record.EventRecorder
+	Scheme    *runtime.Scheme
+	Log       logr.Logger
+	Config    config.Config
 }
 
 func (r *OpenTelemetryCollectorReconciler) findOtelOwnedObjects(ctx context.Context, params manifests.Params) (map[types.UID]client.Object, error) {
@@ -81,6 +98,9 @@ func (r *OpenTelemetryCollectorReconciler) findOtelOwnedObjects(ctx context.Cont
 		&autoscalingv2.HorizontalPodAutoscaler{},
 		&networkingv1.Ingress{},
 		&policyV1.PodDisruptionBudget{},
+		// a job is named after the hash of its spec, the job of a previous spec is pruned
+		&batchv1.Job{},
+		&batchv1.CronJob{},
 	}
 	listOps := &client.ListOptions{
 		Namespace:     params.OtelCol.Namespace,
@@ -104,6 +124,15 @@ func (r *OpenTelemetryCollectorReconciler) findOtelOwnedObjects(ctx context.Cont
 			ownedObjects[uid] = object
 		}
 	}
+	if featuregate.CollectorUsesTargetAllocatorCR.IsEnabled() {
+		objs, err := r.findTargetAllocatorObjects(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		for uid, object := range objs {
+			ownedObjects[uid] = object
+		}
+	}
 	if params.Config.CreateRBACPermissions() == rbac.Available {
 		objs, err := r.findClusterRoleObjects(ctx, params)
 		if err != nil {
@@ -114,6 +143,20 @@ func (r *OpenTelemetryCollectorReconciler) findOtelOwnedObjects(ctx context.Cont
 		}
 	}
 
+	// only the canary deployment is pruned, it's told apart from the collector's own by its labels
+	canaryLabels := manifestutils.SelectorLabels(params.OtelCol.ObjectMeta, collector.ComponentOpenTelemetryCollector)
+	canaryLabels[collector.CanaryLabel] = "true"
+	canaries, canaryErr := getList(ctx, r, &appsv1.Deployment{}, &client.ListOptions{
+		Namespace:     params.OtelCol.Namespace,
+		LabelSelector: labels.SelectorFromSet(canaryLabels),
+	})
+	if canaryErr != nil {
+		return nil, canaryErr
+	}
+	for uid, object := range canaries {
+		ownedObjects[uid] = object
+	}
+
 	configMapList := &corev1.ConfigMapList{}
 	err := r.List(ctx, configMapList, listOps)
 	if err != nil {
@@ -121,12 +164,34 @@ func (r *OpenTelemetryCollectorReconciler) findOtelOwnedObjects(ctx context.Cont
 	}
 	ownedConfigMaps := r.getConfigMapsToRemove(params.OtelCol.Spec.ConfigVersions, configMapList)
 	for i := range ownedConfigMaps {
+		// the stable configuration is still in use while a new one is baked on canary replicas, and is what a failing
+		// configuration gets rolled back to
+		if rollout := params.OtelCol.Status.Rollout; rollout != nil && rollout.StableConfigHash != "" && ownedConfigMaps[i].Name == naming.ConfigMap(params.OtelCol.Name, rollout.StableConfigHash) {
+			continue
+		}
 		ownedObjects[ownedConfigMaps[i].GetUID()] = &ownedConfigMaps[i]
 	}
 
 	return ownedObjects, nil
 }
 
+// findTargetAllocatorObjects returns the TargetAllocator CRs controlled by the collector. They carry the collector's
+// own labels rather than selector labels, so they're matched by owner reference instead.
+func (r *OpenTelemetryCollectorReconciler) findTargetAllocatorObjects(ctx context.Context, params manifests.Params) (map[types.UID]client.Object, error) {
+	ownedObjects := map[types.UID]client.Object{}
+	targetAllocatorList := &v1alpha1.TargetAllocatorList{}
+	err := r.List(ctx, targetAllocatorList, client.InNamespace(params.OtelCol.Namespace))
+	if err != nil {
+		return nil, fmt.Errorf("error listing TargetAllocators: %w", err)
+	}
+	for i := range targetAllocatorList.Items {
+		if metav1.IsControlledBy(&targetAllocatorList.Items[i], &params.OtelCol) {
+			ownedObjects[targetAllocatorList.Items[i].GetUID()] = &targetAllocatorList.Items[i]
+		}
+	}
+	return ownedObjects, nil
+}
+
 // The cluster scope objects do not have owner reference.
 func (r *OpenTelemetryCollectorReconciler) findClusterRoleObjects(ctx context.Context, params manifests.Params) (map[types.UID]client.Object, error) {
 	ownedObjects := map[types.UID]client.Object{}
@@ -172,6 +237,7 @@ func (r *OpenTelemetryCollectorReconciler) getParams(instance v1beta1.OpenTeleme
 	p := manifests.Params{
 		Config:   r.config,
 		Client:   r.Client,
+		Reader:   r.apiReader,
 		OtelCol:  instance,
 		Log:      r.log,
 		Scheme:   r.scheme,
@@ -198,15 +264,23 @@ func NewReconciler(p Params) *OpenTelemetryCollectorReconciler {
 		config:   p.Config,
 		recorder: p.Recorder,
 	}
+	r.apiReader = p.APIReader
+	if r.apiReader == nil {
+		r.apiReader = p.Client
+	}
 	return r
 }
 
 // +kubebuilder:rbac:groups="",resources=pods;configmaps;services;serviceaccounts;persistentvolumeclaims;persistentvolumes,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
 // +kubebuilder:rbac:groups=apps,resources=daemonsets;deployments;statefulsets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=batch,resources=jobs;cronjobs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;create;update
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles;rolebindings,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors;podmonitors,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=route.openshift.io,resources=routes;routes/custom-host,verbs=get;list;watch;create;update;patch;delete
@@ -275,9 +349,27 @@ func (r *OpenTelemetryCollectorReconciler) Reconcile(ctx context.Context, req ct
 		}
 	}
 
+	if collector.OverlaysEnabled(instance) {
+		if params, err = r.applyConfigOverlays(ctx, params); err != nil {
+			return collectorStatus.HandleReconcileStatus(ctx, log, params, instance, collectorStatus.NewConfigError(err))
+		}
+	}
+
+	if len(instance.Spec.ConfigReferences) > 0 {
+		if params.ConfigReferencesHash, err = r.configReferencesHash(ctx, instance); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if collector.RestartOnMountedChangesEnabled(instance) {
+		if params.MountsHash, err = r.mountsHash(ctx, instance); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
 	desiredObjects, buildErr := BuildCollector(params)
 	if buildErr != nil {
-		return ctrl.Result{}, buildErr
+		return collectorStatus.HandleReconcileStatus(ctx, log, params, instance, collectorStatus.NewConfigError(buildErr))
 	}
 
 	ownedObjects, err := r.findOtelOwnedObjects(ctx, params)
@@ -291,6 +383,10 @@ func (r *OpenTelemetryCollectorReconciler) Reconcile(ctx context.Context, req ct
 
 // SetupWithManager tells the manager what our controller is interested in.
 func (r *OpenTelemetryCollectorReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	err := mgr.GetFieldIndexer().IndexField(context.Background(), &v1beta1.OpenTelemetryCollector{}, referencedObjectsIndex, referencedObjects)
+	if err != nil {
+		return err
+	}
 	builder := ctrl.NewControllerManagedBy(mgr).
 		For(&v1beta1.OpenTelemetryCollector{}).
 		Owns(&corev1.ConfigMap{}).
@@ -299,17 +395,34 @@ func (r *OpenTelemetryCollectorReconciler) SetupWithManager(mgr ctrl.Manager) er
 		Owns(&appsv1.Deployment{}).
 		Owns(&appsv1.DaemonSet{}).
 		Owns(&appsv1.StatefulSet{}).
+		Owns(&batchv1.Job{}).
+		Owns(&batchv1.CronJob{}).
 		Owns(&corev1.PersistentVolume{}).
 		Owns(&corev1.PersistentVolumeClaim{}).
 		Owns(&networkingv1.Ingress{}).
 		Owns(&autoscalingv2.HorizontalPodAutoscaler{}).
-		Owns(&policyV1.PodDisruptionBudget{})
+		Owns(&policyV1.PodDisruptionBudget{}).
+		Owns(&rbacv1.Role{}).
+		Owns(&rbacv1.RoleBinding{}).
+		// sidecar pods aren't owned by the collector, but their health is reported in its status. Only their metadata is
+		// cached, the pods themselves are listed from the API server when the status is updated
+		Watches(&corev1.Pod{}, handler.EnqueueRequestsFromMapFunc(sidecarPodToCollector), ctrlbuilder.OnlyMetadata, ctrlbuilder.WithPredicates(predicate.NewPredicateFuncs(isSidecarPod))).
+		// collectors are reconciled again when their base configuration changes, and when the Secrets and ConfigMaps
+		// their pods are restarted for change. Only the metadata of the Secrets is cached, the referenced ones are read
+		// from the API server
+		Watches(&v1beta1.OpenTelemetryCollector{}, handler.EnqueueRequestsFromMapFunc(r.collectorsReferencing("OpenTelemetryCollector"))).
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.collectorsReferencing("ConfigMap"))).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.collectorsReferencing("Secret")), ctrlbuilder.OnlyMetadata)
 
 	if r.config.CreateRBACPermissions() == rbac.Available {
 		builder.Owns(&rbacv1.ClusterRoleBinding{})
 		builder.Owns(&rbacv1.ClusterRole{})
 	}
 
+	if featuregate.CollectorUsesTargetAllocatorCR.IsEnabled() {
+		builder.Owns(&v1alpha1.TargetAllocator{})
+	}
+
 	if featuregate.PrometheusOperatorIsAvailable.IsEnabled() && r.config.PrometheusCRAvailability() == prometheus.Available {
 		builder.Owns(&monitoringv1.ServiceMonitor{})
 		builder.Owns(&monitoringv1.PodMonitor{})
@@ -321,6 +434,238 @@ func (r *OpenTelemetryCollectorReconciler) SetupWithManager(mgr ctrl.Manager) er
 	return builder.Complete(r)
 }
 
+// isSidecarPod returns whether a collector was injected into the pod.
+func isSidecarPod(obj client.Object) bool {
+	_, ok := obj.GetLabels()[sidecar.InjectedLabel]
+	return ok
+}
+
+// sidecarPodToCollector maps a pod to the collector which was injected into it, if any.
+func sidecarPodToCollector(_ context.Context, obj client.Object) []reconcile.Request {
+	value, ok := obj.GetLabels()[sidecar.InjectedLabel]
+	if !ok {
+		return nil
+	}
+	// the label holds "<namespace>.<name>", possibly truncated, and sidecars come from collectors in the pod's namespace
+	name, found := strings.CutPrefix(value, obj.GetNamespace()+".")
+	if !found || name == "" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: obj.GetNamespace(), Name: name}}}
+}
+
+// applyConfigOverlays merges the configuration of the collector on top of its base configuration and applies its
+// patches, before the manifests are built from it.
+func (r *OpenTelemetryCollectorReconciler) applyConfigOverlays(ctx context.Context, params manifests.Params) (manifests.Params, error) {
+	base, err := r.baseConfig(ctx, params.OtelCol)
+	if err != nil {
+		return params, err
+	}
+	merged, err := params.OtelCol.Spec.Config.Merge(base, params.OtelCol.Spec.ConfigPatches)
+	if err != nil {
+		return params, err
+	}
+	// the webhook can't validate the pipelines of a configuration it only has a part of
+	if _, errs := merged.ValidatePipelines(); len(errs) > 0 {
+		return params, fmt.Errorf("the merged configuration is incorrect: %w", errs.ToAggregate())
+	}
+	params.OtelCol.Spec.Config = merged
+
+	// the target allocator is generated from the configuration as well
+	targetAllocator, err := collector.TargetAllocator(params)
+	if err != nil {
+		return params, err
+	}
+	if targetAllocator != nil {
+		params.TargetAllocator = *targetAllocator
+	}
+	return params, nil
+}
+
+func (r *OpenTelemetryCollectorReconciler) baseConfig(ctx context.Context, otelcol v1beta1.OpenTelemetryCollector) (*v1beta1.Config, error) {
+	source := otelcol.Spec.BaseConfig
+	switch {
+	case source == nil:
+		return nil, nil
+	case source.CollectorName != "":
+		base := v1beta1.OpenTelemetryCollector{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: otelcol.Namespace, Name: source.CollectorName}, &base); err != nil {
+			return nil, fmt.Errorf("failed to get the base collector %s: %w", source.CollectorName, err)
+		}
+		return &base.Spec.Config, nil
+	case source.ConfigMap != nil:
+		cm := corev1.ConfigMap{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: otelcol.Namespace, Name: source.ConfigMap.Name}, &cm); err != nil {
+			return nil, fmt.Errorf("failed to get the base configuration ConfigMap %s: %w", source.ConfigMap.Name, err)
+		}
+		data, ok := cm.Data[source.ConfigMap.Key]
+		if !ok {
+			return nil, fmt.Errorf("the base configuration ConfigMap %s has no key %s", source.ConfigMap.Name, source.ConfigMap.Key)
+		}
+		base := &v1beta1.Config{}
+		if err := yaml.Unmarshal([]byte(data), base); err != nil {
+			return nil, fmt.Errorf("failed to parse the base configuration in ConfigMap %s: %w", source.ConfigMap.Name, err)
+		}
+		return base, nil
+	default:
+		return nil, fmt.Errorf("spec.baseConfig must set either collectorName or configMap")
+	}
+}
+
+// configReferencesHash returns the hash of the values referenced by the configuration references of the collector.
+// Missing values don't fail the reconciliation, the pods report them, and they change the hash once created.
+func (r *OpenTelemetryCollectorReconciler) configReferencesHash(ctx context.Context, otelcol v1beta1.OpenTelemetryCollector) (string, error) {
+	h := sha256.New()
+	for _, ref := range otelcol.Spec.ConfigReferences {
+		value, err := r.configReferenceValue(ctx, otelcol.Namespace, ref)
+		if err != nil {
+			return "", err
+		}
+		_, _ = fmt.Fprintf(h, "%s:%d:", ref.Name, len(value))
+		_, _ = h.Write(value)
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
+
+func (r *OpenTelemetryCollectorReconciler) configReferenceValue(ctx context.Context, namespace string, ref v1beta1.ConfigReference) ([]byte, error) {
+	switch {
+	case ref.SecretKeyRef != nil:
+		secret := corev1.Secret{}
+		if err := r.apiReader.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.SecretKeyRef.Name}, &secret); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("failed to get the Secret %s referenced by %s: %w", ref.SecretKeyRef.Name, ref.Name, err)
+		}
+		return secret.Data[ref.SecretKeyRef.Key], nil
+	case ref.ConfigMapKeyRef != nil:
+		cm := corev1.ConfigMap{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.ConfigMapKeyRef.Name}, &cm); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("failed to get the ConfigMap %s referenced by %s: %w", ref.ConfigMapKeyRef.Name, ref.Name, err)
+		}
+		if value, ok := cm.Data[ref.ConfigMapKeyRef.Key]; ok {
+			return []byte(value), nil
+		}
+		return cm.BinaryData[ref.ConfigMapKeyRef.Key], nil
+	default:
+		return nil, nil
+	}
+}
+
+// mountsHash returns the hash of the content of the ConfigMaps and the Secrets mounted by the collector. Missing
+// objects don't fail the reconciliation either.
+func (r *OpenTelemetryCollectorReconciler) mountsHash(ctx context.Context, otelcol v1beta1.OpenTelemetryCollector) (string, error) {
+	configMaps, secrets := collector.MountedObjects(otelcol)
+	h := sha256.New()
+	for _, name := range configMaps {
+		cm := corev1.ConfigMap{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: otelcol.Namespace, Name: name}, &cm); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return "", fmt.Errorf("failed to get the mounted ConfigMap %s: %w", name, err)
+		}
+		data := map[string][]byte{}
+		for key, value := range cm.Data {
+			data[key] = []byte(value)
+		}
+		for key, value := range cm.BinaryData {
+			data[key] = value
+		}
+		writeData(h, "configmap/"+name, data)
+	}
+	for _, name := range secrets {
+		secret := corev1.Secret{}
+		if err := r.apiReader.Get(ctx, types.NamespacedName{Namespace: otelcol.Namespace, Name: name}, &secret); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return "", fmt.Errorf("failed to get the mounted Secret %s: %w", name, err)
+		}
+		writeData(h, "secret/"+name, secret.Data)
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
+
+// writeData writes the given data to the hash, sorted by key.
+func writeData(w io.Writer, prefix string, data map[string][]byte) {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		_, _ = fmt.Fprintf(w, "%s/%s:%d:", prefix, key, len(data[key]))
+		_, _ = w.Write(data[key])
+	}
+}
+
+// referencedObjectsIndex indexes the collectors by the collector, ConfigMaps and Secrets of their namespace they're
+// reconciled again for when they change, as "<kind>/<name>".
+const referencedObjectsIndex = "opentelemetry.io/referenced-objects"
+
+// referencedObjects returns the values of referencedObjectsIndex for the collector: its base configuration, and the
+// Secrets and ConfigMaps its pods are restarted for.
+func referencedObjects(obj client.Object) []string {
+	otelcol, ok := obj.(*v1beta1.OpenTelemetryCollector)
+	if !ok {
+		return nil
+	}
+	var values []string
+	add := func(kind, name string) {
+		if value := kind + "/" + name; !slices.Contains(values, value) {
+			values = append(values, value)
+		}
+	}
+	if base := otelcol.Spec.BaseConfig; base != nil {
+		if base.CollectorName != "" {
+			add("OpenTelemetryCollector", base.CollectorName)
+		}
+		if base.ConfigMap != nil {
+			add("ConfigMap", base.ConfigMap.Name)
+		}
+	}
+	for _, ref := range otelcol.Spec.ConfigReferences {
+		if ref.SecretKeyRef != nil {
+			add("Secret", ref.SecretKeyRef.Name)
+		}
+		if ref.ConfigMapKeyRef != nil {
+			add("ConfigMap", ref.ConfigMapKeyRef.Name)
+		}
+	}
+	if collector.RestartOnMountedChangesEnabled(*otelcol) {
+		configMaps, secrets := collector.MountedObjects(*otelcol)
+		for _, name := range configMaps {
+			add("ConfigMap", name)
+		}
+		for _, name := range secrets {
+			add("Secret", name)
+		}
+	}
+	return values
+}
+
+// collectorsReferencing returns a function mapping an object of the given kind (a collector, a ConfigMap or a Secret)
+// to the collectors of its namespace referencing it, with referencedObjectsIndex. The kind is passed rather than
+// derived from the object, as metadata-only watches only deliver *metav1.PartialObjectMetadata.
+func (r *OpenTelemetryCollectorReconciler) collectorsReferencing(kind string) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		list := &v1beta1.OpenTelemetryCollectorList{}
+		if err := r.List(ctx, list, client.InNamespace(obj.GetNamespace()), client.MatchingFields{referencedObjectsIndex: kind + "/" + obj.GetName()}); err != nil {
+			r.log.Error(err, "failed to list the collectors referencing an object", "kind", kind, "namespace", obj.GetNamespace(), "name", obj.GetName())
+			return nil
+		}
+		requests := make([]reconcile.Request, 0, len(list.Items))
+		for i := range list.Items {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&list.Items[i])})
+		}
+		return requests
+	}
+}
+
 const collectorFinalizer = "opentelemetrycollector.opentelemetry.io/finalizer"
 
 func (r *OpenTelemetryCollectorReconciler) finalizeCollector(ctx context.Context, params manifests.Params) error {
@@ -0,0 +1,172 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+)
+
+func TestCollectorsReferencing(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+
+	collector := func(name string, spec v1beta1.OpenTelemetryCollectorSpec) *v1beta1.OpenTelemetryCollector {
+		return &v1beta1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       spec,
+		}
+	}
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithIndex(&v1beta1.OpenTelemetryCollector{}, referencedObjectsIndex, referencedObjects).
+		WithObjects(
+			collector("base", v1beta1.OpenTelemetryCollectorSpec{}),
+			collector("from-collector", v1beta1.OpenTelemetryCollectorSpec{
+				BaseConfig: &v1beta1.BaseConfigSource{CollectorName: "base"},
+			}),
+			collector("from-configmap", v1beta1.OpenTelemetryCollectorSpec{
+				BaseConfig: &v1beta1.BaseConfigSource{ConfigMap: &corev1.ConfigMapKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "shared"},
+					Key:                  "config.yaml",
+				}},
+			}),
+			collector("with-references", v1beta1.OpenTelemetryCollectorSpec{
+				ConfigReferences: []v1beta1.ConfigReference{
+					{
+						Name: "config",
+						ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "shared"},
+							Key:                  "endpoint",
+						},
+					},
+					{
+						Name: "api-key",
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "backend"},
+							Key:                  "api-key",
+						},
+					},
+				},
+			}),
+		).
+		Build()
+	r := &OpenTelemetryCollectorReconciler{Client: cli, log: logr.Discard()}
+
+	requests := func(names ...string) []reconcile.Request {
+		result := []reconcile.Request{}
+		for _, name := range names {
+			result = append(result, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: name}})
+		}
+		return result
+	}
+	for _, tc := range []struct {
+		name     string
+		kind     string
+		obj      client.Object
+		expected []reconcile.Request
+	}{
+		{
+			name:     "base collector",
+			kind:     "OpenTelemetryCollector",
+			obj:      collector("base", v1beta1.OpenTelemetryCollectorSpec{}),
+			expected: requests("from-collector"),
+		},
+		{
+			name:     "ConfigMap used as base configuration and referenced",
+			kind:     "ConfigMap",
+			obj:      &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "default"}},
+			expected: requests("from-configmap", "with-references"),
+		},
+		{
+			name:     "referenced Secret",
+			kind:     "Secret",
+			obj:      &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: "backend", Namespace: "default"}},
+			expected: requests("with-references"),
+		},
+		{
+			name:     "ConfigMap in another namespace",
+			kind:     "ConfigMap",
+			obj:      &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "other"}},
+			expected: requests(),
+		},
+		{
+			name:     "Secret with the name of a ConfigMap",
+			kind:     "Secret",
+			obj:      &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "default"}},
+			expected: requests(),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.ElementsMatch(t, tc.expected, r.collectorsReferencing(tc.kind)(context.Background(), tc.obj))
+		})
+	}
+}
+
+func TestApplyConfigOverlaysValidatesPipelines(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+
+	base := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "base", Namespace: "default"},
+		Data: map[string]string{"config.yaml": `receivers:
+  otlp: {}
+exporters:
+  debug: {}
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [debug]
+`},
+	}
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(base).Build()
+	r := &OpenTelemetryCollectorReconciler{Client: cli, log: logr.Discard()}
+
+	otelcol := v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			BaseConfig: &v1beta1.BaseConfigSource{ConfigMap: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "base"},
+				Key:                  "config.yaml",
+			}},
+			ConfigPatches: []v1beta1.ConfigPatch{{
+				Type:  v1beta1.ConfigPatchTypeJSON,
+				Patch: `[{"op": "remove", "path": "/receivers/otlp"}]`,
+			}},
+		},
+	}
+	_, err := r.applyConfigOverlays(context.Background(), manifests.Params{OtelCol: otelcol})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "the merged configuration is incorrect")
+	assert.Contains(t, err.Error(), "otlp")
+}
@@ -0,0 +1,176 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyV1 "k8s.io/api/policy/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/prometheus"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/targetallocator"
+	taStatus "github.com/open-telemetry/opentelemetry-operator/internal/status/targetallocator"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+)
+
+// TargetAllocatorReconciler reconciles a TargetAllocator object.
+type TargetAllocatorReconciler struct {
+	client.Client
+	scheme   *runtime.Scheme
+	log      logr.Logger
+	recorder record.EventRecorder
+	config   config.Config
+}
+
+// TargetAllocatorReconcilerParams is the set of options to build a new TargetAllocatorReconciler.
+type TargetAllocatorReconcilerParams struct {
+	client.Client
+	Recorder record.EventRecorder
+	Scheme   *runtime.Scheme
+	Log      logr.Logger
+	Config   config.Config
+}
+
+func (r *TargetAllocatorReconciler) getParams(instance v1alpha1.TargetAllocator) targetallocator.Params {
+	return targetallocator.Params{
+		Config:          r.config,
+		Client:          r.Client,
+		Log:             r.log,
+		Scheme:          r.scheme,
+		Recorder:        r.recorder,
+		TargetAllocator: instance,
+	}
+}
+
+func (r *TargetAllocatorReconciler) findTargetAllocatorOwnedObjects(ctx context.Context, params targetallocator.Params) (map[types.UID]client.Object, error) {
+	ownedObjects := map[types.UID]client.Object{}
+	ownedObjectTypes := []client.Object{
+		&policyV1.PodDisruptionBudget{},
+		&rbacv1.Role{},
+		&rbacv1.RoleBinding{},
+	}
+	listOps := &client.ListOptions{
+		Namespace:     params.TargetAllocator.Namespace,
+		LabelSelector: labels.SelectorFromSet(manifestutils.SelectorLabels(params.TargetAllocator.ObjectMeta, targetallocator.ComponentOpenTelemetryTargetAllocator)),
+	}
+	if featuregate.PrometheusOperatorIsAvailable.IsEnabled() && r.config.PrometheusCRAvailability() == prometheus.Available {
+		ownedObjectTypes = append(ownedObjectTypes, &monitoringv1.ServiceMonitor{})
+	}
+	for _, objectType := range ownedObjectTypes {
+		objs, err := getList(ctx, r, objectType, listOps)
+		if err != nil {
+			return nil, err
+		}
+		for uid, object := range objs {
+			ownedObjects[uid] = object
+		}
+	}
+	return ownedObjects, nil
+}
+
+// NewTargetAllocatorReconciler creates a new reconciler for TargetAllocator objects.
+func NewTargetAllocatorReconciler(params TargetAllocatorReconcilerParams) *TargetAllocatorReconciler {
+	return &TargetAllocatorReconciler{
+		Client:   params.Client,
+		scheme:   params.Scheme,
+		log:      params.Log,
+		recorder: params.Recorder,
+		config:   params.Config,
+	}
+}
+
+//+kubebuilder:rbac:groups="",resources=pods;configmaps;services;serviceaccounts,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+//+kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles;rolebindings,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=opentelemetry.io,resources=targetallocators,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=opentelemetry.io,resources=targetallocators/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=opentelemetry.io,resources=targetallocators/finalizers,verbs=update
+
+// Reconcile the current state of a TargetAllocator resource with the desired state.
+func (r *TargetAllocatorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("targetallocator", req.NamespacedName)
+	var instance v1alpha1.TargetAllocator
+	if err := r.Client.Get(ctx, req.NamespacedName, &instance); err != nil {
+		if !apierrors.IsNotFound(err) {
+			log.Error(err, "unable to fetch TargetAllocator")
+		}
+		// we'll ignore not-found errors, since they can't be fixed by an immediate
+		// requeue (we'll need to wait for a new notification), and we can get them
+		// on deleted requests.
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	// We have a deletion, short circuit and let the deletion happen
+	if deletionTimestamp := instance.GetDeletionTimestamp(); deletionTimestamp != nil {
+		return ctrl.Result{}, nil
+	}
+
+	if instance.Spec.ManagementState == v1beta1.ManagementStateUnmanaged {
+		log.Info("Skipping reconciliation for unmanaged TargetAllocator resource", "name", req.String())
+		// Stop requeueing for unmanaged TargetAllocator custom resources
+		return ctrl.Result{}, nil
+	}
+
+	params := r.getParams(instance)
+	desiredObjects, buildErr := BuildTargetAllocator(params)
+	if buildErr != nil {
+		return ctrl.Result{}, buildErr
+	}
+
+	ownedObjects, err := r.findTargetAllocatorOwnedObjects(ctx, params)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	err = reconcileDesiredObjects(ctx, r.Client, log, &params.TargetAllocator, params.Scheme, desiredObjects, ownedObjects)
+	return taStatus.HandleReconcileStatus(ctx, log, params, err)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *TargetAllocatorReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	builder := ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.TargetAllocator{}).
+		Owns(&corev1.ConfigMap{}).
+		Owns(&corev1.ServiceAccount{}).
+		Owns(&corev1.Service{}).
+		Owns(&appsv1.Deployment{}).
+		Owns(&policyV1.PodDisruptionBudget{}).
+		Owns(&rbacv1.Role{}).
+		Owns(&rbacv1.RoleBinding{})
+
+	if featuregate.PrometheusOperatorIsAvailable.IsEnabled() && r.config.PrometheusCRAvailability() == prometheus.Available {
+		builder.Owns(&monitoringv1.ServiceMonitor{})
+	}
+
+	return builder.Complete(r)
+}
@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	policyV1 "k8s.io/api/policy/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/targetallocator"
+)
+
+func TestFindTargetAllocatorOwnedObjects(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	instance := v1alpha1.TargetAllocator{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+	}
+	other := v1alpha1.TargetAllocator{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default"},
+	}
+	objectMeta := func(name string, ta v1alpha1.TargetAllocator) metav1.ObjectMeta {
+		return metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			UID:       types.UID("uid-" + name),
+			Labels:    manifestutils.SelectorLabels(ta.ObjectMeta, targetallocator.ComponentOpenTelemetryTargetAllocator),
+		}
+	}
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			&policyV1.PodDisruptionBudget{ObjectMeta: objectMeta("test-pdb", instance)},
+			&rbacv1.Role{ObjectMeta: objectMeta("test-role", instance)},
+			&rbacv1.RoleBinding{ObjectMeta: objectMeta("test-rolebinding", instance)},
+			&policyV1.PodDisruptionBudget{ObjectMeta: objectMeta("other-pdb", other)},
+		).
+		Build()
+	r := NewTargetAllocatorReconciler(TargetAllocatorReconcilerParams{
+		Client: cli,
+		Scheme: scheme,
+		Log:    logr.Discard(),
+		Config: config.New(),
+	})
+
+	ownedObjects, err := r.findTargetAllocatorOwnedObjects(context.Background(), r.getParams(instance))
+	require.NoError(t, err)
+
+	var names []string
+	for _, object := range ownedObjects {
+		names = append(names, object.GetName())
+	}
+	assert.ElementsMatch(t, []string{"test-pdb", "test-role", "test-rolebinding"}, names)
+}
@@ -31,7 +31,8 @@ const (
 	NAMESPACE_FILE_PATH = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
 )
 
-func getOperatorNamespace() (string, error) {
+// OperatorNamespace returns the namespace the operator runs in.
+func OperatorNamespace() (string, error) {
 	namespace := os.Getenv(NAMESPACE_ENV_VAR)
 	if namespace != "" {
 		return namespace, nil
@@ -55,7 +56,7 @@ func getOperatorServiceAccount() (string, error) {
 // CheckRBACPermissions checks if the operator has the needed permissions to create RBAC resources automatically.
 // If the RBAC is there, no errors nor warnings are returned.
 func CheckRBACPermissions(ctx context.Context, reviewer *rbac.Reviewer) (admission.Warnings, error) {
-	namespace, err := getOperatorNamespace()
+	namespace, err := OperatorNamespace()
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", "not possible to check RBAC rules", err)
 	}
@@ -67,6 +67,8 @@ type Config struct {
 	prometheusCRAvailability    prometheus.Availability
 	labelsFilter                []string
 	annotationsFilter           []string
+
+	instrumentationAdminNamespaces []string
 }
 
 // New constructs a new configuration based on the given options.
@@ -118,6 +120,7 @@ func New(opts ...Option) Config {
 		labelsFilter:                        o.labelsFilter,
 		annotationsFilter:                   o.annotationsFilter,
 		createRBACPermissions:               o.createRBACPermissions,
+		instrumentationAdminNamespaces:      o.instrumentationAdminNamespaces,
 	}
 }
 
@@ -278,3 +281,8 @@ func (c *Config) LabelsFilter() []string {
 func (c *Config) AnnotationsFilter() []string {
 	return c.annotationsFilter
 }
+
+// InstrumentationAdminNamespaces returns the namespaces whose instrumentations may select the pods of other namespaces.
+func (c *Config) InstrumentationAdminNamespaces() []string {
+	return c.instrumentationAdminNamespaces
+}
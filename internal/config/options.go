@@ -58,6 +58,7 @@ type options struct {
 	prometheusCRAvailability            prometheus.Availability
 	labelsFilter                        []string
 	annotationsFilter                   []string
+	instrumentationAdminNamespaces      []string
 }
 
 func WithAutoDetect(a autodetect.AutoDetect) Option {
@@ -221,6 +222,14 @@ func WithAnnotationFilters(annotationFilters []string) Option {
 	}
 }
 
+// WithInstrumentationAdminNamespaces is additive if called multiple times. The instrumentations of these namespaces
+// may select the pods of other namespaces.
+func WithInstrumentationAdminNamespaces(namespaces []string) Option {
+	return func(o *options) {
+		o.instrumentationAdminNamespaces = append(o.instrumentationAdminNamespaces, namespaces...)
+	}
+}
+
 func WithEncodeLevelFormat(s string) zapcore.LevelEncoder {
 	if s == "lowercase" {
 		return zapcore.LowercaseLevelEncoder
@@ -34,12 +34,17 @@ func Build(params manifests.Params) ([]client.Object, error) {
 	switch params.OtelCol.Spec.Mode {
 	case v1beta1.ModeDeployment:
 		manifestFactories = append(manifestFactories, manifests.Factory(Deployment))
+		manifestFactories = append(manifestFactories, manifests.Factory(CanaryDeployment))
 		manifestFactories = append(manifestFactories, manifests.Factory(PodDisruptionBudget))
 	case v1beta1.ModeStatefulSet:
 		manifestFactories = append(manifestFactories, manifests.Factory(StatefulSet))
 		manifestFactories = append(manifestFactories, manifests.Factory(PodDisruptionBudget))
 	case v1beta1.ModeDaemonSet:
 		manifestFactories = append(manifestFactories, manifests.Factory(DaemonSet))
+	case v1beta1.ModeJob:
+		manifestFactories = append(manifestFactories, manifests.Factory(Job))
+	case v1beta1.ModeCronJob:
+		manifestFactories = append(manifestFactories, manifests.Factory(CronJob))
 	case v1beta1.ModeSidecar:
 		params.Log.V(5).Info("not building sidecar...")
 	}
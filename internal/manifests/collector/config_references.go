@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+// configReferencesMountPath is the directory where the files of the configuration references are mounted, in a
+// subdirectory per reference.
+const configReferencesMountPath = "/var/refs"
+
+// replaceConfigReferences replaces the placeholders of the configuration references in the given configuration.
+func replaceConfigReferences(cfg string, refs []v1beta1.ConfigReference) string {
+	if len(refs) == 0 {
+		return cfg
+	}
+	oldNew := make([]string, 0, 2*len(refs))
+	for _, ref := range refs {
+		value := fmt.Sprintf("${env:%s}", ref.EnvVarName())
+		if ref.MountAs == v1beta1.ConfigReferenceMountFile {
+			value = configReferencePath(ref)
+		}
+		oldNew = append(oldNew, ref.Placeholder(), value)
+	}
+	return strings.NewReplacer(oldNew...).Replace(cfg)
+}
+
+func configReferencePath(ref v1beta1.ConfigReference) string {
+	return path.Join(configReferencesMountPath, ref.Name, ref.Key())
+}
+
+// configReferenceEnvVars builds the environment variables of the references mounted as such.
+func configReferenceEnvVars(refs []v1beta1.ConfigReference) []corev1.EnvVar {
+	var envVars []corev1.EnvVar
+	for _, ref := range refs {
+		if ref.MountAs == v1beta1.ConfigReferenceMountFile {
+			continue
+		}
+		envVars = append(envVars, corev1.EnvVar{
+			Name: ref.EnvVarName(),
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef:    ref.SecretKeyRef,
+				ConfigMapKeyRef: ref.ConfigMapKeyRef,
+			},
+		})
+	}
+	return envVars
+}
+
+// configReferenceVolumeMounts builds the volume mounts of the references mounted as files.
+func configReferenceVolumeMounts(refs []v1beta1.ConfigReference) []corev1.VolumeMount {
+	var volumeMounts []corev1.VolumeMount
+	for _, ref := range refs {
+		if ref.MountAs != v1beta1.ConfigReferenceMountFile {
+			continue
+		}
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      naming.ConfigReferenceVolume(ref.Name),
+			MountPath: path.Join(configReferencesMountPath, ref.Name),
+			ReadOnly:  true,
+		})
+	}
+	return volumeMounts
+}
+
+// ConfigReferenceVolumes builds the volumes of the references mounted as files, holding the selected key only.
+func ConfigReferenceVolumes(refs []v1beta1.ConfigReference) []corev1.Volume {
+	var volumes []corev1.Volume
+	for _, ref := range refs {
+		if ref.MountAs != v1beta1.ConfigReferenceMountFile {
+			continue
+		}
+		items := []corev1.KeyToPath{{Key: ref.Key(), Path: ref.Key()}}
+		volume := corev1.Volume{Name: naming.ConfigReferenceVolume(ref.Name)}
+		switch {
+		case ref.SecretKeyRef != nil:
+			volume.Secret = &corev1.SecretVolumeSource{
+				SecretName: ref.SecretKeyRef.Name,
+				Items:      items,
+				Optional:   ref.SecretKeyRef.Optional,
+			}
+		case ref.ConfigMapKeyRef != nil:
+			volume.ConfigMap = &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: ref.ConfigMapKeyRef.LocalObjectReference,
+				Items:                items,
+				Optional:             ref.ConfigMapKeyRef.Optional,
+			}
+		default:
+			continue
+		}
+		volumes = append(volumes, volume)
+	}
+	return volumes
+}
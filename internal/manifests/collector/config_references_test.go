@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	. "github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+)
+
+func TestConfigReferences(t *testing.T) {
+	// prepare
+	cfg := v1beta1.Config{}
+	require.NoError(t, yaml.Unmarshal([]byte(`receivers:
+  otlp:
+    protocols:
+      grpc: {}
+exporters:
+  otlp:
+    endpoint: backend:4317
+    headers:
+      x-api-key: ${ref:api-key}
+    tls:
+      ca_file: ${ref:ca}
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [otlp]
+`), &cfg))
+	params := manifests.Params{
+		Config: config.New(),
+		OtelCol: v1beta1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-instance",
+				Namespace: "my-namespace",
+			},
+			Spec: v1beta1.OpenTelemetryCollectorSpec{
+				Mode:   v1beta1.ModeDeployment,
+				Config: cfg,
+				ConfigReferences: []v1beta1.ConfigReference{
+					{
+						Name: "api-key",
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "backend"},
+							Key:                  "api-key",
+						},
+					},
+					{
+						Name: "ca",
+						ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "backend-ca"},
+							Key:                  "ca.crt",
+						},
+						MountAs: v1beta1.ConfigReferenceMountFile,
+					},
+				},
+			},
+		},
+		ConfigReferencesHash: "0123456789abcdef",
+	}
+
+	// test
+	cm, err := ConfigMap(params)
+	require.NoError(t, err)
+	d, err := Deployment(params)
+	require.NoError(t, err)
+
+	// verify
+	assert.Contains(t, cm.Data["collector.yaml"], "x-api-key: ${env:OTEL_REF_API_KEY}")
+	assert.Contains(t, cm.Data["collector.yaml"], "ca_file: /var/refs/ca/ca.crt")
+	assert.NotContains(t, cm.Data["collector.yaml"], "${ref:")
+
+	assert.Equal(t, "0123456789abcdef", d.Spec.Template.Annotations[manifestutils.ConfigReferencesHashAnnotation])
+
+	container := d.Spec.Template.Spec.Containers[0]
+	assert.Contains(t, container.Env, corev1.EnvVar{
+		Name: "OTEL_REF_API_KEY",
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: params.OtelCol.Spec.ConfigReferences[0].SecretKeyRef,
+		},
+	})
+	assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{
+		Name:      "ref-ca",
+		MountPath: "/var/refs/ca",
+		ReadOnly:  true,
+	})
+	assert.Contains(t, d.Spec.Template.Spec.Volumes, corev1.Volume{
+		Name: "ref-ca",
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "backend-ca"},
+				Items:                []corev1.KeyToPath{{Key: "ca.crt", Path: "ca.crt"}},
+			},
+		},
+	})
+}
+
+func TestConfigReferencesHashNotSet(t *testing.T) {
+	// prepare
+	params := manifests.Params{
+		Config: config.New(),
+		OtelCol: v1beta1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "my-instance",
+			},
+		},
+	}
+
+	// test
+	d, err := Deployment(params)
+	require.NoError(t, err)
+
+	// verify
+	assert.NotContains(t, d.Spec.Template.Annotations, manifestutils.ConfigReferencesHashAnnotation)
+}
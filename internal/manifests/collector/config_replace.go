@@ -46,6 +46,7 @@ func ReplaceConfig(instance v1beta1.OpenTelemetryCollector) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	cfgStr = replaceConfigReferences(cfgStr, instance.Spec.ConfigReferences)
 	// Check if TargetAllocator is enabled, if not, return the original config
 	if !instance.Spec.TargetAllocator.Enabled {
 		return cfgStr, nil
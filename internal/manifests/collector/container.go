@@ -128,6 +128,9 @@ func Container(cfg config.Config, logger logr.Logger, otelcol v1beta1.OpenTeleme
 		}
 	}
 
+	envVars = append(envVars, configReferenceEnvVars(otelcol.Spec.ConfigReferences)...)
+	volumeMounts = append(volumeMounts, configReferenceVolumeMounts(otelcol.Spec.ConfigReferences)...)
+
 	if otelcol.Spec.TargetAllocator.Enabled {
 		// We need to add a SHARD here so the collector is able to keep targets after the hashmod operation which is
 		// added by default by the Prometheus operator's config generator.
@@ -201,6 +204,8 @@ func Container(cfg config.Config, logger logr.Logger, otelcol v1beta1.OpenTeleme
 		LivenessProbe:   livenessProbe,
 		ReadinessProbe:  readinessProbe,
 		Lifecycle:       otelcol.Spec.Lifecycle,
+		// the collector logs why it rejected its configuration, which then shows up in the pod status
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 	}
 }
 
@@ -38,8 +38,9 @@ func DaemonSet(params manifests.Params) (*appsv1.DaemonSet, error) {
 	if err != nil {
 		return nil, err
 	}
+	annotateContentHashes(params, podAnnotations)
 
-	return &appsv1.DaemonSet{
+	ds := &appsv1.DaemonSet{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        naming.Collector(params.OtelCol.Name),
 			Namespace:   params.OtelCol.Namespace,
@@ -72,5 +73,13 @@ func DaemonSet(params manifests.Params) (*appsv1.DaemonSet, error) {
 			},
 			UpdateStrategy: params.OtelCol.Spec.DaemonSetUpdateStrategy,
 		},
-	}, nil
+	}
+	stableHash, _, err := rolloutConfig(params.OtelCol)
+	if err != nil {
+		return nil, err
+	}
+	if stableHash != "" {
+		pinConfig(params.OtelCol, ds.Annotations, &ds.Spec.Template, stableHash)
+	}
+	return ds, nil
 }
@@ -26,6 +26,41 @@ import (
 
 // Deployment builds the deployment for the given instance.
 func Deployment(params manifests.Params) (*appsv1.Deployment, error) {
+	dep, err := deployment(params)
+	if err != nil {
+		return nil, err
+	}
+	stableHash, _, err := rolloutConfig(params.OtelCol)
+	if err != nil {
+		return nil, err
+	}
+	if stableHash != "" {
+		pinConfig(params.OtelCol, dep.Annotations, &dep.Spec.Template, stableHash)
+	}
+	return dep, nil
+}
+
+// CanaryDeployment builds the deployment running the canary replicas of the given instance, while a new
+// configuration is baked.
+func CanaryDeployment(params manifests.Params) (*appsv1.Deployment, error) {
+	_, canary, err := rolloutConfig(params.OtelCol)
+	if err != nil || !canary {
+		return nil, err
+	}
+	dep, err := deployment(params)
+	if err != nil {
+		return nil, err
+	}
+	replicas := CanaryReplicas(params.OtelCol)
+	dep.Name = naming.CollectorCanary(params.OtelCol.Name)
+	dep.Labels[CanaryLabel] = "true"
+	dep.Spec.Replicas = &replicas
+	dep.Spec.Selector.MatchLabels[CanaryLabel] = "true"
+	dep.Spec.Template.Labels[CanaryLabel] = "true"
+	return dep, nil
+}
+
+func deployment(params manifests.Params) (*appsv1.Deployment, error) {
 	name := naming.Collector(params.OtelCol.Name)
 	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, params.Config.LabelsFilter())
 	annotations, err := manifestutils.Annotations(params.OtelCol, params.Config.AnnotationsFilter())
@@ -37,6 +72,7 @@ func Deployment(params manifests.Params) (*appsv1.Deployment, error) {
 	if err != nil {
 		return nil, err
 	}
+	annotateContentHashes(params, podAnnotations)
 
 	return &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
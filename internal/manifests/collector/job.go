@@ -0,0 +1,156 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+// Job builds the job running the collector in the job mode. The spec of a job is immutable, so the job is named after
+// the hash of the configuration and of spec.job, and a change of either runs a new job. Only these inputs of the user
+// are hashed, so that a change of the defaults of the operator, e.g. the collector image, doesn't run a completed job
+// again.
+func Job(params manifests.Params) (*batchv1.Job, error) {
+	spec, err := jobSpec(params)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := jobHash(params.OtelCol)
+	if err != nil {
+		return nil, err
+	}
+
+	name := naming.Collector(params.OtelCol.Name)
+	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, params.Config.LabelsFilter())
+	annotations, err := manifestutils.Annotations(params.OtelCol, params.Config.AnnotationsFilter())
+	if err != nil {
+		return nil, err
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        naming.CollectorJob(params.OtelCol.Name, hash),
+			Namespace:   params.OtelCol.Namespace,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: spec,
+	}, nil
+}
+
+// CronJob builds the cronjob running the collector in the cronjob mode.
+func CronJob(params manifests.Params) (*batchv1.CronJob, error) {
+	spec, err := jobSpec(params)
+	if err != nil {
+		return nil, err
+	}
+
+	name := naming.Collector(params.OtelCol.Name)
+	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, params.Config.LabelsFilter())
+	annotations, err := manifestutils.Annotations(params.OtelCol, params.Config.AnnotationsFilter())
+	if err != nil {
+		return nil, err
+	}
+
+	cronJob := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        naming.CollectorCronJob(params.OtelCol.Name),
+			Namespace:   params.OtelCol.Namespace,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: batchv1.CronJobSpec{
+			// the jobs don't carry the labels of the collector, which would have them pruned as the collector's own
+			JobTemplate: batchv1.JobTemplateSpec{Spec: spec},
+		},
+	}
+	if schedule := params.OtelCol.Spec.CronJob; schedule != nil {
+		cronJob.Spec.Schedule = schedule.Schedule
+		cronJob.Spec.TimeZone = schedule.TimeZone
+		cronJob.Spec.ConcurrencyPolicy = schedule.ConcurrencyPolicy
+		cronJob.Spec.StartingDeadlineSeconds = schedule.StartingDeadlineSeconds
+		cronJob.Spec.Suspend = schedule.Suspend
+		cronJob.Spec.SuccessfulJobsHistoryLimit = schedule.SuccessfulJobsHistoryLimit
+		cronJob.Spec.FailedJobsHistoryLimit = schedule.FailedJobsHistoryLimit
+	}
+	return cronJob, nil
+}
+
+// jobHash returns the hash of the inputs of the job, which is every field of the spec. A job is never updated, a new one
+// is created when the spec changes, but not when only the defaults of the operator, like the collector image, change.
+func jobHash(otelcol v1beta1.OpenTelemetryCollector) (string, error) {
+	b, err := json.Marshal(otelcol.Spec)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(b)), nil
+}
+
+func jobSpec(params manifests.Params) (batchv1.JobSpec, error) {
+	name := naming.Collector(params.OtelCol.Name)
+	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, params.Config.LabelsFilter())
+	podAnnotations, err := manifestutils.PodAnnotations(params.OtelCol, params.Config.AnnotationsFilter())
+	if err != nil {
+		return batchv1.JobSpec{}, err
+	}
+	annotateContentHashes(params, podAnnotations)
+
+	spec := batchv1.JobSpec{
+		Template: corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels:      labels,
+				Annotations: podAnnotations,
+			},
+			Spec: corev1.PodSpec{
+				ServiceAccountName:            ServiceAccountName(params.OtelCol),
+				InitContainers:                params.OtelCol.Spec.InitContainers,
+				Containers:                    append(params.OtelCol.Spec.AdditionalContainers, Container(params.Config, params.Log, params.OtelCol, true)),
+				Volumes:                       Volumes(params.Config, params.OtelCol),
+				RestartPolicy:                 corev1.RestartPolicyOnFailure,
+				DNSPolicy:                     manifestutils.GetDNSPolicy(params.OtelCol.Spec.HostNetwork),
+				HostNetwork:                   params.OtelCol.Spec.HostNetwork,
+				ShareProcessNamespace:         &params.OtelCol.Spec.ShareProcessNamespace,
+				Tolerations:                   params.OtelCol.Spec.Tolerations,
+				NodeSelector:                  params.OtelCol.Spec.NodeSelector,
+				SecurityContext:               params.OtelCol.Spec.PodSecurityContext,
+				PriorityClassName:             params.OtelCol.Spec.PriorityClassName,
+				Affinity:                      params.OtelCol.Spec.Affinity,
+				TerminationGracePeriodSeconds: params.OtelCol.Spec.TerminationGracePeriodSeconds,
+				TopologySpreadConstraints:     params.OtelCol.Spec.TopologySpreadConstraints,
+			},
+		},
+	}
+	if job := params.OtelCol.Spec.Job; job != nil {
+		spec.Completions = job.Completions
+		spec.Parallelism = job.Parallelism
+		spec.BackoffLimit = job.BackoffLimit
+		spec.ActiveDeadlineSeconds = job.ActiveDeadlineSeconds
+		if job.RestartPolicy != "" {
+			spec.Template.Spec.RestartPolicy = job.RestartPolicy
+		}
+	}
+	return spec, nil
+}
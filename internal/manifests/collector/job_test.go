@@ -0,0 +1,205 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	. "github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+)
+
+func TestJobNewDefault(t *testing.T) {
+	// prepare
+	params := manifests.Params{
+		Config: config.New(),
+		OtelCol: v1beta1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-instance",
+				Namespace: "my-namespace",
+			},
+			Spec: v1beta1.OpenTelemetryCollectorSpec{
+				Mode: v1beta1.ModeJob,
+				OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
+					Tolerations: testTolerationValues,
+				},
+			},
+		},
+		Log: logger,
+	}
+
+	// test
+	j, err := Job(params)
+	require.NoError(t, err)
+
+	// verify
+	assert.Regexp(t, "^my-instance-collector-[0-9a-f]{8}$", j.Name)
+	assert.Equal(t, "my-instance-collector", j.Labels["app.kubernetes.io/name"])
+	assert.Equal(t, "my-instance-collector", j.Spec.Template.Labels["app.kubernetes.io/name"])
+	assert.Equal(t, corev1.RestartPolicyOnFailure, j.Spec.Template.Spec.RestartPolicy)
+	assert.Equal(t, testTolerationValues, j.Spec.Template.Spec.Tolerations)
+	assert.Len(t, j.Spec.Template.Spec.Containers, 1)
+	assert.Nil(t, j.Spec.Completions)
+	assert.Nil(t, j.Spec.ActiveDeadlineSeconds)
+}
+
+func TestJobSettings(t *testing.T) {
+	// prepare
+	completions, backoffLimit, deadline := int32(3), int32(2), int64(600)
+	params := manifests.Params{
+		Config: config.New(),
+		OtelCol: v1beta1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "my-instance",
+			},
+			Spec: v1beta1.OpenTelemetryCollectorSpec{
+				Mode: v1beta1.ModeJob,
+				Job: &v1beta1.JobSpec{
+					Completions:           &completions,
+					BackoffLimit:          &backoffLimit,
+					ActiveDeadlineSeconds: &deadline,
+					RestartPolicy:         corev1.RestartPolicyNever,
+				},
+			},
+		},
+		Log: logger,
+	}
+
+	// test
+	j, err := Job(params)
+	require.NoError(t, err)
+
+	// verify
+	assert.Equal(t, &completions, j.Spec.Completions)
+	assert.Equal(t, &backoffLimit, j.Spec.BackoffLimit)
+	assert.Equal(t, &deadline, j.Spec.ActiveDeadlineSeconds)
+	assert.Equal(t, corev1.RestartPolicyNever, j.Spec.Template.Spec.RestartPolicy)
+}
+
+func TestJobNameChangesWithInputs(t *testing.T) {
+	// prepare
+	params := manifests.Params{
+		Config: config.New(),
+		OtelCol: v1beta1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "my-instance",
+			},
+			Spec: v1beta1.OpenTelemetryCollectorSpec{
+				Mode: v1beta1.ModeJob,
+			},
+		},
+		Log: logger,
+	}
+	first, err := Job(params)
+	require.NoError(t, err)
+
+	// test
+	completions := int32(2)
+	params.OtelCol.Spec.Job = &v1beta1.JobSpec{Completions: &completions}
+	second, err := Job(params)
+	require.NoError(t, err)
+	again, err := Job(params)
+	require.NoError(t, err)
+	params.OtelCol.Spec.Config.Service.Extensions = &[]string{"health_check"}
+	third, err := Job(params)
+	require.NoError(t, err)
+	params.OtelCol.Spec.Image = "collector:v0.2.0"
+	fourth, err := Job(params)
+	require.NoError(t, err)
+	params.OtelCol.Spec.Env = []corev1.EnvVar{{Name: "LOG_LEVEL", Value: "debug"}}
+	fifth, err := Job(params)
+	require.NoError(t, err)
+
+	// verify
+	assert.NotEqual(t, first.Name, second.Name)
+	assert.Equal(t, second.Name, again.Name)
+	assert.NotEqual(t, second.Name, third.Name)
+	assert.NotEqual(t, third.Name, fourth.Name, "changing spec.image should create a new job")
+	assert.NotEqual(t, fourth.Name, fifth.Name, "changing spec.env should create a new job")
+}
+
+func TestJobNameIgnoresOperatorDefaults(t *testing.T) {
+	// prepare
+	params := manifests.Params{
+		Config: config.New(config.WithCollectorImage("collector:v0.1.0")),
+		OtelCol: v1beta1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "my-instance",
+			},
+			Spec: v1beta1.OpenTelemetryCollectorSpec{
+				Mode: v1beta1.ModeJob,
+			},
+		},
+		Log: logger,
+	}
+	first, err := Job(params)
+	require.NoError(t, err)
+
+	// test
+	params.Config = config.New(config.WithCollectorImage("collector:v0.2.0"))
+	second, err := Job(params)
+	require.NoError(t, err)
+
+	// verify
+	assert.Equal(t, first.Name, second.Name)
+	assert.NotEqual(t, first.Spec.Template.Spec.Containers[0].Image, second.Spec.Template.Spec.Containers[0].Image)
+}
+
+func TestCronJob(t *testing.T) {
+	// prepare
+	timeZone, historyLimit := "Etc/UTC", int32(5)
+	params := manifests.Params{
+		Config: config.New(),
+		OtelCol: v1beta1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-instance",
+				Namespace: "my-namespace",
+			},
+			Spec: v1beta1.OpenTelemetryCollectorSpec{
+				Mode: v1beta1.ModeCronJob,
+				CronJob: &v1beta1.CronJobSpec{
+					Schedule:                   "*/15 * * * *",
+					TimeZone:                   &timeZone,
+					ConcurrencyPolicy:          batchv1.ReplaceConcurrent,
+					SuccessfulJobsHistoryLimit: &historyLimit,
+				},
+			},
+		},
+		Log: logger,
+	}
+
+	// test
+	c, err := CronJob(params)
+	require.NoError(t, err)
+
+	// verify
+	assert.Equal(t, "my-instance-collector", c.Name)
+	assert.Equal(t, "my-instance-collector", c.Labels["app.kubernetes.io/name"])
+	assert.Equal(t, "*/15 * * * *", c.Spec.Schedule)
+	assert.Equal(t, &timeZone, c.Spec.TimeZone)
+	assert.Equal(t, batchv1.ReplaceConcurrent, c.Spec.ConcurrencyPolicy)
+	assert.Equal(t, &historyLimit, c.Spec.SuccessfulJobsHistoryLimit)
+	assert.Empty(t, c.Spec.JobTemplate.Labels)
+	assert.Equal(t, "my-instance-collector", c.Spec.JobTemplate.Spec.Template.Labels["app.kubernetes.io/name"])
+	assert.Equal(t, corev1.RestartPolicyOnFailure, c.Spec.JobTemplate.Spec.Template.Spec.RestartPolicy)
+}
@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"sort"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+)
+
+// RestartOnMountedChangesEnabled returns whether the pods of the collector are replaced when the content of a
+// ConfigMap or a Secret they use changes.
+func RestartOnMountedChangesEnabled(otelcol v1beta1.OpenTelemetryCollector) bool {
+	if otelcol.Spec.Rollout == nil || !otelcol.Spec.Rollout.RestartOnMountedChanges {
+		return false
+	}
+	return otelcol.Spec.Mode == v1beta1.ModeDeployment || otelcol.Spec.Mode == v1beta1.ModeStatefulSet ||
+		otelcol.Spec.Mode == v1beta1.ModeDaemonSet
+}
+
+// MountedObjects returns the sorted names of the ConfigMaps and the Secrets the collector container uses, through
+// spec.configmaps, volumes, envFrom and the valueFrom of env.
+func MountedObjects(otelcol v1beta1.OpenTelemetryCollector) (configMaps []string, secrets []string) {
+	cms, ss := map[string]bool{}, map[string]bool{}
+	for _, cm := range otelcol.Spec.ConfigMaps {
+		cms[cm.Name] = true
+	}
+	for _, volume := range otelcol.Spec.Volumes {
+		switch {
+		case volume.ConfigMap != nil:
+			cms[volume.ConfigMap.Name] = true
+		case volume.Secret != nil:
+			ss[volume.Secret.SecretName] = true
+		case volume.Projected != nil:
+			for _, source := range volume.Projected.Sources {
+				if source.ConfigMap != nil {
+					cms[source.ConfigMap.Name] = true
+				}
+				if source.Secret != nil {
+					ss[source.Secret.Name] = true
+				}
+			}
+		}
+	}
+	for _, envFrom := range otelcol.Spec.EnvFrom {
+		if envFrom.ConfigMapRef != nil {
+			cms[envFrom.ConfigMapRef.Name] = true
+		}
+		if envFrom.SecretRef != nil {
+			ss[envFrom.SecretRef.Name] = true
+		}
+	}
+	for _, env := range otelcol.Spec.Env {
+		if env.ValueFrom == nil {
+			continue
+		}
+		if env.ValueFrom.ConfigMapKeyRef != nil {
+			cms[env.ValueFrom.ConfigMapKeyRef.Name] = true
+		}
+		if env.ValueFrom.SecretKeyRef != nil {
+			ss[env.ValueFrom.SecretKeyRef.Name] = true
+		}
+	}
+	return sortedNames(cms), sortedNames(ss)
+}
+
+func sortedNames(set map[string]bool) []string {
+	var names []string
+	for name := range set {
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// annotateContentHashes records the hashes of the referenced values and of the mounted objects on the pod template,
+// so that the pods are replaced when they change.
+func annotateContentHashes(params manifests.Params, podAnnotations map[string]string) {
+	if params.ConfigReferencesHash != "" {
+		podAnnotations[manifestutils.ConfigReferencesHashAnnotation] = params.ConfigReferencesHash
+	}
+	if params.MountsHash != "" {
+		podAnnotations[manifestutils.MountsHashAnnotation] = params.MountsHash
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	. "github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+)
+
+func TestMountedObjects(t *testing.T) {
+	// prepare
+	otelcol := v1beta1.OpenTelemetryCollector{
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			ConfigMaps: []v1beta1.ConfigMapsSpec{{Name: "extra", MountPath: "/"}},
+			OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
+				Volumes: []corev1.Volume{
+					{
+						Name: "certs",
+						VolumeSource: corev1.VolumeSource{
+							Secret: &corev1.SecretVolumeSource{SecretName: "tls"},
+						},
+					},
+					{
+						Name: "projected",
+						VolumeSource: corev1.VolumeSource{
+							Projected: &corev1.ProjectedVolumeSource{
+								Sources: []corev1.VolumeProjection{
+									{ConfigMap: &corev1.ConfigMapProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "ca"}}},
+									{Secret: &corev1.SecretProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "tls"}}},
+								},
+							},
+						},
+					},
+					{
+						Name:         "scratch",
+						VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+					},
+				},
+				EnvFrom: []corev1.EnvFromSource{
+					{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "backend"}}},
+				},
+				Env: []corev1.EnvVar{
+					{Name: "PLAIN", Value: "value"},
+					{
+						Name: "FROM_CONFIGMAP",
+						ValueFrom: &corev1.EnvVarSource{
+							ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "settings"},
+								Key:                  "level",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// test
+	configMaps, secrets := MountedObjects(otelcol)
+
+	// verify
+	assert.Equal(t, []string{"ca", "extra", "settings"}, configMaps)
+	assert.Equal(t, []string{"backend", "tls"}, secrets)
+}
+
+func TestRestartOnMountedChangesEnabled(t *testing.T) {
+	for _, tt := range []struct {
+		mode     v1beta1.Mode
+		rollout  *v1beta1.RolloutSpec
+		expected bool
+	}{
+		{mode: v1beta1.ModeDeployment, rollout: nil, expected: false},
+		{mode: v1beta1.ModeDeployment, rollout: &v1beta1.RolloutSpec{}, expected: false},
+		{mode: v1beta1.ModeDeployment, rollout: &v1beta1.RolloutSpec{RestartOnMountedChanges: true}, expected: true},
+		{mode: v1beta1.ModeDaemonSet, rollout: &v1beta1.RolloutSpec{RestartOnMountedChanges: true}, expected: true},
+		{mode: v1beta1.ModeStatefulSet, rollout: &v1beta1.RolloutSpec{RestartOnMountedChanges: true}, expected: true},
+		{mode: v1beta1.ModeSidecar, rollout: &v1beta1.RolloutSpec{RestartOnMountedChanges: true}, expected: false},
+	} {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			otelcol := v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode:    tt.mode,
+					Rollout: tt.rollout,
+				},
+			}
+			assert.Equal(t, tt.expected, RestartOnMountedChangesEnabled(otelcol))
+		})
+	}
+}
+
+func TestMountsHashAnnotation(t *testing.T) {
+	// prepare
+	params := manifests.Params{
+		Config: config.New(),
+		OtelCol: v1beta1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "my-instance",
+			},
+			Spec: v1beta1.OpenTelemetryCollectorSpec{
+				Mode: v1beta1.ModeDaemonSet,
+			},
+		},
+		MountsHash: "0123456789abcdef",
+	}
+
+	// test
+	ds, err := DaemonSet(params)
+	require.NoError(t, err)
+
+	// verify
+	assert.Equal(t, "0123456789abcdef", ds.Spec.Template.Annotations[manifestutils.MountsHashAnnotation])
+	assert.NotContains(t, ds.Annotations, manifestutils.MountsHashAnnotation)
+}
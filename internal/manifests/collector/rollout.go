@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+// CanaryLabel is set on the canary deployment of a collector and on its pods.
+const CanaryLabel = "opentelemetry.io/canary"
+
+// CanaryEnabled returns whether configuration changes of the collector are rolled out to canary replicas first.
+func CanaryEnabled(otelcol v1beta1.OpenTelemetryCollector) bool {
+	if otelcol.Spec.Rollout == nil || otelcol.Spec.Rollout.Strategy != v1beta1.RolloutStrategyCanary {
+		return false
+	}
+	return otelcol.Spec.Mode == v1beta1.ModeDeployment || otelcol.Spec.Mode == v1beta1.ModeStatefulSet
+}
+
+// AutoRollbackEnabled returns whether the collector is rolled back to its last known-good configuration when a new one
+// leaves pods unhealthy.
+func AutoRollbackEnabled(otelcol v1beta1.OpenTelemetryCollector) bool {
+	if otelcol.Spec.Rollout == nil || !otelcol.Spec.Rollout.AutoRollback.Enabled || CanaryEnabled(otelcol) {
+		return false
+	}
+	return otelcol.Spec.Mode == v1beta1.ModeDeployment || otelcol.Spec.Mode == v1beta1.ModeStatefulSet ||
+		otelcol.Spec.Mode == v1beta1.ModeDaemonSet
+}
+
+// RolloutTracked returns whether the rollout of the collector's configuration is recorded in its status.
+func RolloutTracked(otelcol v1beta1.OpenTelemetryCollector) bool {
+	return CanaryEnabled(otelcol) || AutoRollbackEnabled(otelcol)
+}
+
+// CanaryReplicas returns the number of replicas running a new configuration while it's baked.
+func CanaryReplicas(otelcol v1beta1.OpenTelemetryCollector) int32 {
+	if otelcol.Spec.Rollout == nil {
+		return 1
+	}
+	return max(1, otelcol.Spec.Rollout.Canary.Replicas)
+}
+
+// rolloutConfig returns the hash of the configuration the non-canary replicas have to keep running, which is empty
+// when they run the configuration of the spec, and whether canary replicas run the configuration of the spec.
+func rolloutConfig(otelcol v1beta1.OpenTelemetryCollector) (string, bool, error) {
+	rollout := otelcol.Status.Rollout
+	if !RolloutTracked(otelcol) || rollout == nil || rollout.StableConfigHash == "" {
+		return "", false, nil
+	}
+	hash, err := manifestutils.GetConfigMapSHA(otelcol.Spec.Config)
+	if err != nil {
+		return "", false, err
+	}
+	switch {
+	case hash == rollout.StableConfigHash:
+		return "", false, nil
+	case rollout.Phase == v1beta1.RolloutPhaseRolledBack && hash == rollout.CanaryConfigHash:
+		return rollout.StableConfigHash, false, nil
+	case CanaryEnabled(otelcol):
+		return rollout.StableConfigHash, true, nil
+	default:
+		// without canary replicas, a new configuration goes to every replica right away
+		return "", false, nil
+	}
+}
+
+// pinConfig makes the given pod template run the configuration with the given hash, rather than the one of the spec.
+func pinConfig(otelcol v1beta1.OpenTelemetryCollector, annotations map[string]string, template *corev1.PodTemplateSpec, hash string) {
+	annotations[manifestutils.ConfigHashAnnotation] = hash
+	template.Annotations[manifestutils.ConfigHashAnnotation] = hash
+	for _, volume := range template.Spec.Volumes {
+		if volume.Name == naming.ConfigMapVolume() && volume.ConfigMap != nil {
+			volume.ConfigMap.Name = naming.ConfigMap(otelcol.Name, hash)
+		}
+	}
+}
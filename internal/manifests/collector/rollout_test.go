@@ -0,0 +1,188 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	. "github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+)
+
+// stableHash stands for the hash of the configuration running before the spec changed.
+var stableHash = strings.Repeat("a", 64)
+
+func canaryCollector(t *testing.T, mode v1beta1.Mode, phase v1beta1.RolloutPhase, canaryHash string) (v1beta1.OpenTelemetryCollector, string) {
+	replicas := int32(4)
+	otelcol := v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instance",
+			Namespace: "my-namespace",
+		},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode: mode,
+			OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
+				Replicas: &replicas,
+			},
+			Rollout: &v1beta1.RolloutSpec{
+				Strategy: v1beta1.RolloutStrategyCanary,
+				Canary: v1beta1.CanaryRolloutSpec{
+					Replicas: 1,
+				},
+			},
+		},
+		Status: v1beta1.OpenTelemetryCollectorStatus{
+			Rollout: &v1beta1.RolloutStatus{
+				Phase:            phase,
+				StableConfigHash: stableHash,
+				CanaryConfigHash: canaryHash,
+			},
+		},
+	}
+	hash, err := manifestutils.GetConfigMapSHA(otelcol.Spec.Config)
+	require.NoError(t, err)
+	if canaryHash == "spec" {
+		otelcol.Status.Rollout.CanaryConfigHash = hash
+	}
+	return otelcol, hash
+}
+
+func TestDeploymentCanary(t *testing.T) {
+	otelcol, hash := canaryCollector(t, v1beta1.ModeDeployment, v1beta1.RolloutPhaseProgressing, "spec")
+	params := manifests.Params{
+		OtelCol: otelcol,
+		Config:  config.New(),
+		Log:     logger,
+	}
+
+	d, err := Deployment(params)
+	require.NoError(t, err)
+	assert.Equal(t, stableHash, d.Spec.Template.Annotations[manifestutils.ConfigHashAnnotation])
+	assert.Equal(t, "my-instance-collector-aaaaaaaa", d.Spec.Template.Spec.Volumes[0].ConfigMap.Name)
+
+	canary, err := CanaryDeployment(params)
+	require.NoError(t, err)
+	require.NotNil(t, canary)
+	assert.Equal(t, "my-instance-collector-canary", canary.Name)
+	assert.Equal(t, int32(1), *canary.Spec.Replicas)
+	assert.Equal(t, "true", canary.Spec.Selector.MatchLabels[CanaryLabel])
+	assert.Equal(t, "true", canary.Spec.Template.Labels[CanaryLabel])
+	assert.Equal(t, hash, canary.Spec.Template.Annotations[manifestutils.ConfigHashAnnotation])
+	assert.Equal(t, "my-instance-collector-"+hash[:8], canary.Spec.Template.Spec.Volumes[0].ConfigMap.Name)
+}
+
+func TestDeploymentCanaryRolledBack(t *testing.T) {
+	otelcol, _ := canaryCollector(t, v1beta1.ModeDeployment, v1beta1.RolloutPhaseRolledBack, "spec")
+	params := manifests.Params{
+		OtelCol: otelcol,
+		Config:  config.New(),
+		Log:     logger,
+	}
+
+	d, err := Deployment(params)
+	require.NoError(t, err)
+	assert.Equal(t, stableHash, d.Spec.Template.Annotations[manifestutils.ConfigHashAnnotation])
+
+	canary, err := CanaryDeployment(params)
+	require.NoError(t, err)
+	assert.Nil(t, canary)
+}
+
+func TestDeploymentCanaryPromoted(t *testing.T) {
+	otelcol, hash := canaryCollector(t, v1beta1.ModeDeployment, v1beta1.RolloutPhaseStable, "")
+	otelcol.Status.Rollout.StableConfigHash = hash
+	params := manifests.Params{
+		OtelCol: otelcol,
+		Config:  config.New(),
+		Log:     logger,
+	}
+
+	d, err := Deployment(params)
+	require.NoError(t, err)
+	assert.Equal(t, hash, d.Spec.Template.Annotations[manifestutils.ConfigHashAnnotation])
+
+	canary, err := CanaryDeployment(params)
+	require.NoError(t, err)
+	assert.Nil(t, canary)
+}
+
+func TestStatefulSetCanary(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		phase     v1beta1.RolloutPhase
+		partition int32
+		pinned    bool
+	}{
+		{name: "progressing", phase: v1beta1.RolloutPhaseProgressing, partition: 3},
+		{name: "rolled back", phase: v1beta1.RolloutPhaseRolledBack, pinned: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			otelcol, hash := canaryCollector(t, v1beta1.ModeStatefulSet, tc.phase, "spec")
+			params := manifests.Params{
+				OtelCol: otelcol,
+				Config:  config.New(),
+				Log:     logger,
+			}
+
+			ss, err := StatefulSet(params)
+			require.NoError(t, err)
+			assert.Equal(t, tc.partition, *ss.Spec.UpdateStrategy.RollingUpdate.Partition)
+			expectedHash := hash
+			if tc.pinned {
+				expectedHash = stableHash
+			}
+			assert.Equal(t, expectedHash, ss.Spec.Template.Annotations[manifestutils.ConfigHashAnnotation])
+		})
+	}
+}
+
+func TestDaemonSetAutoRollback(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		phase  v1beta1.RolloutPhase
+		pinned bool
+	}{
+		{name: "progressing", phase: v1beta1.RolloutPhaseProgressing},
+		{name: "rolled back", phase: v1beta1.RolloutPhaseRolledBack, pinned: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			otelcol, hash := canaryCollector(t, v1beta1.ModeDaemonSet, tc.phase, "spec")
+			otelcol.Spec.Rollout = &v1beta1.RolloutSpec{
+				AutoRollback: v1beta1.AutoRollbackSpec{Enabled: true},
+			}
+			params := manifests.Params{
+				OtelCol: otelcol,
+				Config:  config.New(),
+				Log:     logger,
+			}
+
+			ds, err := DaemonSet(params)
+			require.NoError(t, err)
+			expectedHash := hash
+			if tc.pinned {
+				expectedHash = stableHash
+			}
+			assert.Equal(t, expectedHash, ds.Spec.Template.Annotations[manifestutils.ConfigHashAnnotation])
+		})
+	}
+}
@@ -26,6 +26,11 @@ import (
 
 // StatefulSet builds the statefulset for the given instance.
 func StatefulSet(params manifests.Params) (*appsv1.StatefulSet, error) {
+	stableHash, canary, err := rolloutConfig(params.OtelCol)
+	if err != nil {
+		return nil, err
+	}
+
 	name := naming.Collector(params.OtelCol.Name)
 	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, params.Config.LabelsFilter())
 
@@ -38,8 +43,19 @@ func StatefulSet(params manifests.Params) (*appsv1.StatefulSet, error) {
 	if err != nil {
 		return nil, err
 	}
+	annotateContentHashes(params, podAnnotations)
+
+	// while a new configuration is baked, only the highest ordinals run it
+	var partition int32
+	if canary {
+		replicas := int32(1)
+		if params.OtelCol.Spec.Replicas != nil {
+			replicas = *params.OtelCol.Spec.Replicas
+		}
+		partition = max(0, replicas-CanaryReplicas(params.OtelCol))
+	}
 
-	return &appsv1.StatefulSet{
+	ss := &appsv1.StatefulSet{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        name,
 			Namespace:   params.OtelCol.Namespace,
@@ -72,9 +88,19 @@ func StatefulSet(params manifests.Params) (*appsv1.StatefulSet, error) {
 					TopologySpreadConstraints: params.OtelCol.Spec.TopologySpreadConstraints,
 				},
 			},
-			Replicas:             params.OtelCol.Spec.Replicas,
-			PodManagementPolicy:  "Parallel",
+			Replicas:            params.OtelCol.Spec.Replicas,
+			PodManagementPolicy: "Parallel",
+			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+				Type: appsv1.RollingUpdateStatefulSetStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{
+					Partition: &partition,
+				},
+			},
 			VolumeClaimTemplates: VolumeClaimTemplates(params.OtelCol),
 		},
-	}, nil
+	}
+	if stableHash != "" && !canary {
+		pinConfig(params.OtelCol, ss.Annotations, &ss.Spec.Template, stableHash)
+	}
+	return ss, nil
 }
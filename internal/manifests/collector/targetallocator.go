@@ -20,6 +20,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/targetallocator/adapters"
 )
 
@@ -63,7 +64,11 @@ func TargetAllocator(params manifests.Params) (*v1alpha1.TargetAllocator, error)
 				PodAnnotations:            params.OtelCol.Spec.PodAnnotations,
 				PodDisruptionBudget:       taSpec.PodDisruptionBudget,
 			},
+			CollectorSelector: metav1.LabelSelector{
+				MatchLabels: manifestutils.SelectorLabels(params.OtelCol.ObjectMeta, ComponentOpenTelemetryCollector),
+			},
 			AllocationStrategy: taSpec.AllocationStrategy,
+			TopologyKey:        taSpec.TopologyKey,
 			FilterStrategy:     taSpec.FilterStrategy,
 			ScrapeConfigs:      scrapeConfigs,
 			PrometheusCR:       taSpec.PrometheusCR,
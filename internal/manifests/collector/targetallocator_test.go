@@ -42,6 +42,14 @@ func TestTargetAllocator(t *testing.T) {
 			"label_key": "label_value",
 		},
 	}
+	collectorSelector := metav1.LabelSelector{
+		MatchLabels: map[string]string{
+			"app.kubernetes.io/component":  "opentelemetry-collector",
+			"app.kubernetes.io/instance":   "namespace.name",
+			"app.kubernetes.io/managed-by": "opentelemetry-operator",
+			"app.kubernetes.io/part-of":    "opentelemetry",
+		},
+	}
 	replicas := int32(2)
 	runAsNonRoot := true
 	privileged := true
@@ -90,7 +98,8 @@ func TestTargetAllocator(t *testing.T) {
 			want: &v1alpha1.TargetAllocator{
 				ObjectMeta: objectMetadata,
 				Spec: v1alpha1.TargetAllocatorSpec{
-					ScrapeConfigs: []v1beta1.AnyConfig{},
+					CollectorSelector: collectorSelector,
+					ScrapeConfigs:     []v1beta1.AnyConfig{},
 				},
 			},
 		},
@@ -113,6 +122,7 @@ func TestTargetAllocator(t *testing.T) {
 							},
 						},
 						AllocationStrategy: v1beta1.TargetAllocatorAllocationStrategyConsistentHashing,
+						TopologyKey:        "topology.kubernetes.io/zone",
 						FilterStrategy:     "relabel-config",
 						ServiceAccount:     "serviceAccountName",
 						Image:              "custom_image",
@@ -278,7 +288,9 @@ func TestTargetAllocator(t *testing.T) {
 							},
 						},
 					},
+					CollectorSelector:  collectorSelector,
 					AllocationStrategy: v1beta1.TargetAllocatorAllocationStrategyConsistentHashing,
+					TopologyKey:        "topology.kubernetes.io/zone",
 					FilterStrategy:     v1beta1.TargetAllocatorFilterStrategyRelabelConfig,
 					PrometheusCR: v1beta1.TargetAllocatorPrometheusCR{
 						Enabled:        true,
@@ -60,5 +60,7 @@ func Volumes(cfg config.Config, otelcol v1beta1.OpenTelemetryCollector) []corev1
 		}
 	}
 
+	volumes = append(volumes, ConfigReferenceVolumes(otelcol.Spec.ConfigReferences)...)
+
 	return volumes
 }
@@ -22,6 +22,16 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
 )
 
+// ConfigHashAnnotation holds the hash of the collector configuration on the workloads and pods running it.
+const ConfigHashAnnotation = "opentelemetry-operator-config/sha256"
+
+// ConfigReferencesHashAnnotation holds the hash of the values referenced by the collector configuration on its pods.
+const ConfigReferencesHashAnnotation = "opentelemetry-operator-config/references-sha256"
+
+// MountsHashAnnotation holds the hash of the content of the ConfigMaps and the Secrets mounted by the collector on its
+// pods.
+const MountsHashAnnotation = "opentelemetry-operator-config/mounts-sha256"
+
 // Annotations return the annotations for OpenTelemetryCollector pod.
 func Annotations(instance v1beta1.OpenTelemetryCollector, filterAnnotations []string) (map[string]string, error) {
 	// new map every time, so that we don't touch the instance's annotations
@@ -50,7 +60,7 @@ func Annotations(instance v1beta1.OpenTelemetryCollector, filterAnnotations []st
 	}
 
 	// make sure sha256 for configMap is always calculated
-	annotations["opentelemetry-operator-config/sha256"] = hash
+	annotations[ConfigHashAnnotation] = hash
 
 	return annotations, nil
 }
@@ -83,7 +93,7 @@ func PodAnnotations(instance v1beta1.OpenTelemetryCollector, filterAnnotations [
 		return nil, err
 	}
 	// make sure sha256 for configMap is always calculated
-	podAnnotations["opentelemetry-operator-config/sha256"] = hash
+	podAnnotations[ConfigHashAnnotation] = hash
 
 	return podAnnotations, nil
 }
@@ -24,6 +24,7 @@ import (
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	policyV1 "k8s.io/api/policy/v1"
@@ -31,6 +32,8 @@ import (
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 )
 
 var (
@@ -50,11 +53,14 @@ var (
 // - Deployment
 // - DaemonSet
 // - StatefulSet
+// - Job
+// - CronJob
 // - ServiceMonitor
 // - Ingress
 // - HorizontalPodAutoscaler
 // - Route
 // - Secret
+// - TargetAllocator
 // In order for the operator to reconcile other types, they must be added here.
 // The function returned takes no arguments but instead uses the existing and desired inputs here. Existing is expected
 // to be set by the controller-runtime package through a client get call.
@@ -131,6 +137,16 @@ func MutateFuncFor(existing, desired client.Object) controllerutil.MutateFn {
 			wantSts := desired.(*appsv1.StatefulSet)
 			return mutateStatefulSet(sts, wantSts)
 
+		case *batchv1.Job:
+			job := existing.(*batchv1.Job)
+			wantJob := desired.(*batchv1.Job)
+			mutateJob(job, wantJob)
+
+		case *batchv1.CronJob:
+			cronJob := existing.(*batchv1.CronJob)
+			wantCronJob := desired.(*batchv1.CronJob)
+			return mutateCronJob(cronJob, wantCronJob)
+
 		case *monitoringv1.ServiceMonitor:
 			svcMonitor := existing.(*monitoringv1.ServiceMonitor)
 			wantSvcMonitor := desired.(*monitoringv1.ServiceMonitor)
@@ -166,6 +182,11 @@ func MutateFuncFor(existing, desired client.Object) controllerutil.MutateFn {
 			wantPr := desired.(*corev1.Secret)
 			mutateSecret(pr, wantPr)
 
+		case *v1alpha1.TargetAllocator:
+			ta := existing.(*v1alpha1.TargetAllocator)
+			wantTa := desired.(*v1alpha1.TargetAllocator)
+			mutateTargetAllocator(ta, wantTa)
+
 		default:
 			t := reflect.TypeOf(existing).String()
 			return fmt.Errorf("missing mutate implementation for resource type: %s", t)
@@ -188,6 +209,12 @@ func mutateSecret(existing, desired *corev1.Secret) {
 	existing.Data = desired.Data
 }
 
+func mutateTargetAllocator(existing, desired *v1alpha1.TargetAllocator) {
+	existing.Annotations = desired.Annotations
+	existing.Labels = desired.Labels
+	existing.Spec = desired.Spec
+}
+
 func mutateConfigMap(existing, desired *corev1.ConfigMap) {
 	existing.BinaryData = desired.BinaryData
 	existing.Data = desired.Data
@@ -316,6 +343,7 @@ func mutateStatefulSet(existing, desired *appsv1.StatefulSet) error {
 	}
 	existing.Spec.PodManagementPolicy = desired.Spec.PodManagementPolicy
 	existing.Spec.Replicas = desired.Spec.Replicas
+	existing.Spec.UpdateStrategy = desired.Spec.UpdateStrategy
 
 	for i := range existing.Spec.VolumeClaimTemplates {
 		existing.Spec.VolumeClaimTemplates[i].TypeMeta = desired.Spec.VolumeClaimTemplates[i].TypeMeta
@@ -331,6 +359,37 @@ func mutateStatefulSet(existing, desired *appsv1.StatefulSet) error {
 	return nil
 }
 
+func mutateJob(existing, desired *batchv1.Job) {
+	// Job spec is immutable so we set this value only if
+	// a new object is going to be created, a changed spec gets a new job name
+	if existing.CreationTimestamp.IsZero() {
+		existing.Spec = desired.Spec
+	}
+}
+
+func mutateCronJob(existing, desired *batchv1.CronJob) error {
+	existing.Spec.Schedule = desired.Spec.Schedule
+	existing.Spec.TimeZone = desired.Spec.TimeZone
+	existing.Spec.ConcurrencyPolicy = desired.Spec.ConcurrencyPolicy
+	existing.Spec.StartingDeadlineSeconds = desired.Spec.StartingDeadlineSeconds
+	existing.Spec.Suspend = desired.Spec.Suspend
+	existing.Spec.SuccessfulJobsHistoryLimit = desired.Spec.SuccessfulJobsHistoryLimit
+	existing.Spec.FailedJobsHistoryLimit = desired.Spec.FailedJobsHistoryLimit
+
+	existingJob, desiredJob := &existing.Spec.JobTemplate.Spec, desired.Spec.JobTemplate.Spec
+	existingJob.Completions = desiredJob.Completions
+	existingJob.Parallelism = desiredJob.Parallelism
+	existingJob.BackoffLimit = desiredJob.BackoffLimit
+	existingJob.ActiveDeadlineSeconds = desiredJob.ActiveDeadlineSeconds
+	if err := mergeWithOverride(&existingJob.Template, desiredJob.Template); err != nil {
+		return err
+	}
+	if err := mergeWithOverwriteWithEmptyValue(&existingJob.Template.Spec.NodeSelector, desiredJob.Template.Spec.NodeSelector); err != nil {
+		return err
+	}
+	return nil
+}
+
 func hasImmutableFieldChange(existing, desired *appsv1.StatefulSet) (bool, string) {
 	if existing.CreationTimestamp.IsZero() {
 		return false, ""
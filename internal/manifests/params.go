@@ -27,7 +27,9 @@ import (
 
 // Params holds the reconciliation-specific parameters.
 type Params struct {
-	Client          client.Client
+	Client client.Client
+	// Reader reads the objects the operator doesn't cache, like pods, straight from the API server.
+	Reader          client.Reader
 	Recorder        record.EventRecorder
 	Scheme          *runtime.Scheme
 	Log             logr.Logger
@@ -35,4 +37,9 @@ type Params struct {
 	TargetAllocator v1alpha1.TargetAllocator
 	OpAMPBridge     v1alpha1.OpAMPBridge
 	Config          config.Config
+	// ConfigReferencesHash is the hash of the values referenced by the collector's configuration references.
+	ConfigReferencesHash string
+	// MountsHash is the hash of the content of the ConfigMaps and the Secrets mounted by the collector, when its pods
+	// are replaced as they change.
+	MountsHash string
 }
@@ -19,10 +19,11 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
-	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
 	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
 
 const (
@@ -37,9 +38,7 @@ func ConfigMap(params Params) (*corev1.ConfigMap, error) {
 
 	taConfig := make(map[interface{}]interface{})
 
-	taConfig["collector_selector"] = metav1.LabelSelector{
-		MatchLabels: manifestutils.SelectorLabels(params.Collector.ObjectMeta, collector.ComponentOpenTelemetryCollector),
-	}
+	taConfig["collector_selector"] = taSpec.CollectorSelector
 
 	// Add scrape configs if present
 	if instance.Spec.ScrapeConfigs != nil && len(instance.Spec.ScrapeConfigs) > 0 {
@@ -53,6 +52,9 @@ func ConfigMap(params Params) (*corev1.ConfigMap, error) {
 	} else {
 		taConfig["allocation_strategy"] = v1beta1.TargetAllocatorAllocationStrategyConsistentHashing
 	}
+	if len(taSpec.TopologyKey) > 0 {
+		taConfig["topology_key"] = taSpec.TopologyKey
+	}
 	taConfig["filter_strategy"] = taSpec.FilterStrategy
 
 	if taSpec.PrometheusCR.Enabled {
@@ -67,9 +69,28 @@ func ConfigMap(params Params) (*corev1.ConfigMap, error) {
 
 		prometheusCRConfig["pod_monitor_selector"] = taSpec.PrometheusCR.PodMonitorSelector
 
+		// Probes and ScrapeConfigs are opt-in, their CRDs aren't necessarily installed
+		if taSpec.PrometheusCR.ProbeSelector != nil {
+			prometheusCRConfig["probe_selector"] = taSpec.PrometheusCR.ProbeSelector
+			prometheusCRConfig["probe_namespace_selector"] = taSpec.PrometheusCR.ProbeNamespaceSelector
+		}
+
+		if taSpec.PrometheusCR.ScrapeConfigSelector != nil {
+			prometheusCRConfig["scrape_config_selector"] = taSpec.PrometheusCR.ScrapeConfigSelector
+			prometheusCRConfig["scrape_config_namespace_selector"] = taSpec.PrometheusCR.ScrapeConfigNamespaceSelector
+		}
+
 		taConfig["prometheus_cr"] = prometheusCRConfig
 	}
 
+	if isHighlyAvailable(instance) {
+		taConfig["high_availability"] = map[interface{}]interface{}{
+			"enabled":         true,
+			"lease_name":      naming.TargetAllocator(instance.Name),
+			"lease_namespace": instance.Namespace,
+		}
+	}
+
 	taConfigYAML, err := yaml.Marshal(taConfig)
 	if err != nil {
 		return &corev1.ConfigMap{}, err
@@ -87,3 +108,8 @@ func ConfigMap(params Params) (*corev1.ConfigMap, error) {
 		},
 	}, nil
 }
+
+// isHighlyAvailable returns whether the target allocator replicas should elect a leader.
+func isHighlyAvailable(instance v1alpha1.TargetAllocator) bool {
+	return featuregate.TargetAllocatorHighAvailability.IsEnabled() && instance.Spec.Replicas != nil && *instance.Spec.Replicas > 1
+}
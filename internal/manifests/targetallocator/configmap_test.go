@@ -21,10 +21,12 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	colfeaturegate "go.opentelemetry.io/collector/featuregate"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
 
 func TestDesiredConfigMap(t *testing.T) {
@@ -200,5 +202,148 @@ prometheus_cr:
 		assert.Equal(t, expectedData, actual.Data)
 
 	})
+	t.Run("should return expected target allocator config map with probe and scrape config selectors", func(t *testing.T) {
+		expectedData := map[string]string{
+			targetAllocatorFilename: `allocation_strategy: consistent-hashing
+collector_selector:
+  matchlabels:
+    app.kubernetes.io/component: opentelemetry-collector
+    app.kubernetes.io/instance: default.my-instance
+    app.kubernetes.io/managed-by: opentelemetry-operator
+    app.kubernetes.io/part-of: opentelemetry
+  matchexpressions: []
+filter_strategy: relabel-config
+prometheus_cr:
+  enabled: true
+  pod_monitor_selector: null
+  probe_namespace_selector:
+    matchlabels:
+      team: observability
+    matchexpressions: []
+  probe_selector:
+    matchlabels:
+      release: my-instance
+    matchexpressions: []
+  scrape_config_namespace_selector: null
+  scrape_config_selector:
+    matchlabels: {}
+    matchexpressions: []
+  service_monitor_selector: null
+`,
+		}
+		targetAllocator = targetAllocatorInstance()
+		targetAllocator.Spec.ScrapeConfigs = nil
+		targetAllocator.Spec.PrometheusCR.Enabled = true
+		targetAllocator.Spec.PrometheusCR.ProbeSelector = &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				"release": "my-instance",
+			},
+		}
+		targetAllocator.Spec.PrometheusCR.ProbeNamespaceSelector = &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				"team": "observability",
+			},
+		}
+		targetAllocator.Spec.PrometheusCR.ScrapeConfigSelector = &metav1.LabelSelector{}
+		params.TargetAllocator = targetAllocator
+		actual, err := ConfigMap(params)
+		require.NoError(t, err)
+
+		assert.Equal(t, expectedData, actual.Data)
+	})
+	t.Run("should configure leader election for multiple replicas", func(t *testing.T) {
+		require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.TargetAllocatorHighAvailability.ID(), true))
+		t.Cleanup(func() {
+			require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.TargetAllocatorHighAvailability.ID(), false))
+		})
+		expectedData := map[string]string{
+			targetAllocatorFilename: `allocation_strategy: consistent-hashing
+collector_selector:
+  matchlabels:
+    app.kubernetes.io/component: opentelemetry-collector
+    app.kubernetes.io/instance: default.my-instance
+    app.kubernetes.io/managed-by: opentelemetry-operator
+    app.kubernetes.io/part-of: opentelemetry
+  matchexpressions: []
+filter_strategy: relabel-config
+high_availability:
+  enabled: true
+  lease_name: my-instance-targetallocator
+  lease_namespace: default
+`,
+		}
+		targetAllocator = targetAllocatorInstance()
+		targetAllocator.Spec.ScrapeConfigs = nil
+		replicas := int32(2)
+		targetAllocator.Spec.Replicas = &replicas
+		params.TargetAllocator = targetAllocator
+		actual, err := ConfigMap(params)
+		require.NoError(t, err)
+
+		assert.Equal(t, expectedData, actual.Data)
+	})
+	t.Run("should configure the topology key", func(t *testing.T) {
+		expectedData := map[string]string{
+			targetAllocatorFilename: `allocation_strategy: topology-aware
+collector_selector:
+  matchlabels:
+    app.kubernetes.io/component: opentelemetry-collector
+    app.kubernetes.io/instance: default.my-instance
+    app.kubernetes.io/managed-by: opentelemetry-operator
+    app.kubernetes.io/part-of: opentelemetry
+  matchexpressions: []
+filter_strategy: relabel-config
+topology_key: topology.kubernetes.io/region
+`,
+		}
+		targetAllocator = targetAllocatorInstance()
+		targetAllocator.Spec.ScrapeConfigs = nil
+		targetAllocator.Spec.AllocationStrategy = v1beta1.TargetAllocatorAllocationStrategyTopologyAware
+		targetAllocator.Spec.TopologyKey = "topology.kubernetes.io/region"
+		params.TargetAllocator = targetAllocator
+		actual, err := ConfigMap(params)
+		require.NoError(t, err)
+
+		assert.Equal(t, expectedData, actual.Data)
+	})
+	t.Run("should use the collector selector from the target allocator", func(t *testing.T) {
+		expectedData := map[string]string{
+			targetAllocatorFilename: `allocation_strategy: consistent-hashing
+collector_selector:
+  matchlabels:
+    pool: shared
+  matchexpressions:
+  - key: tier
+    operator: In
+    values:
+    - frontend
+    - backend
+filter_strategy: relabel-config
+`,
+		}
+		targetAllocator = targetAllocatorInstance()
+		targetAllocator.Spec.ScrapeConfigs = nil
+		targetAllocator.Spec.CollectorSelector = metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				"pool": "shared",
+			},
+			MatchExpressions: []metav1.LabelSelectorRequirement{
+				{
+					Key:      "tier",
+					Operator: metav1.LabelSelectorOpIn,
+					Values:   []string{"frontend", "backend"},
+				},
+			},
+		}
+		params := Params{
+			TargetAllocator: targetAllocator,
+			Config:          cfg,
+			Log:             logr.Discard(),
+		}
+		actual, err := ConfigMap(params)
+		require.NoError(t, err)
+
+		assert.Equal(t, expectedData, actual.Data)
+	})
 
 }
@@ -24,6 +24,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
@@ -79,6 +80,27 @@ func Container(cfg config.Config, logger logr.Logger, instance v1alpha1.TargetAl
 		})
 	}
 
+	if isHighlyAvailable(instance) {
+		// the allocator uses these to tell the other replicas where to reach it once elected
+		envVars = append(envVars, corev1.EnvVar{
+			Name: "POD_NAME",
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{
+					FieldPath: "metadata.name",
+				},
+			},
+		},
+			corev1.EnvVar{
+				Name: "POD_IP",
+				ValueFrom: &corev1.EnvVarSource{
+					FieldRef: &corev1.ObjectFieldSelector{
+						FieldPath: "status.podIP",
+					},
+				},
+			},
+		)
+	}
+
 	if featuregate.SetGolangFlags.IsEnabled() {
 		envVars = append(envVars, corev1.EnvVar{
 			Name: "GOMEMLIMIT",
@@ -111,22 +133,8 @@ func Container(cfg config.Config, logger logr.Logger, instance v1alpha1.TargetAl
 		args = append(args, fmt.Sprintf("--%s=%s", k, v))
 	}
 	sort.Strings(args)
-	readinessProbe := &corev1.Probe{
-		ProbeHandler: corev1.ProbeHandler{
-			HTTPGet: &corev1.HTTPGetAction{
-				Path: "/readyz",
-				Port: intstr.FromInt(8080),
-			},
-		},
-	}
-	livenessProbe := &corev1.Probe{
-		ProbeHandler: corev1.ProbeHandler{
-			HTTPGet: &corev1.HTTPGetAction{
-				Path: "/livez",
-				Port: intstr.FromInt(8080),
-			},
-		},
-	}
+	readinessProbe := getProbe("/readyz", instance.Spec.ReadinessProbe)
+	livenessProbe := getProbe("/livez", instance.Spec.LivenessProbe)
 
 	envVars = append(envVars, proxy.ReadProxyVarsFromEnv()...)
 	return corev1.Container{
@@ -145,3 +153,33 @@ func Container(cfg config.Config, logger logr.Logger, instance v1alpha1.TargetAl
 		Lifecycle:       instance.Spec.Lifecycle,
 	}
 }
+
+func getProbe(path string, probeConfig *v1beta1.Probe) *corev1.Probe {
+	probe := &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			HTTPGet: &corev1.HTTPGetAction{
+				Path: path,
+				Port: intstr.FromInt(8080),
+			},
+		},
+	}
+	if probeConfig != nil {
+		if probeConfig.InitialDelaySeconds != nil {
+			probe.InitialDelaySeconds = *probeConfig.InitialDelaySeconds
+		}
+		if probeConfig.PeriodSeconds != nil {
+			probe.PeriodSeconds = *probeConfig.PeriodSeconds
+		}
+		if probeConfig.FailureThreshold != nil {
+			probe.FailureThreshold = *probeConfig.FailureThreshold
+		}
+		if probeConfig.SuccessThreshold != nil {
+			probe.SuccessThreshold = *probeConfig.SuccessThreshold
+		}
+		if probeConfig.TimeoutSeconds != nil {
+			probe.TimeoutSeconds = *probeConfig.TimeoutSeconds
+		}
+		probe.TerminationGracePeriodSeconds = probeConfig.TerminationGracePeriodSeconds
+	}
+	return probe
+}
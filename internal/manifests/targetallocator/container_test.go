@@ -20,6 +20,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	colfeaturegate "go.opentelemetry.io/collector/featuregate"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -29,6 +30,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
 
 var logger = logf.Log.WithName("unit-tests")
@@ -207,6 +209,50 @@ func TestContainerHasEnvVars(t *testing.T) {
 	assert.Equal(t, expected, c)
 }
 
+func TestContainerHighAvailabilityEnvVars(t *testing.T) {
+	require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.TargetAllocatorHighAvailability.ID(), true))
+	t.Cleanup(func() {
+		require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.TargetAllocatorHighAvailability.ID(), false))
+	})
+	cfg := config.New(config.WithTargetAllocatorImage("default-image"))
+
+	for _, tc := range []struct {
+		name     string
+		replicas *int32
+		expected bool
+	}{
+		{name: "default replicas", replicas: nil, expected: false},
+		{name: "single replica", replicas: &[]int32{1}[0], expected: false},
+		{name: "multiple replicas", replicas: &[]int32{2}[0], expected: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			targetAllocator := v1alpha1.TargetAllocator{
+				Spec: v1alpha1.TargetAllocatorSpec{
+					OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
+						Replicas: tc.replicas,
+					},
+				},
+			}
+
+			c := Container(cfg, logger, targetAllocator)
+
+			env := map[string]string{}
+			for _, envVar := range c.Env {
+				if envVar.ValueFrom != nil && envVar.ValueFrom.FieldRef != nil {
+					env[envVar.Name] = envVar.ValueFrom.FieldRef.FieldPath
+				}
+			}
+			if tc.expected {
+				assert.Equal(t, "metadata.name", env["POD_NAME"])
+				assert.Equal(t, "status.podIP", env["POD_IP"])
+			} else {
+				assert.NotContains(t, env, "POD_NAME")
+				assert.NotContains(t, env, "POD_IP")
+			}
+		})
+	}
+}
+
 func TestContainerHasProxyEnvVars(t *testing.T) {
 	err := os.Setenv("NO_PROXY", "localhost")
 	require.NoError(t, err)
@@ -340,6 +386,52 @@ func TestLivenessProbe(t *testing.T) {
 	assert.Equal(t, expected, c.LivenessProbe)
 }
 
+func TestProbeOverrides(t *testing.T) {
+	// prepare
+	initialDelaySeconds := int32(10)
+	timeoutSeconds := int32(11)
+	failureThreshold := int32(12)
+	terminationGracePeriodSeconds := int64(13)
+	targetAllocator := v1alpha1.TargetAllocator{
+		Spec: v1alpha1.TargetAllocatorSpec{
+			LivenessProbe: &v1beta1.Probe{
+				InitialDelaySeconds:           &initialDelaySeconds,
+				TerminationGracePeriodSeconds: &terminationGracePeriodSeconds,
+			},
+			ReadinessProbe: &v1beta1.Probe{
+				TimeoutSeconds:   &timeoutSeconds,
+				FailureThreshold: &failureThreshold,
+			},
+		},
+	}
+	cfg := config.New()
+
+	// test
+	c := Container(cfg, logger, targetAllocator)
+
+	// verify
+	assert.Equal(t, &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			HTTPGet: &corev1.HTTPGetAction{
+				Path: "/livez",
+				Port: intstr.FromInt(8080),
+			},
+		},
+		InitialDelaySeconds:           initialDelaySeconds,
+		TerminationGracePeriodSeconds: &terminationGracePeriodSeconds,
+	}, c.LivenessProbe)
+	assert.Equal(t, &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			HTTPGet: &corev1.HTTPGetAction{
+				Path: "/readyz",
+				Port: intstr.FromInt(8080),
+			},
+		},
+		TimeoutSeconds:   timeoutSeconds,
+		FailureThreshold: failureThreshold,
+	}, c.ReadinessProbe)
+}
+
 func TestSecurityContext(t *testing.T) {
 	runAsNonRoot := true
 	securityContext := &corev1.SecurityContext{
@@ -405,6 +497,32 @@ func TestContainerCustomVolumes(t *testing.T) {
 	assert.Equal(t, "custom-volume-mount", c.VolumeMounts[1].Name)
 }
 
+func TestContainerCustomVolumesAreMounted(t *testing.T) {
+	// prepare
+	targetAllocator := v1alpha1.TargetAllocator{
+		Spec: v1alpha1.TargetAllocatorSpec{
+			OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
+				Volumes: []corev1.Volume{{
+					Name: "custom-volume",
+				}},
+				VolumeMounts: []corev1.VolumeMount{{
+					Name: "custom-volume",
+				}},
+			},
+		},
+	}
+	cfg := config.New()
+
+	// test
+	c := Container(cfg, logger, targetAllocator)
+	volumes := Volumes(cfg, targetAllocator)
+
+	// verify
+	assert.Len(t, volumes, 2)
+	assert.Equal(t, "custom-volume", volumes[1].Name)
+	assert.Equal(t, volumes[1].Name, c.VolumeMounts[1].Name)
+}
+
 func TestContainerCustomPorts(t *testing.T) {
 	// prepare
 	targetAllocator := v1alpha1.TargetAllocator{
@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package targetallocator
+
+import (
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+// Role returns the role granting the highly available target allocator replicas access to their leader election lease.
+// The permissions of a service account provided by the user are left to the user, the webhook warns about missing ones.
+func Role(params Params) *rbacv1.Role {
+	if !isHighlyAvailable(params.TargetAllocator) || len(params.TargetAllocator.Spec.ServiceAccount) > 0 {
+		return nil
+	}
+	name := naming.TargetAllocatorRole(params.TargetAllocator.Name)
+	labels := manifestutils.Labels(params.TargetAllocator.ObjectMeta, name, params.TargetAllocator.Spec.Image, ComponentOpenTelemetryTargetAllocator, nil)
+
+	return &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   params.TargetAllocator.Namespace,
+			Labels:      labels,
+			Annotations: params.TargetAllocator.Annotations,
+		},
+		Rules: leaseRules(params.TargetAllocator),
+	}
+}
+
+// RoleBinding returns the role binding of the leader election role to the target allocator service account.
+func RoleBinding(params Params) *rbacv1.RoleBinding {
+	if !isHighlyAvailable(params.TargetAllocator) || len(params.TargetAllocator.Spec.ServiceAccount) > 0 {
+		return nil
+	}
+	name := naming.TargetAllocatorRoleBinding(params.TargetAllocator.Name)
+	labels := manifestutils.Labels(params.TargetAllocator.ObjectMeta, name, params.TargetAllocator.Spec.Image, ComponentOpenTelemetryTargetAllocator, nil)
+
+	return &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   params.TargetAllocator.Namespace,
+			Labels:      labels,
+			Annotations: params.TargetAllocator.Annotations,
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      "ServiceAccount",
+				Name:      naming.TargetAllocatorServiceAccount(params.TargetAllocator.Name),
+				Namespace: params.TargetAllocator.Namespace,
+			},
+		},
+		RoleRef: rbacv1.RoleRef{
+			Kind:     "Role",
+			Name:     naming.TargetAllocatorRole(params.TargetAllocator.Name),
+			APIGroup: "rbac.authorization.k8s.io",
+		},
+	}
+}
+
+// leaseRules returns the policy rules the target allocator needs to elect a leader through its lease.
+func leaseRules(instance v1alpha1.TargetAllocator) []rbacv1.PolicyRule {
+	return []rbacv1.PolicyRule{
+		{
+			APIGroups: []string{"coordination.k8s.io"},
+			Resources: []string{"leases"},
+			Verbs:     []string{"create"},
+		},
+		{
+			APIGroups:     []string{"coordination.k8s.io"},
+			Resources:     []string{"leases"},
+			ResourceNames: []string{naming.TargetAllocator(instance.Name)},
+			Verbs:         []string{"get", "update"},
+		},
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package targetallocator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	colfeaturegate "go.opentelemetry.io/collector/featuregate"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+)
+
+func TestRole(t *testing.T) {
+	require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.TargetAllocatorHighAvailability.ID(), true))
+	t.Cleanup(func() {
+		require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.TargetAllocatorHighAvailability.ID(), false))
+	})
+	one, two := int32(1), int32(2)
+
+	params := func(replicas int32, serviceAccount string) Params {
+		return Params{
+			TargetAllocator: v1alpha1.TargetAllocator{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-instance",
+					Namespace: "observability",
+				},
+				Spec: v1alpha1.TargetAllocatorSpec{
+					OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
+						Replicas:       &replicas,
+						ServiceAccount: serviceAccount,
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("should grant access to the lease of highly available replicas", func(t *testing.T) {
+		role := Role(params(two, ""))
+		require.NotNil(t, role)
+		assert.Equal(t, "my-instance-targetallocator", role.Name)
+		assert.Equal(t, "observability", role.Namespace)
+		assert.Equal(t, []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{"coordination.k8s.io"},
+				Resources: []string{"leases"},
+				Verbs:     []string{"create"},
+			},
+			{
+				APIGroups:     []string{"coordination.k8s.io"},
+				Resources:     []string{"leases"},
+				ResourceNames: []string{"my-instance-targetallocator"},
+				Verbs:         []string{"get", "update"},
+			},
+		}, role.Rules)
+
+		binding := RoleBinding(params(two, ""))
+		require.NotNil(t, binding)
+		assert.Equal(t, rbacv1.RoleRef{
+			Kind:     "Role",
+			Name:     "my-instance-targetallocator",
+			APIGroup: "rbac.authorization.k8s.io",
+		}, binding.RoleRef)
+		assert.Equal(t, []rbacv1.Subject{
+			{
+				Kind:      "ServiceAccount",
+				Name:      "my-instance-targetallocator",
+				Namespace: "observability",
+			},
+		}, binding.Subjects)
+	})
+
+	t.Run("should not grant anything to a single replica", func(t *testing.T) {
+		assert.Nil(t, Role(params(one, "")))
+		assert.Nil(t, RoleBinding(params(one, "")))
+	})
+
+	t.Run("should leave the permissions of a custom service account to the user", func(t *testing.T) {
+		assert.Nil(t, Role(params(two, "my-special-sa")))
+		assert.Nil(t, RoleBinding(params(two, "my-special-sa")))
+	})
+}
@@ -40,6 +40,8 @@ func Build(params Params) ([]client.Object, error) {
 		manifests.FactoryWithoutError(ServiceAccount),
 		manifests.FactoryWithoutError(Service),
 		manifests.Factory(PodDisruptionBudget),
+		manifests.FactoryWithoutError(Role),
+		manifests.FactoryWithoutError(RoleBinding),
 	}
 
 	if params.TargetAllocator.Spec.Observability.Metrics.EnableMetrics && featuregate.PrometheusOperatorIsAvailable.IsEnabled() {
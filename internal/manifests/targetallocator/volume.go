@@ -22,7 +22,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
 )
 
-// Volumes builds the volumes for the given instance, including the config map volume.
+// Volumes builds the volumes for the given instance, including the config map volume and the volumes of the spec.
 func Volumes(cfg config.Config, instance v1alpha1.TargetAllocator) []corev1.Volume {
 	volumes := []corev1.Volume{{
 		Name: naming.TAConfigMapVolume(),
@@ -38,5 +38,9 @@ func Volumes(cfg config.Config, instance v1alpha1.TargetAllocator) []corev1.Volu
 		},
 	}}
 
+	if len(instance.Spec.Volumes) > 0 {
+		volumes = append(volumes, instance.Spec.Volumes...)
+	}
+
 	return volumes
 }
@@ -41,6 +41,11 @@ func ConfigMapExtra(extraConfigMapName string) string {
 	return DNSName(Truncate("configmap-%s", 63, extraConfigMapName))
 }
 
+// ConfigReferenceVolume returns the name of the volume holding the file of a configuration reference in the pod.
+func ConfigReferenceVolume(reference string) string {
+	return DNSName(Truncate("ref-%s", 63, reference))
+}
+
 // TAConfigMapVolume returns the name to use for the config map's volume in the TargetAllocator pod.
 func TAConfigMapVolume() string {
 	return "ta-internal"
@@ -71,6 +76,21 @@ func Collector(otelcol string) string {
 	return DNSName(Truncate("%s-collector", 63, otelcol))
 }
 
+// CollectorCanary builds the name of the deployment running the canary replicas of the collector.
+func CollectorCanary(otelcol string) string {
+	return DNSName(Truncate("%s-collector-canary", 63, otelcol))
+}
+
+// CollectorJob builds the name of the job running the collector, after the hash of its inputs.
+func CollectorJob(otelcol, inputsHash string) string {
+	return DNSName(Truncate("%s-collector-%s", 63, otelcol, inputsHash[:8]))
+}
+
+// CollectorCronJob builds the name of the cronjob running the collector, whose length is limited to 52 characters.
+func CollectorCronJob(otelcol string) string {
+	return DNSName(Truncate("%s-collector", 52, otelcol))
+}
+
 // HorizontalPodAutoscaler builds the autoscaler name based on the instance.
 func HorizontalPodAutoscaler(otelcol string) string {
 	return DNSName(Truncate("%s-collector", 63, otelcol))
@@ -171,6 +191,16 @@ func TargetAllocatorServiceAccount(otelcol string) string {
 	return DNSName(Truncate("%s-targetallocator", 63, otelcol))
 }
 
+// TargetAllocatorRole returns the TargetAllocator role resource name.
+func TargetAllocatorRole(otelcol string) string {
+	return DNSName(Truncate("%s-targetallocator", 63, otelcol))
+}
+
+// TargetAllocatorRoleBinding returns the TargetAllocator role binding resource name.
+func TargetAllocatorRoleBinding(otelcol string) string {
+	return DNSName(Truncate("%s-targetallocator", 63, otelcol))
+}
+
 // TargetAllocatorServiceMonitor returns the TargetAllocator service account resource name.
 func TargetAllocatorServiceMonitor(otelcol string) string {
 	return DNSName(Truncate("%s-targetallocator", 63, otelcol))
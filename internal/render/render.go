@@ -0,0 +1,433 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package render builds the manifests of an OpenTelemetryCollector offline, the way the operator would reconcile it,
+// so that changes to a collector can be reviewed before they're applied.
+package render
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	routev1 "github.com/openshift/api/route/v1"
+	"github.com/pmezard/go-difflib/difflib"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/spf13/pflag"
+	colfeaturegate "go.opentelemetry.io/collector/featuregate"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/yaml"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/controllers"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/openshift"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/prometheus"
+	autoRBAC "github.com/open-telemetry/opentelemetry-operator/internal/autodetect/rbac"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+	"github.com/open-telemetry/opentelemetry-operator/internal/version"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+)
+
+const (
+	// Command is the argument of the manager binary running the render command.
+	Command = "render"
+
+	filenameFlagName             = "filename"
+	compareToFlagName            = "compare-to"
+	baseConfigFlagName           = "base-config"
+	namespaceFlagName            = "namespace"
+	collectorImageFlagName       = "collector-image"
+	targetAllocatorImageFlagName = "target-allocator-image"
+	openShiftRoutesFlagName      = "openshift-routes"
+	prometheusCRsFlagName        = "prometheus-crs"
+	createRBACFlagName           = "create-rbac-permissions"
+	labelsFilterFlagName         = "label"
+	annotationsFilterFlagName    = "annotations-filter"
+
+	// componentLabel is how objects whose name changes with their content, like the collector's ConfigMap, are matched
+	// between two versions of a collector.
+	componentLabel = "app.kubernetes.io/component"
+)
+
+// Run renders the manifests of the collector given in args, or the differences with the manifests of another version
+// of it, to out.
+func Run(args []string, out io.Writer) error {
+	v := version.Get()
+	flagSet := pflag.NewFlagSet(Command, pflag.ContinueOnError)
+	flagSet.StringP(filenameFlagName, "f", "", "The file holding the OpenTelemetryCollector to render, either v1alpha1 or v1beta1.")
+	flagSet.String(compareToFlagName, "", "A file holding another version of the OpenTelemetryCollector. When set, the differences between the manifests of both versions are printed instead.")
+	flagSet.String(baseConfigFlagName, "", "A file holding the base configuration, as YAML, of an OpenTelemetryCollector setting spec.baseConfig.")
+	flagSet.String(namespaceFlagName, "default", "The namespace of the OpenTelemetryCollector, when its metadata doesn't set one.")
+	flagSet.String(collectorImageFlagName, fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-collector-releases/opentelemetry-collector:%s", v.OpenTelemetryCollector), "The default OpenTelemetry collector image. This image is used when no image is specified in the CustomResource.")
+	flagSet.String(targetAllocatorImageFlagName, fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/target-allocator:%s", v.TargetAllocator), "The default OpenTelemetry target allocator image. This image is used when no image is specified in the CustomResource.")
+	flagSet.Bool(openShiftRoutesFlagName, false, "Render as if the route.openshift.io API was available.")
+	flagSet.Bool(prometheusCRsFlagName, false, "Render as if the monitoring.coreos.com API was available.")
+	flagSet.Bool(createRBACFlagName, false, "Render as if the operator had the permissions to create RBAC resources.")
+	flagSet.StringArray(labelsFilterFlagName, []string{}, "Labels to filter away from propagating onto deploys, like the flag of the operator.")
+	flagSet.StringArray(annotationsFilterFlagName, []string{}, "Annotations to filter away from propagating onto deploys, like the flag of the operator.")
+	flagSet.AddGoFlagSet(featuregate.Flags(colfeaturegate.GlobalRegistry()))
+	if err := flagSet.Parse(args); err != nil {
+		return err
+	}
+	filename, _ := flagSet.GetString(filenameFlagName)
+	compareTo, _ := flagSet.GetString(compareToFlagName)
+	baseConfigFile, _ := flagSet.GetString(baseConfigFlagName)
+	namespace, _ := flagSet.GetString(namespaceFlagName)
+	collectorImage, _ := flagSet.GetString(collectorImageFlagName)
+	targetAllocatorImage, _ := flagSet.GetString(targetAllocatorImageFlagName)
+	openShiftRoutes, _ := flagSet.GetBool(openShiftRoutesFlagName)
+	prometheusCRs, _ := flagSet.GetBool(prometheusCRsFlagName)
+	createRBAC, _ := flagSet.GetBool(createRBACFlagName)
+	labelsFilter, _ := flagSet.GetStringArray(labelsFilterFlagName)
+	annotationsFilter, _ := flagSet.GetStringArray(annotationsFilterFlagName)
+	if filename == "" {
+		return errors.New("the file holding the OpenTelemetryCollector is required")
+	}
+
+	routes := openshift.RoutesNotAvailable
+	if openShiftRoutes {
+		routes = openshift.RoutesAvailable
+	}
+	prometheusCRAvailability := prometheus.NotAvailable
+	if prometheusCRs {
+		prometheusCRAvailability = prometheus.Available
+	}
+	rbacAvailability := autoRBAC.NotAvailable
+	if createRBAC {
+		rbacAvailability = autoRBAC.Available
+	}
+	cfg := config.New(
+		config.WithLogger(logr.Discard()),
+		config.WithVersion(v),
+		config.WithCollectorImage(collectorImage),
+		config.WithTargetAllocatorImage(targetAllocatorImage),
+		config.WithOpenShiftRoutesAvailability(routes),
+		config.WithPrometheusCRAvailability(prometheusCRAvailability),
+		config.WithRBACPermissions(rbacAvailability),
+		config.WithLabelFilters(labelsFilter),
+		config.WithAnnotationFilters(annotationsFilter),
+	)
+	r := renderer{
+		cfg:       cfg,
+		scheme:    newScheme(),
+		namespace: namespace,
+	}
+	if baseConfigFile != "" {
+		data, err := os.ReadFile(baseConfigFile)
+		if err != nil {
+			return err
+		}
+		r.baseConfig = &v1beta1.Config{}
+		if err = yaml.Unmarshal(data, r.baseConfig); err != nil {
+			return fmt.Errorf("failed to parse the base configuration from %s: %w", baseConfigFile, err)
+		}
+	}
+
+	objects, err := r.renderFile(filename)
+	if err != nil {
+		return err
+	}
+	if compareTo == "" {
+		return writeObjects(out, objects)
+	}
+	previous, err := r.renderFile(compareTo)
+	if err != nil {
+		return err
+	}
+	return writeDiff(out, previous, objects)
+}
+
+func newScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(v1alpha1.AddToScheme(scheme))
+	utilruntime.Must(v1beta1.AddToScheme(scheme))
+	utilruntime.Must(monitoringv1.AddToScheme(scheme))
+	utilruntime.Must(routev1.Install(scheme))
+	return scheme
+}
+
+type renderer struct {
+	cfg        config.Config
+	scheme     *runtime.Scheme
+	namespace  string
+	baseConfig *v1beta1.Config
+}
+
+// object is a rendered manifest.
+type object struct {
+	kind      string
+	namespace string
+	name      string
+	component string
+	yaml      string
+}
+
+func (o object) id() string {
+	if o.namespace == "" {
+		return fmt.Sprintf("%s/%s", o.kind, o.name)
+	}
+	return fmt.Sprintf("%s/%s/%s", o.kind, o.namespace, o.name)
+}
+
+func (r renderer) renderFile(filename string) ([]object, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	otelcol, err := r.decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the OpenTelemetryCollector from %s: %w", filename, err)
+	}
+	objects, err := r.render(otelcol)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render the OpenTelemetryCollector from %s: %w", filename, err)
+	}
+	return objects, nil
+}
+
+// decode reads an OpenTelemetryCollector of any version, and applies the defaults of the admission webhook to it.
+func (r renderer) decode(data []byte) (v1beta1.OpenTelemetryCollector, error) {
+	otelcol := v1beta1.OpenTelemetryCollector{}
+	decoded, _, err := serializer.NewCodecFactory(r.scheme).UniversalDeserializer().Decode(data, nil, nil)
+	if err != nil {
+		return otelcol, err
+	}
+	switch in := decoded.(type) {
+	case *v1beta1.OpenTelemetryCollector:
+		otelcol = *in
+	case *v1alpha1.OpenTelemetryCollector:
+		if err = in.ConvertTo(&otelcol); err != nil {
+			return otelcol, err
+		}
+	default:
+		return otelcol, fmt.Errorf("expected an OpenTelemetryCollector, got %T", decoded)
+	}
+	if otelcol.Namespace == "" {
+		otelcol.Namespace = r.namespace
+	}
+	applySchemaDefaults(&otelcol)
+	err = v1beta1.CollectorWebhook{}.Default(context.Background(), &otelcol)
+	return otelcol, err
+}
+
+// applySchemaDefaults sets the defaults the API server applies from the CRD schema, for the fields the manifests
+// depend on.
+func applySchemaDefaults(otelcol *v1beta1.OpenTelemetryCollector) {
+	if otelcol.Spec.ConfigVersions == 0 {
+		otelcol.Spec.ConfigVersions = 3
+	}
+	ta := &otelcol.Spec.TargetAllocator
+	if ta.AllocationStrategy == "" {
+		ta.AllocationStrategy = v1beta1.TargetAllocatorAllocationStrategyConsistentHashing
+	}
+	if ta.FilterStrategy == "" {
+		ta.FilterStrategy = v1beta1.TargetAllocatorFilterStrategyRelabelConfig
+	}
+	if ta.PrometheusCR.Enabled && ta.PrometheusCR.ScrapeInterval == nil {
+		ta.PrometheusCR.ScrapeInterval = &metav1.Duration{Duration: 30 * time.Second}
+	}
+	if rollout := otelcol.Spec.Rollout; rollout != nil {
+		if rollout.Strategy == "" {
+			rollout.Strategy = v1beta1.RolloutStrategyAll
+		}
+		if rollout.Canary.Replicas == 0 {
+			rollout.Canary.Replicas = 1
+		}
+	}
+}
+
+// render builds the manifests of the collector like the reconciler does, without a cluster.
+func (r renderer) render(otelcol v1beta1.OpenTelemetryCollector) ([]object, error) {
+	if collector.OverlaysEnabled(otelcol) {
+		if otelcol.Spec.BaseConfig != nil && r.baseConfig == nil {
+			return nil, fmt.Errorf("the collector sets spec.baseConfig, the base configuration must be given with --%s", baseConfigFlagName)
+		}
+		merged, err := otelcol.Spec.Config.Merge(r.baseConfig, otelcol.Spec.ConfigPatches)
+		if err != nil {
+			return nil, err
+		}
+		otelcol.Spec.Config = merged
+	}
+	params := manifests.Params{
+		Config:   r.cfg,
+		OtelCol:  otelcol,
+		Log:      logr.Discard(),
+		Scheme:   r.scheme,
+		Recorder: record.NewFakeRecorder(100),
+	}
+	targetAllocator, err := collector.TargetAllocator(params)
+	if err != nil {
+		return nil, err
+	}
+	if targetAllocator != nil {
+		params.TargetAllocator = *targetAllocator
+	}
+	built, err := controllers.BuildCollector(params)
+	if err != nil {
+		return nil, err
+	}
+
+	objects := make([]object, 0, len(built))
+	for _, obj := range built {
+		rendered, renderErr := r.renderObject(obj)
+		if renderErr != nil {
+			return nil, renderErr
+		}
+		objects = append(objects, rendered)
+	}
+	sort.Slice(objects, func(i, j int) bool {
+		return objects[i].id() < objects[j].id()
+	})
+	return objects, nil
+}
+
+func (r renderer) renderObject(obj client.Object) (object, error) {
+	gvk, err := apiutil.GVKForObject(obj, r.scheme)
+	if err != nil {
+		return object{}, err
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return object{}, err
+	}
+	content["apiVersion"], content["kind"] = gvk.GroupVersion().String(), gvk.Kind
+	// the manifests are desired states, they don't have a status or a creation time yet
+	delete(content, "status")
+	if metadata, ok := content["metadata"].(map[string]interface{}); ok {
+		delete(metadata, "creationTimestamp")
+	}
+	out, err := yaml.Marshal(content)
+	if err != nil {
+		return object{}, err
+	}
+	return object{
+		kind:      gvk.Kind,
+		namespace: obj.GetNamespace(),
+		name:      obj.GetName(),
+		component: obj.GetLabels()[componentLabel],
+		yaml:      string(out),
+	}, nil
+}
+
+func writeObjects(out io.Writer, objects []object) error {
+	for i, obj := range objects {
+		if i > 0 {
+			if _, err := io.WriteString(out, "---\n"); err != nil {
+				return err
+			}
+		}
+		if _, err := io.WriteString(out, obj.yaml); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeDiff writes a unified diff of every object which differs between both versions of the manifests. Objects are
+// matched by kind and name, and objects whose name changed, like the collector's ConfigMap which is named after the
+// hash of the configuration, by kind and component.
+func writeDiff(out io.Writer, previous, current []object) error {
+	pairs := map[string]*[2]*object{}
+	var ids []string
+	add := func(obj *object, side int) {
+		pair, ok := pairs[obj.id()]
+		if !ok {
+			pair = &[2]*object{}
+			pairs[obj.id()] = pair
+			ids = append(ids, obj.id())
+		}
+		pair[side] = obj
+	}
+	for i := range previous {
+		add(&previous[i], 0)
+	}
+	for i := range current {
+		add(&current[i], 1)
+	}
+	matchRenamed(pairs, ids)
+
+	sort.Strings(ids)
+	buf := &bytes.Buffer{}
+	for _, id := range ids {
+		pair, ok := pairs[id]
+		if !ok {
+			continue
+		}
+		from, to := "/dev/null", "/dev/null"
+		var a, b string
+		if pair[0] != nil {
+			from, a = "a/"+pair[0].id(), pair[0].yaml
+		}
+		if pair[1] != nil {
+			to, b = "b/"+pair[1].id(), pair[1].yaml
+		}
+		if a == b {
+			continue
+		}
+		err := difflib.WriteUnifiedDiff(buf, difflib.UnifiedDiff{
+			A:        difflib.SplitLines(a),
+			B:        difflib.SplitLines(b),
+			FromFile: from,
+			ToFile:   to,
+			Context:  3,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	_, err := out.Write(buf.Bytes())
+	return err
+}
+
+// matchRenamed pairs objects only found in one version with the only object of the same kind and component only
+// found in the other version.
+func matchRenamed(pairs map[string]*[2]*object, ids []string) {
+	unmatched := map[string][2][]string{}
+	for _, id := range ids {
+		pair := pairs[id]
+		for side, obj := range pair {
+			if obj == nil || pair[1-side] != nil || obj.component == "" {
+				continue
+			}
+			key := obj.kind + "/" + obj.namespace + "/" + obj.component
+			sides := unmatched[key]
+			sides[side] = append(sides[side], id)
+			unmatched[key] = sides
+		}
+	}
+	for _, sides := range unmatched {
+		if len(sides[0]) != 1 || len(sides[1]) != 1 {
+			continue
+		}
+		pairs[sides[1][0]][0] = pairs[sides[0][0]][0]
+		delete(pairs, sides[0][0])
+	}
+}
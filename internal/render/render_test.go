@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	out := &bytes.Buffer{}
+	err := Run([]string{"-f", "testdata/collector.yaml"}, out)
+	require.NoError(t, err)
+
+	documents := strings.Split(out.String(), "---\n")
+	var kinds []string
+	for _, document := range documents {
+		for _, line := range strings.Split(document, "\n") {
+			if strings.HasPrefix(line, "kind: ") {
+				kinds = append(kinds, strings.TrimPrefix(line, "kind: "))
+			}
+		}
+	}
+	assert.Equal(t, []string{"ConfigMap", "Deployment", "PodDisruptionBudget", "Service", "Service", "Service", "ServiceAccount"}, kinds)
+	assert.Contains(t, out.String(), "namespace: default")
+	assert.NotContains(t, out.String(), "\nstatus:")
+}
+
+func TestRunTargetAllocator(t *testing.T) {
+	out := &bytes.Buffer{}
+	err := Run([]string{"-f", "testdata/collector-v1alpha1.yaml"}, out)
+	require.NoError(t, err)
+
+	assert.Contains(t, out.String(), "name: simplest-targetallocator")
+	assert.Contains(t, out.String(), "namespace: observability")
+	// the collector's configuration points its prometheus receiver at the target allocator
+	assert.Contains(t, out.String(), "endpoint: http://simplest-targetallocator:80")
+}
+
+func TestRunCompareTo(t *testing.T) {
+	out := &bytes.Buffer{}
+	err := Run([]string{"-f", "testdata/collector-changed.yaml", "--compare-to", "testdata/collector.yaml"}, out)
+	require.NoError(t, err)
+
+	diff := out.String()
+	// the ConfigMap named after the hash of the configuration is compared with its previous version
+	assert.Equal(t, 1, strings.Count(diff, "--- a/ConfigMap/"))
+	assert.NotContains(t, diff, "/dev/null")
+	assert.Contains(t, diff, "+          http: {}")
+	assert.Contains(t, diff, "-  replicas: 1\n+  replicas: 2")
+	assert.NotContains(t, diff, "ServiceAccount")
+}
+
+func TestRunErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		args []string
+		err  string
+	}{
+		{
+			name: "no file",
+			err:  "the file holding the OpenTelemetryCollector is required",
+		},
+		{
+			name: "missing file",
+			args: []string{"-f", "testdata/missing.yaml"},
+			err:  "no such file or directory",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Run(tc.args, &bytes.Buffer{})
+			assert.ErrorContains(t, err, tc.err)
+		})
+	}
+}
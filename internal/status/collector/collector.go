@@ -20,6 +20,7 @@ import (
 	"strconv"
 
 	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -30,7 +31,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/internal/version"
 )
 
-func UpdateCollectorStatus(ctx context.Context, cli client.Client, changed *v1beta1.OpenTelemetryCollector) error {
+func UpdateCollectorStatus(ctx context.Context, cli client.Client, reader client.Reader, changed *v1beta1.OpenTelemetryCollector) error {
 	if changed.Status.Version == "" {
 		// a version is not set, otherwise let the upgrade mechanism take care of it!
 		changed.Status.Version = version.OpenTelemetryCollector()
@@ -38,9 +39,31 @@ func UpdateCollectorStatus(ctx context.Context, cli client.Client, changed *v1be
 
 	mode := changed.Spec.Mode
 
+	if err := updateTargetAllocatorCondition(ctx, cli, changed); err != nil {
+		return err
+	}
+
+	pods, err := listCollectorPods(ctx, reader, changed)
+	if err != nil {
+		return err
+	}
+	zones, err := nodeZones(ctx, cli, pods)
+	if err != nil {
+		return err
+	}
+	changed.Status.Pods = podsStatus(pods, zones)
+
+	if mode != v1beta1.ModeJob && mode != v1beta1.ModeCronJob {
+		changed.Status.Job = nil
+	}
+
 	if mode == v1beta1.ModeSidecar {
 		changed.Status.Scale.Replicas = 0
 		changed.Status.Scale.Selector = ""
+		message := "sidecars are injected into the pods which request them"
+		setCondition(changed, v1beta1.CollectorConditionReady, metav1.ConditionTrue, reasonSidecar, message)
+		setCondition(changed, v1beta1.CollectorConditionDegraded, metav1.ConditionFalse, reasonSidecar, message)
+		setPodsConditions(changed)
 		return nil
 	}
 
@@ -54,6 +77,14 @@ func UpdateCollectorStatus(ctx context.Context, cli client.Client, changed *v1be
 	}
 	changed.Status.Scale.Selector = selector.String()
 
+	if mode == v1beta1.ModeJob || mode == v1beta1.ModeCronJob {
+		if err := updateJobStatus(ctx, cli, changed); err != nil {
+			return err
+		}
+		setPodsConditions(changed)
+		return nil
+	}
+
 	// Set the scale replicas
 	objKey := client.ObjectKey{
 		Namespace: changed.GetNamespace(),
@@ -62,6 +93,7 @@ func UpdateCollectorStatus(ctx context.Context, cli client.Client, changed *v1be
 
 	var replicas int32
 	var readyReplicas int32
+	var desiredReplicas int32
 	var statusReplicas string
 	var statusImage string
 
@@ -73,6 +105,7 @@ func UpdateCollectorStatus(ctx context.Context, cli client.Client, changed *v1be
 		}
 		replicas = obj.Status.Replicas
 		readyReplicas = obj.Status.ReadyReplicas
+		desiredReplicas = specReplicas(obj.Spec.Replicas)
 		statusReplicas = strconv.Itoa(int(readyReplicas)) + "/" + strconv.Itoa(int(replicas))
 		statusImage = obj.Spec.Template.Spec.Containers[0].Image
 
@@ -83,6 +116,7 @@ func UpdateCollectorStatus(ctx context.Context, cli client.Client, changed *v1be
 		}
 		replicas = obj.Status.Replicas
 		readyReplicas = obj.Status.ReadyReplicas
+		desiredReplicas = specReplicas(obj.Spec.Replicas)
 		statusReplicas = strconv.Itoa(int(readyReplicas)) + "/" + strconv.Itoa(int(replicas))
 		statusImage = obj.Spec.Template.Spec.Containers[0].Image
 
@@ -91,12 +125,47 @@ func UpdateCollectorStatus(ctx context.Context, cli client.Client, changed *v1be
 		if err := cli.Get(ctx, objKey, obj); err != nil {
 			return fmt.Errorf("failed to get daemonSet status.replicas: %w", err)
 		}
+		replicas = obj.Status.CurrentNumberScheduled
+		readyReplicas = obj.Status.NumberReady
+		desiredReplicas = obj.Status.DesiredNumberScheduled
+		statusReplicas = strconv.Itoa(int(readyReplicas)) + "/" + strconv.Itoa(int(desiredReplicas))
 		statusImage = obj.Spec.Template.Spec.Containers[0].Image
 	}
 
 	changed.Status.Scale.Replicas = replicas
 	changed.Status.Image = statusImage
 	changed.Status.Scale.StatusReplicas = statusReplicas
+	setReplicaConditions(changed, readyReplicas, desiredReplicas)
+	setPodsConditions(changed)
 
 	return nil
 }
+
+func updateTargetAllocatorCondition(ctx context.Context, cli client.Client, changed *v1beta1.OpenTelemetryCollector) error {
+	if !changed.Spec.TargetAllocator.Enabled {
+		setTargetAllocatorCondition(changed, false, 0, 0)
+		return nil
+	}
+	obj := &appsv1.Deployment{}
+	objKey := client.ObjectKey{
+		Namespace: changed.GetNamespace(),
+		Name:      naming.TargetAllocator(changed.Name),
+	}
+	if err := cli.Get(ctx, objKey, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			setTargetAllocatorCondition(changed, false, 0, 0)
+			return nil
+		}
+		return fmt.Errorf("failed to get target allocator deployment: %w", err)
+	}
+	setTargetAllocatorCondition(changed, true, obj.Status.ReadyReplicas, specReplicas(obj.Spec.Replicas))
+	return nil
+}
+
+// specReplicas returns the number of replicas a workload asks for, which defaults to one.
+func specReplicas(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
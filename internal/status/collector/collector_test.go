@@ -21,6 +21,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -42,7 +43,7 @@ func TestUpdateCollectorStatusUnsupported(t *testing.T) {
 		},
 	}
 
-	err := UpdateCollectorStatus(ctx, cli, changed)
+	err := UpdateCollectorStatus(ctx, cli, cli, changed)
 	assert.NoError(t, err)
 
 	assert.Equal(t, int32(0), changed.Status.Scale.Replicas, "expected replicas to be 0")
@@ -89,7 +90,7 @@ func TestUpdateCollectorStatusDeploymentMode(t *testing.T) {
 		},
 	}
 
-	err := UpdateCollectorStatus(ctx, cli, changed)
+	err := UpdateCollectorStatus(ctx, cli, cli, changed)
 	assert.NoError(t, err)
 
 	assert.Equal(t, int32(1), changed.Status.Scale.Replicas, "expected replicas to be 1")
@@ -137,7 +138,7 @@ func TestUpdateCollectorStatusStatefulset(t *testing.T) {
 		},
 	}
 
-	err := UpdateCollectorStatus(ctx, cli, changed)
+	err := UpdateCollectorStatus(ctx, cli, cli, changed)
 	assert.NoError(t, err)
 
 	assert.Equal(t, int32(1), changed.Status.Scale.Replicas, "expected replicas to be 1")
@@ -184,9 +185,115 @@ func TestUpdateCollectorStatusDaemonsetMode(t *testing.T) {
 		},
 	}
 
-	err := UpdateCollectorStatus(ctx, cli, changed)
+	err := UpdateCollectorStatus(ctx, cli, cli, changed)
 	assert.NoError(t, err)
 
 	assert.Contains(t, changed.Status.Scale.Selector, "customLabel=customValue", "expected selector to contain customlabel=customValue")
 	assert.Equal(t, "app:latest", changed.Status.Image, "expected image to be app:latest")
 }
+
+func TestUpdateCollectorStatusConditions(t *testing.T) {
+	ctx := context.TODO()
+	replicas := int32(3)
+	objects := []client.Object{
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-collector",
+				Namespace: "default",
+			},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: &replicas,
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{Name: "app", Image: "app:latest"}},
+					},
+				},
+			},
+			Status: appsv1.DeploymentStatus{
+				Replicas:      3,
+				ReadyReplicas: 2,
+			},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-targetallocator",
+				Namespace: "default",
+			},
+			Status: appsv1.DeploymentStatus{
+				Replicas:      1,
+				ReadyReplicas: 1,
+			},
+		},
+	}
+	cli := fake.NewClientBuilder().WithObjects(objects...).Build()
+
+	changed := &v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "test",
+			Namespace:  "default",
+			Generation: 2,
+		},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode: v1beta1.ModeDeployment,
+			TargetAllocator: v1beta1.TargetAllocatorEmbedded{
+				Enabled: true,
+			},
+		},
+	}
+
+	err := UpdateCollectorStatus(ctx, cli, cli, changed)
+	assert.NoError(t, err)
+
+	ready := meta.FindStatusCondition(changed.Status.Conditions, v1beta1.CollectorConditionReady)
+	if assert.NotNil(t, ready) {
+		assert.Equal(t, metav1.ConditionFalse, ready.Status)
+		assert.Equal(t, "2/3 replicas ready", ready.Message)
+		assert.Equal(t, int64(2), ready.ObservedGeneration)
+	}
+	assert.True(t, meta.IsStatusConditionTrue(changed.Status.Conditions, v1beta1.CollectorConditionDegraded))
+	assert.True(t, meta.IsStatusConditionTrue(changed.Status.Conditions, v1beta1.CollectorConditionTargetAllocatorReady))
+
+	// disabling the target allocator removes its condition
+	changed.Spec.TargetAllocator.Enabled = false
+	err = UpdateCollectorStatus(ctx, cli, cli, changed)
+	assert.NoError(t, err)
+	assert.Nil(t, meta.FindStatusCondition(changed.Status.Conditions, v1beta1.CollectorConditionTargetAllocatorReady))
+}
+
+func TestUpdateCollectorStatusDaemonsetConditions(t *testing.T) {
+	ctx := context.TODO()
+	daemonset := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-collector",
+			Namespace: "default",
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "app", Image: "app:latest"}},
+				},
+			},
+		},
+		Status: appsv1.DaemonSetStatus{
+			DesiredNumberScheduled: 4,
+			NumberReady:            4,
+		},
+	}
+	cli := fake.NewClientBuilder().WithObjects(daemonset).Build()
+
+	changed := &v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+		},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode: v1beta1.ModeDaemonSet,
+		},
+	}
+
+	err := UpdateCollectorStatus(ctx, cli, cli, changed)
+	assert.NoError(t, err)
+	assert.Equal(t, "4/4", changed.Status.Scale.StatusReplicas)
+	assert.True(t, meta.IsStatusConditionTrue(changed.Status.Conditions, v1beta1.CollectorConditionReady))
+	assert.True(t, meta.IsStatusConditionFalse(changed.Status.Conditions, v1beta1.CollectorConditionDegraded))
+}
@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+)
+
+const (
+	reasonReconcileSucceeded = "ReconcileSucceeded"
+	reasonReconcileFailed    = "ReconcileFailed"
+	reasonConfigValid        = "ConfigValid"
+	reasonConfigInvalid      = "ConfigInvalid"
+	reasonReplicasReady      = "ReplicasReady"
+	reasonReplicasNotReady   = "ReplicasNotReady"
+	reasonSidecar            = "Sidecar"
+	reasonJobPending         = "JobPending"
+	reasonJobSucceeded       = "JobSucceeded"
+	reasonJobFailed          = "JobFailed"
+)
+
+// ConfigError is returned when the manifests of a collector can't be built from its configuration, as opposed to
+// errors applying the manifests to the cluster.
+type ConfigError struct {
+	Err error
+}
+
+// NewConfigError wraps the given error into a ConfigError.
+func NewConfigError(err error) error {
+	return &ConfigError{Err: err}
+}
+
+func (e *ConfigError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ConfigError) Unwrap() error {
+	return e.Err
+}
+
+func setCondition(otelcol *v1beta1.OpenTelemetryCollector, conditionType string, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&otelcol.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		ObservedGeneration: otelcol.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// setReconcileConditions records the outcome of building and applying the manifests of the collector. A failed
+// reconciliation leaves the collector not ready and degraded, whatever the state of its replicas.
+func setReconcileConditions(otelcol *v1beta1.OpenTelemetryCollector, err error) {
+	if err == nil {
+		setCondition(otelcol, v1beta1.CollectorConditionReconciled, metav1.ConditionTrue, reasonReconcileSucceeded, "")
+		setCondition(otelcol, v1beta1.CollectorConditionConfigValid, metav1.ConditionTrue, reasonConfigValid, "")
+		return
+	}
+
+	setCondition(otelcol, v1beta1.CollectorConditionReconciled, metav1.ConditionFalse, reasonReconcileFailed, err.Error())
+	var configErr *ConfigError
+	if errors.As(err, &configErr) {
+		setCondition(otelcol, v1beta1.CollectorConditionConfigValid, metav1.ConditionFalse, reasonConfigInvalid, err.Error())
+	} else {
+		setCondition(otelcol, v1beta1.CollectorConditionConfigValid, metav1.ConditionTrue, reasonConfigValid, "")
+	}
+	setCondition(otelcol, v1beta1.CollectorConditionReady, metav1.ConditionFalse, reasonReconcileFailed, err.Error())
+	setCondition(otelcol, v1beta1.CollectorConditionDegraded, metav1.ConditionTrue, reasonReconcileFailed, err.Error())
+}
+
+// setReplicaConditions sets the Ready and Degraded conditions from the number of ready replicas of the collector.
+func setReplicaConditions(otelcol *v1beta1.OpenTelemetryCollector, ready, desired int32) {
+	message := fmt.Sprintf("%d/%d replicas ready", ready, desired)
+	if ready >= desired {
+		setCondition(otelcol, v1beta1.CollectorConditionReady, metav1.ConditionTrue, reasonReplicasReady, message)
+		setCondition(otelcol, v1beta1.CollectorConditionDegraded, metav1.ConditionFalse, reasonReplicasReady, message)
+		return
+	}
+	setCondition(otelcol, v1beta1.CollectorConditionReady, metav1.ConditionFalse, reasonReplicasNotReady, message)
+	setCondition(otelcol, v1beta1.CollectorConditionDegraded, metav1.ConditionTrue, reasonReplicasNotReady, message)
+}
+
+// setTargetAllocatorCondition sets the TargetAllocatorReady condition, or removes it when the target allocator is
+// disabled.
+func setTargetAllocatorCondition(otelcol *v1beta1.OpenTelemetryCollector, found bool, ready, desired int32) {
+	switch {
+	case !otelcol.Spec.TargetAllocator.Enabled:
+		meta.RemoveStatusCondition(&otelcol.Status.Conditions, v1beta1.CollectorConditionTargetAllocatorReady)
+	case !found:
+		setCondition(otelcol, v1beta1.CollectorConditionTargetAllocatorReady, metav1.ConditionFalse, reasonReplicasNotReady, "target allocator deployment not found")
+	case ready >= desired:
+		setCondition(otelcol, v1beta1.CollectorConditionTargetAllocatorReady, metav1.ConditionTrue, reasonReplicasReady, fmt.Sprintf("%d/%d replicas ready", ready, desired))
+	default:
+		setCondition(otelcol, v1beta1.CollectorConditionTargetAllocatorReady, metav1.ConditionFalse, reasonReplicasNotReady, fmt.Sprintf("%d/%d replicas ready", ready, desired))
+	}
+}
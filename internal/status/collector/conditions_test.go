@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+)
+
+func TestSetReconcileConditions(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		err         error
+		reconciled  metav1.ConditionStatus
+		configValid metav1.ConditionStatus
+	}{
+		{
+			name:        "success",
+			reconciled:  metav1.ConditionTrue,
+			configValid: metav1.ConditionTrue,
+		},
+		{
+			name:        "invalid config",
+			err:         fmt.Errorf("building manifests: %w", NewConfigError(errors.New("no receivers"))),
+			reconciled:  metav1.ConditionFalse,
+			configValid: metav1.ConditionFalse,
+		},
+		{
+			name:        "apply failure",
+			err:         errors.New("forbidden"),
+			reconciled:  metav1.ConditionFalse,
+			configValid: metav1.ConditionTrue,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			otelcol := &v1beta1.OpenTelemetryCollector{ObjectMeta: metav1.ObjectMeta{Generation: 3}}
+			setReconcileConditions(otelcol, tc.err)
+
+			reconciled := meta.FindStatusCondition(otelcol.Status.Conditions, v1beta1.CollectorConditionReconciled)
+			if assert.NotNil(t, reconciled) {
+				assert.Equal(t, tc.reconciled, reconciled.Status)
+				assert.Equal(t, int64(3), reconciled.ObservedGeneration)
+				if tc.err != nil {
+					assert.Equal(t, tc.err.Error(), reconciled.Message)
+				}
+			}
+			assert.Equal(t, tc.configValid, meta.FindStatusCondition(otelcol.Status.Conditions, v1beta1.CollectorConditionConfigValid).Status)
+			if tc.err != nil {
+				assert.True(t, meta.IsStatusConditionFalse(otelcol.Status.Conditions, v1beta1.CollectorConditionReady))
+				assert.True(t, meta.IsStatusConditionTrue(otelcol.Status.Conditions, v1beta1.CollectorConditionDegraded))
+			}
+		})
+	}
+}
@@ -24,6 +24,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
 	"github.com/open-telemetry/opentelemetry-operator/internal/version"
 	collectorupgrade "github.com/open-telemetry/opentelemetry-operator/pkg/collector/upgrade"
 )
@@ -42,9 +43,16 @@ func HandleReconcileStatus(ctx context.Context, log logr.Logger, params manifest
 	log.V(2).Info("updating collector status")
 	if err != nil {
 		params.Recorder.Event(&otelcol, eventTypeWarning, reasonError, err.Error())
+		changed := otelcol.DeepCopy()
+		setReconcileConditions(changed, err)
+		if patchErr := params.Client.Status().Patch(ctx, changed, client.MergeFrom(&otelcol)); patchErr != nil {
+			// don't hide the reconciliation error behind the status one
+			log.Error(patchErr, "failed to apply status conditions to the OpenTelemetry CR")
+		}
 		return ctrl.Result{}, err
 	}
 	changed := otelcol.DeepCopy()
+	setReconcileConditions(changed, nil)
 
 	up := &collectorupgrade.VersionUpgrade{
 		Log:      params.Log,
@@ -58,15 +66,37 @@ func HandleReconcileStatus(ctx context.Context, log logr.Logger, params manifest
 		log.V(2).Error(upgradeErr, "failed to upgrade the OpenTelemetry CR")
 	}
 	changed = &upgraded
-	statusErr := UpdateCollectorStatus(ctx, params.Client, changed)
+	if effectiveErr := setEffectiveConfig(changed, params.OtelCol); effectiveErr != nil {
+		log.V(2).Error(effectiveErr, "failed to record the effective configuration of the OpenTelemetry CR")
+	}
+	statusErr := UpdateCollectorStatus(ctx, params.Client, params.Reader, changed)
 	if statusErr != nil {
 		params.Recorder.Event(changed, eventTypeWarning, reasonStatusFailure, statusErr.Error())
 		return ctrl.Result{}, statusErr
 	}
+	requeueAfter, rolloutErr := updateRolloutStatus(ctx, params, changed)
+	if rolloutErr != nil {
+		params.Recorder.Event(changed, eventTypeWarning, reasonStatusFailure, rolloutErr.Error())
+		return ctrl.Result{}, rolloutErr
+	}
 	statusPatch := client.MergeFrom(&otelcol)
 	if err := params.Client.Status().Patch(ctx, changed, statusPatch); err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to apply status changes to the OpenTelemetry CR: %w", err)
 	}
 	params.Recorder.Event(changed, eventTypeNormal, reasonInfo, "applied status changes")
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// setEffectiveConfig records the configuration the manifests were built from, when it's merged from overlays.
+func setEffectiveConfig(changed *v1beta1.OpenTelemetryCollector, built v1beta1.OpenTelemetryCollector) error {
+	if !collector.OverlaysEnabled(built) {
+		changed.Status.EffectiveConfig = ""
+		return nil
+	}
+	effective, err := built.Spec.Config.Yaml()
+	if err != nil {
+		return err
+	}
+	changed.Status.EffectiveConfig = effective
+	return nil
 }
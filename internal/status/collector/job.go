@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+// updateJobStatus reports the outcome of the latest run of the collector, in the job and cronjob modes.
+func updateJobStatus(ctx context.Context, cli client.Client, changed *v1beta1.OpenTelemetryCollector) error {
+	status := &v1beta1.CollectorJobStatus{Phase: v1beta1.CollectorJobPhasePending}
+
+	var jobs []batchv1.Job
+	if changed.Spec.Mode == v1beta1.ModeCronJob {
+		cronJob := &batchv1.CronJob{}
+		objKey := client.ObjectKey{Namespace: changed.Namespace, Name: naming.CollectorCronJob(changed.Name)}
+		if err := cli.Get(ctx, objKey, cronJob); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get cronjob: %w", err)
+		} else if err == nil {
+			status.LastScheduleTime = cronJob.Status.LastScheduleTime
+			status.LastSuccessfulTime = cronJob.Status.LastSuccessfulTime
+			// the jobs of the cronjob don't carry the labels of the collector, they're found by their owner
+			list := &batchv1.JobList{}
+			if err := cli.List(ctx, list, client.InNamespace(changed.Namespace)); err != nil {
+				return fmt.Errorf("failed to list jobs: %w", err)
+			}
+			for _, job := range list.Items {
+				if owner := metav1.GetControllerOf(&job); owner != nil && owner.UID == cronJob.UID {
+					jobs = append(jobs, job)
+				}
+			}
+		}
+	} else {
+		list := &batchv1.JobList{}
+		opts := []client.ListOption{
+			client.InNamespace(changed.Namespace),
+			client.MatchingLabels(manifestutils.SelectorLabels(changed.ObjectMeta, collector.ComponentOpenTelemetryCollector)),
+		}
+		if err := cli.List(ctx, list, opts...); err != nil {
+			return fmt.Errorf("failed to list jobs: %w", err)
+		}
+		jobs = list.Items
+	}
+
+	latest := latestJob(jobs)
+	if latest != nil {
+		status.Name = latest.Name
+		status.Phase, status.Message = jobPhase(latest)
+		status.Active = latest.Status.Active
+		status.Succeeded = latest.Status.Succeeded
+		status.Failed = latest.Status.Failed
+		status.StartTime = latest.Status.StartTime
+		status.CompletionTime = latest.Status.CompletionTime
+		changed.Status.Image = latest.Spec.Template.Spec.Containers[len(latest.Spec.Template.Spec.Containers)-1].Image
+	}
+	changed.Status.Job = status
+	changed.Status.Scale.Replicas = status.Active
+
+	switch status.Phase {
+	case v1beta1.CollectorJobPhaseFailed:
+		setCondition(changed, v1beta1.CollectorConditionReady, metav1.ConditionFalse, reasonJobFailed, status.Message)
+		setCondition(changed, v1beta1.CollectorConditionDegraded, metav1.ConditionTrue, reasonJobFailed, status.Message)
+	case v1beta1.CollectorJobPhaseSucceeded:
+		setCondition(changed, v1beta1.CollectorConditionReady, metav1.ConditionTrue, reasonJobSucceeded, "")
+		setCondition(changed, v1beta1.CollectorConditionDegraded, metav1.ConditionFalse, reasonJobSucceeded, "")
+	case v1beta1.CollectorJobPhaseRunning:
+		var ready int32
+		if latest.Status.Ready != nil {
+			ready = *latest.Status.Ready
+		}
+		setReplicaConditions(changed, ready, latest.Status.Active)
+	default:
+		message := "the collector didn't run yet"
+		setCondition(changed, v1beta1.CollectorConditionReady, metav1.ConditionTrue, reasonJobPending, message)
+		setCondition(changed, v1beta1.CollectorConditionDegraded, metav1.ConditionFalse, reasonJobPending, message)
+	}
+	return nil
+}
+
+// latestJob returns the job created last, or nil if there's none.
+func latestJob(jobs []batchv1.Job) *batchv1.Job {
+	var latest *batchv1.Job
+	for i := range jobs {
+		if latest == nil || latest.CreationTimestamp.Before(&jobs[i].CreationTimestamp) {
+			latest = &jobs[i]
+		}
+	}
+	return latest
+}
+
+// jobPhase returns the phase of the job from its conditions, and the message of the failure if it failed.
+func jobPhase(job *batchv1.Job) (v1beta1.CollectorJobPhase, string) {
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type { // nolint:exhaustive
+		case batchv1.JobComplete:
+			return v1beta1.CollectorJobPhaseSucceeded, ""
+		case batchv1.JobFailed:
+			return v1beta1.CollectorJobPhaseFailed, fmt.Sprintf("%s: %s", condition.Reason, condition.Message)
+		}
+	}
+	return v1beta1.CollectorJobPhaseRunning, ""
+}
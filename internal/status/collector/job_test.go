@@ -0,0 +1,175 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+)
+
+var jobSelectorLabels = map[string]string{
+	"app.kubernetes.io/managed-by": "opentelemetry-operator",
+	"app.kubernetes.io/instance":   "default.test-job",
+	"app.kubernetes.io/part-of":    "opentelemetry",
+	"app.kubernetes.io/component":  "opentelemetry-collector",
+}
+
+func collectorJob(name string, labels map[string]string, created time.Time, conditions ...batchv1.JobCondition) *batchv1.Job {
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "default",
+			Labels:            labels,
+			CreationTimestamp: metav1.NewTime(created),
+		},
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "otc-container", Image: "app:latest"}},
+				},
+			},
+		},
+		Status: batchv1.JobStatus{Conditions: conditions},
+	}
+}
+
+func TestUpdateCollectorStatusJobMode(t *testing.T) {
+	now := time.Now()
+	for _, tt := range []struct {
+		name          string
+		conditions    []batchv1.JobCondition
+		expectedPhase v1beta1.CollectorJobPhase
+		expectedReady metav1.ConditionStatus
+	}{
+		{
+			name:          "running",
+			expectedPhase: v1beta1.CollectorJobPhaseRunning,
+			expectedReady: metav1.ConditionTrue,
+		},
+		{
+			name:          "succeeded",
+			conditions:    []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}},
+			expectedPhase: v1beta1.CollectorJobPhaseSucceeded,
+			expectedReady: metav1.ConditionTrue,
+		},
+		{
+			name:          "failed",
+			conditions:    []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Reason: "DeadlineExceeded", Message: "Job was active longer than specified deadline"}},
+			expectedPhase: v1beta1.CollectorJobPhaseFailed,
+			expectedReady: metav1.ConditionFalse,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			// prepare
+			cli := fake.NewClientBuilder().WithObjects(
+				collectorJob("test-job-collector-00000000", jobSelectorLabels, now.Add(-time.Hour), batchv1.JobCondition{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}),
+				collectorJob("test-job-collector-11111111", jobSelectorLabels, now, tt.conditions...),
+			).Build()
+			changed := &v1beta1.OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-job",
+					Namespace: "default",
+				},
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode: v1beta1.ModeJob,
+				},
+			}
+
+			// test
+			err := UpdateCollectorStatus(context.TODO(), cli, cli, changed)
+			require.NoError(t, err)
+
+			// verify
+			require.NotNil(t, changed.Status.Job)
+			assert.Equal(t, "test-job-collector-11111111", changed.Status.Job.Name)
+			assert.Equal(t, tt.expectedPhase, changed.Status.Job.Phase)
+			assert.Equal(t, "app:latest", changed.Status.Image)
+			ready := meta.FindStatusCondition(changed.Status.Conditions, v1beta1.CollectorConditionReady)
+			require.NotNil(t, ready)
+			assert.Equal(t, tt.expectedReady, ready.Status)
+		})
+	}
+}
+
+func TestUpdateCollectorStatusCronJobMode(t *testing.T) {
+	// prepare
+	scheduled := metav1.NewTime(time.Now().Truncate(time.Second))
+	cronJob := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cronjob-collector",
+			Namespace: "default",
+			UID:       "cronjob-uid",
+		},
+		Status: batchv1.CronJobStatus{LastScheduleTime: &scheduled},
+	}
+	controller := true
+	owned := collectorJob("test-cronjob-collector-28000000", nil, time.Now(), batchv1.JobCondition{Type: batchv1.JobComplete, Status: corev1.ConditionTrue})
+	owned.OwnerReferences = []metav1.OwnerReference{{Kind: "CronJob", Name: cronJob.Name, UID: cronJob.UID, Controller: &controller}}
+	unrelated := collectorJob("unrelated", nil, time.Now().Add(time.Hour))
+	cli := fake.NewClientBuilder().WithObjects(cronJob, owned, unrelated).Build()
+	changed := &v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cronjob",
+			Namespace: "default",
+		},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode: v1beta1.ModeCronJob,
+		},
+	}
+
+	// test
+	err := UpdateCollectorStatus(context.TODO(), cli, cli, changed)
+	require.NoError(t, err)
+
+	// verify
+	require.NotNil(t, changed.Status.Job)
+	assert.Equal(t, owned.Name, changed.Status.Job.Name)
+	assert.Equal(t, v1beta1.CollectorJobPhaseSucceeded, changed.Status.Job.Phase)
+	assert.True(t, scheduled.Equal(changed.Status.Job.LastScheduleTime))
+}
+
+func TestUpdateCollectorStatusCronJobNotScheduled(t *testing.T) {
+	// prepare
+	changed := &v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cronjob",
+			Namespace: "default",
+		},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode: v1beta1.ModeCronJob,
+		},
+	}
+
+	// test
+	cli := fake.NewFakeClient()
+	err := UpdateCollectorStatus(context.TODO(), cli, cli, changed)
+	require.NoError(t, err)
+
+	// verify
+	require.NotNil(t, changed.Status.Job)
+	assert.Equal(t, v1beta1.CollectorJobPhasePending, changed.Status.Job.Phase)
+	assert.True(t, meta.IsStatusConditionTrue(changed.Status.Conditions, v1beta1.CollectorConditionReady))
+}
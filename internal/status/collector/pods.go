@@ -0,0 +1,195 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/sidecar"
+)
+
+const (
+	// maxNodes is the number of nodes listed in the pods status, to keep its size bounded on large clusters.
+	maxNodes = 10
+	// maxZones is the number of zones listed in the pods status.
+	maxZones = 10
+
+	reasonCrashLoopBackOff = "CrashLoopBackOff"
+	reasonPodsCrashLooping = "PodsCrashLooping"
+)
+
+// listCollectorPods returns the pods running the collector, which are the pods it was injected into in sidecar mode.
+// Pods aren't cached by the operator, they're listed with a reader going to the API server.
+func listCollectorPods(ctx context.Context, reader client.Reader, otelcol *v1beta1.OpenTelemetryCollector) ([]corev1.Pod, error) {
+	labels := manifestutils.SelectorLabels(otelcol.ObjectMeta, collector.ComponentOpenTelemetryCollector)
+	if otelcol.Spec.Mode == v1beta1.ModeSidecar {
+		labels = map[string]string{sidecar.InjectedLabel: sidecar.InjectedLabelValue(*otelcol)}
+	}
+	pods := &corev1.PodList{}
+	if err := reader.List(ctx, pods, client.InNamespace(otelcol.Namespace), client.MatchingLabels(labels)); err != nil {
+		return nil, fmt.Errorf("failed to list collector pods: %w", err)
+	}
+	return pods.Items, nil
+}
+
+// nodeZones returns the zone of each node running the given pods, for the nodes which have one. Only the metadata of
+// the nodes is read, so that only their metadata is cached.
+func nodeZones(ctx context.Context, cli client.Client, pods []corev1.Pod) (map[string]string, error) {
+	zones := map[string]string{}
+	for i := range pods {
+		name := pods[i].Spec.NodeName
+		if _, ok := zones[name]; ok || name == "" {
+			continue
+		}
+		node := &metav1.PartialObjectMetadata{}
+		node.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Node"))
+		if err := cli.Get(ctx, client.ObjectKey{Name: name}, node); err != nil {
+			if apierrors.IsNotFound(err) {
+				zones[name] = ""
+				continue
+			}
+			return nil, fmt.Errorf("failed to get node %s: %w", name, err)
+		}
+		zones[name] = node.Labels[corev1.LabelTopologyZone]
+	}
+	return zones, nil
+}
+
+// podsStatus summarizes the state of the collector container of the given pods, running on nodes in the given zones.
+func podsStatus(pods []corev1.Pod, zones map[string]string) *v1beta1.CollectorPodsStatus {
+	status := &v1beta1.CollectorPodsStatus{}
+	nodes := map[string]*v1beta1.CollectorNodePodsStatus{}
+	zoneStatuses := map[string]*v1beta1.CollectorZonePodsStatus{}
+	for i := range pods {
+		pod := &pods[i]
+		if pod.DeletionTimestamp != nil || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		node := nodes[pod.Spec.NodeName]
+		if node == nil {
+			node = &v1beta1.CollectorNodePodsStatus{Name: pod.Spec.NodeName}
+			nodes[pod.Spec.NodeName] = node
+		}
+		// pods on nodes without a zone are counted in a zone which isn't listed
+		zone := zoneStatuses[zones[pod.Spec.NodeName]]
+		if zone == nil {
+			zone = &v1beta1.CollectorZonePodsStatus{Name: zones[pod.Spec.NodeName]}
+			zoneStatuses[zone.Name] = zone
+		}
+
+		container := collectorContainerStatus(pod)
+		switch {
+		case container != nil && container.Ready:
+			status.Ready++
+			node.Ready++
+			zone.Ready++
+		case container != nil && container.State.Waiting != nil && container.State.Waiting.Reason == reasonCrashLoopBackOff:
+			status.NotReady++
+			status.CrashLooping++
+			node.NotReady++
+			node.CrashLooping++
+			zone.NotReady++
+			zone.CrashLooping++
+		default:
+			status.NotReady++
+			node.NotReady++
+			zone.NotReady++
+		}
+		if container == nil {
+			continue
+		}
+
+		status.Restarts += container.RestartCount
+		terminated := container.State.Terminated
+		if terminated == nil {
+			terminated = container.LastTerminationState.Terminated
+		}
+		if terminated != nil && (status.LastTermination == nil || status.LastTermination.FinishedAt.Before(&terminated.FinishedAt)) {
+			status.LastTermination = &v1beta1.CollectorTermination{
+				Pod:        pod.Name,
+				Reason:     terminated.Reason,
+				Message:    terminated.Message,
+				ExitCode:   terminated.ExitCode,
+				FinishedAt: terminated.FinishedAt,
+			}
+		}
+	}
+
+	for _, node := range nodes {
+		// pods which aren't scheduled yet have no node, they're only accounted for in the totals
+		if node.Name != "" && node.NotReady > 0 {
+			status.Nodes = append(status.Nodes, *node)
+		}
+	}
+	sort.Slice(status.Nodes, func(i, j int) bool {
+		if status.Nodes[i].NotReady != status.Nodes[j].NotReady {
+			return status.Nodes[i].NotReady > status.Nodes[j].NotReady
+		}
+		return status.Nodes[i].Name < status.Nodes[j].Name
+	})
+	if len(status.Nodes) > maxNodes {
+		status.Nodes = status.Nodes[:maxNodes]
+	}
+
+	for _, zone := range zoneStatuses {
+		if zone.Name != "" {
+			status.Zones = append(status.Zones, *zone)
+		}
+	}
+	sort.Slice(status.Zones, func(i, j int) bool {
+		if status.Zones[i].NotReady != status.Zones[j].NotReady {
+			return status.Zones[i].NotReady > status.Zones[j].NotReady
+		}
+		return status.Zones[i].Name < status.Zones[j].Name
+	})
+	if len(status.Zones) > maxZones {
+		status.Zones = status.Zones[:maxZones]
+	}
+	return status
+}
+
+func collectorContainerStatus(pod *corev1.Pod) *corev1.ContainerStatus {
+	for i := range pod.Status.ContainerStatuses {
+		if pod.Status.ContainerStatuses[i].Name == naming.Container() {
+			return &pod.Status.ContainerStatuses[i]
+		}
+	}
+	return nil
+}
+
+// setPodsConditions marks the collector as degraded when some of its pods are crashlooping, which is how a
+// configuration rejected by the collector shows up.
+func setPodsConditions(otelcol *v1beta1.OpenTelemetryCollector) {
+	pods := otelcol.Status.Pods
+	if pods == nil || pods.CrashLooping == 0 {
+		return
+	}
+	message := fmt.Sprintf("%d pods crashlooping", pods.CrashLooping)
+	if pods.LastTermination != nil && pods.LastTermination.Message != "" {
+		message = fmt.Sprintf("%s, last termination of %s: %s", message, pods.LastTermination.Pod, pods.LastTermination.Message)
+	}
+	setCondition(otelcol, v1beta1.CollectorConditionDegraded, metav1.ConditionTrue, reasonPodsCrashLooping, message)
+}
@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+)
+
+func collectorPod(name, node string, labels map[string]string, status corev1.ContainerStatus) *corev1.Pod {
+	status.Name = "otc-container"
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels:    labels,
+		},
+		Spec: corev1.PodSpec{NodeName: node},
+		Status: corev1.PodStatus{
+			Phase:             corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{{Name: "app", Ready: true}, status},
+		},
+	}
+}
+
+func TestPodsStatus(t *testing.T) {
+	earlier := metav1.NewTime(time.Now().Add(-time.Hour))
+	later := metav1.NewTime(time.Now())
+	pods := []corev1.Pod{
+		*collectorPod("ready", "node-a", nil, corev1.ContainerStatus{Ready: true, RestartCount: 1}),
+		*collectorPod("crashlooping", "node-b", nil, corev1.ContainerStatus{
+			RestartCount: 5,
+			State: corev1.ContainerState{
+				Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+			},
+			LastTerminationState: corev1.ContainerState{
+				Terminated: &corev1.ContainerStateTerminated{
+					ExitCode:   1,
+					Reason:     "Error",
+					Message:    "invalid configuration: no receiver configuration specified in config",
+					FinishedAt: later,
+				},
+			},
+		}),
+		*collectorPod("starting", "node-b", nil, corev1.ContainerStatus{
+			LastTerminationState: corev1.ContainerState{
+				Terminated: &corev1.ContainerStateTerminated{ExitCode: 137, Reason: "OOMKilled", FinishedAt: earlier},
+			},
+		}),
+		*collectorPod("pending", "", nil, corev1.ContainerStatus{}),
+	}
+
+	status := podsStatus(pods, map[string]string{"node-a": "zone-1", "node-b": "zone-2"})
+
+	assert.Equal(t, int32(1), status.Ready)
+	assert.Equal(t, int32(3), status.NotReady)
+	assert.Equal(t, int32(1), status.CrashLooping)
+	assert.Equal(t, int32(6), status.Restarts)
+	assert.Equal(t, []v1beta1.CollectorNodePodsStatus{
+		{Name: "node-b", NotReady: 2, CrashLooping: 1},
+	}, status.Nodes)
+	// the pending pod has no node, and so no zone
+	assert.Equal(t, []v1beta1.CollectorZonePodsStatus{
+		{Name: "zone-2", NotReady: 2, CrashLooping: 1},
+		{Name: "zone-1", Ready: 1},
+	}, status.Zones)
+	require.NotNil(t, status.LastTermination)
+	assert.Equal(t, "crashlooping", status.LastTermination.Pod)
+	assert.Equal(t, "Error", status.LastTermination.Reason)
+	assert.Equal(t, int32(1), status.LastTermination.ExitCode)
+}
+
+func TestUpdateCollectorStatusSidecarPods(t *testing.T) {
+	ctx := context.TODO()
+	injected := map[string]string{"sidecar.opentelemetry.io/injected": "default.test"}
+	cli := fake.NewClientBuilder().WithObjects(
+		collectorPod("app-1", "node-a", injected, corev1.ContainerStatus{Ready: true}),
+		collectorPod("app-2", "node-a", injected, corev1.ContainerStatus{
+			State: corev1.ContainerState{
+				Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+			},
+			LastTerminationState: corev1.ContainerState{
+				Terminated: &corev1.ContainerStateTerminated{ExitCode: 1, Message: "invalid configuration"},
+			},
+		}),
+		collectorPod("other", "node-a", map[string]string{"sidecar.opentelemetry.io/injected": "default.other"}, corev1.ContainerStatus{}),
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{
+			Name:   "node-a",
+			Labels: map[string]string{corev1.LabelTopologyZone: "zone-1"},
+		}},
+	).Build()
+
+	changed := &v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+		},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode: v1beta1.ModeSidecar,
+		},
+	}
+
+	err := UpdateCollectorStatus(ctx, cli, cli, changed)
+	require.NoError(t, err)
+
+	require.NotNil(t, changed.Status.Pods)
+	assert.Equal(t, int32(1), changed.Status.Pods.Ready)
+	assert.Equal(t, int32(1), changed.Status.Pods.CrashLooping)
+	assert.Equal(t, []v1beta1.CollectorZonePodsStatus{
+		{Name: "zone-1", Ready: 1, NotReady: 1, CrashLooping: 1},
+	}, changed.Status.Pods.Zones)
+	degraded := meta.FindStatusCondition(changed.Status.Conditions, v1beta1.CollectorConditionDegraded)
+	require.NotNil(t, degraded)
+	assert.Equal(t, metav1.ConditionTrue, degraded.Status)
+	assert.Equal(t, "1 pods crashlooping, last termination of app-2: invalid configuration", degraded.Message)
+}
@@ -0,0 +1,373 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/expfmt"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+)
+
+const (
+	defaultBakeDuration     = 5 * time.Minute
+	defaultKnownGoodAfter   = 5 * time.Minute
+	defaultProgressDeadline = 10 * time.Minute
+	// rolloutCheckInterval is how often the health of the pods is checked while a configuration is rolled out.
+	rolloutCheckInterval = 30 * time.Second
+
+	reasonRolloutStarted    = "RolloutStarted"
+	reasonRolloutPromoted   = "RolloutPromoted"
+	reasonRolloutKnownGood  = "RolloutKnownGood"
+	reasonRolloutRolledBack = "RolloutRolledBack"
+)
+
+// scrapeExportCounters returns how many items the collector behind the given metrics endpoint sent and failed to send
+// through its exporters. It's a variable so tests can replace it.
+var scrapeExportCounters = func(ctx context.Context, url string) (sent float64, failed float64, err error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, 0, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, 0, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(resp.Body)
+	if err != nil {
+		return 0, 0, err
+	}
+	for name, family := range families {
+		var sum float64
+		for _, m := range family.GetMetric() {
+			sum += m.GetCounter().GetValue() + m.GetUntyped().GetValue()
+		}
+		switch {
+		case strings.HasPrefix(name, "otelcol_exporter_send_failed_"):
+			failed += sum
+		case strings.HasPrefix(name, "otelcol_exporter_sent_"):
+			sent += sum
+		}
+	}
+	return sent, failed, nil
+}
+
+// canaryState is what's known about the health of the canary replicas.
+type canaryState struct {
+	ready int32
+	// failure is why the canary replicas are unhealthy, if they are
+	failure string
+	// metricsErr is why the export failures of the canary replicas couldn't be checked, if they couldn't
+	metricsErr error
+}
+
+// updateRolloutStatus moves the rollout of the collector's configuration forward, and returns how long to wait before
+// checking on it again.
+func updateRolloutStatus(ctx context.Context, params manifests.Params, changed *v1beta1.OpenTelemetryCollector) (time.Duration, error) {
+	requeueAfter, err := progressRollout(ctx, params, changed)
+	if err != nil {
+		return 0, err
+	}
+	rollout := changed.Status.Rollout
+	switch {
+	case rollout == nil:
+		meta.RemoveStatusCondition(&changed.Status.Conditions, v1beta1.CollectorConditionConfigRolledBack)
+	case rollout.Phase == v1beta1.RolloutPhaseRolledBack:
+		setCondition(changed, v1beta1.CollectorConditionConfigRolledBack, metav1.ConditionTrue, string(rollout.Phase), rollout.Message)
+	default:
+		setCondition(changed, v1beta1.CollectorConditionConfigRolledBack, metav1.ConditionFalse, string(rollout.Phase), rollout.Message)
+	}
+	return requeueAfter, nil
+}
+
+// configHash returns the hash of the configuration being rolled out. The manifests are built from the configuration
+// of the params, which has the base configuration and the patches applied, so it's the one the pods are annotated
+// with rather than the one of the spec.
+func configHash(params manifests.Params) (string, error) {
+	return manifestutils.GetConfigMapSHA(params.OtelCol.Spec.Config)
+}
+
+func progressRollout(ctx context.Context, params manifests.Params, changed *v1beta1.OpenTelemetryCollector) (time.Duration, error) {
+	if !collector.RolloutTracked(*changed) {
+		changed.Status.Rollout = nil
+		return 0, nil
+	}
+	hash, err := configHash(params)
+	if err != nil {
+		return 0, err
+	}
+	canary := collector.CanaryEnabled(*changed)
+
+	rollout := changed.Status.Rollout
+	switch {
+	case rollout == nil && !canary:
+		// the configuration isn't known to be good yet, there's nothing to roll back to until it is
+		rollout = &v1beta1.RolloutStatus{}
+		changed.Status.Rollout = rollout
+	case rollout == nil || rollout.StableConfigHash == "" && canary:
+		// nothing to bake, the replicas run the configuration of the spec already
+		changed.Status.Rollout = &v1beta1.RolloutStatus{
+			Phase:            v1beta1.RolloutPhaseStable,
+			StableConfigHash: hash,
+		}
+		return 0, nil
+	case rollout.StableConfigHash == hash:
+		if rollout.Phase != v1beta1.RolloutPhaseStable {
+			changed.Status.Rollout = &v1beta1.RolloutStatus{
+				Phase:            v1beta1.RolloutPhaseStable,
+				StableConfigHash: hash,
+				Message:          "the configuration was changed back to the stable one",
+			}
+		}
+		return 0, nil
+	case rollout.CanaryConfigHash == hash && rollout.Phase == v1beta1.RolloutPhaseRolledBack:
+		return 0, nil
+	}
+
+	if rollout.CanaryConfigHash != hash || rollout.Phase != v1beta1.RolloutPhaseProgressing {
+		now := metav1.Now()
+		rollout.Phase = v1beta1.RolloutPhaseProgressing
+		rollout.CanaryConfigHash = hash
+		rollout.StartedAt = &now
+		if canary {
+			rollout.Message = fmt.Sprintf("baking the new configuration on %d canary replicas", collector.CanaryReplicas(*changed))
+		} else {
+			rollout.Message = "rolling the new configuration out, it isn't known to be good yet"
+		}
+		params.Recorder.Event(changed, eventTypeNormal, reasonRolloutStarted, rollout.Message)
+		return rolloutCheckInterval, nil
+	}
+
+	if canary {
+		return bakeCanary(ctx, params, changed, hash)
+	}
+	return watchRollout(ctx, params, changed, hash)
+}
+
+func bakeCanary(ctx context.Context, params manifests.Params, changed *v1beta1.OpenTelemetryCollector, hash string) (time.Duration, error) {
+	state, err := checkCanary(ctx, params.Reader, changed, hash)
+	if err != nil {
+		return 0, err
+	}
+	if state.failure != "" {
+		rollBack(params, changed, state.failure)
+		return 0, nil
+	}
+
+	bake := defaultBakeDuration
+	if changed.Spec.Rollout.Canary.BakeDuration.Duration > 0 {
+		bake = changed.Spec.Rollout.Canary.BakeDuration.Duration
+	}
+	if remaining := bake - time.Since(changed.Status.Rollout.StartedAt.Time); remaining > 0 {
+		return min(remaining, rolloutCheckInterval), nil
+	}
+
+	replicas := collector.CanaryReplicas(*changed)
+	switch {
+	case state.ready < replicas:
+		rollBack(params, changed, fmt.Sprintf("only %d/%d canary replicas were ready after %s", state.ready, replicas, bake))
+	case state.metricsErr != nil:
+		rollBack(params, changed, fmt.Sprintf("couldn't check the export failures of the canary replicas: %s", state.metricsErr))
+	default:
+		changed.Status.Rollout = &v1beta1.RolloutStatus{
+			Phase:            v1beta1.RolloutPhaseStable,
+			StableConfigHash: hash,
+			Message:          fmt.Sprintf("the canary replicas stayed healthy for %s, promoted the new configuration", bake),
+		}
+		params.Recorder.Event(changed, eventTypeNormal, reasonRolloutPromoted, changed.Status.Rollout.Message)
+	}
+	return 0, nil
+}
+
+// watchRollout marks the configuration being rolled out to every replica as known-good once all pods run it and stayed
+// ready for long enough, or rolls it back when pods running it are still unhealthy past the progress deadline.
+func watchRollout(ctx context.Context, params manifests.Params, changed *v1beta1.OpenTelemetryCollector, hash string) (time.Duration, error) {
+	knownGoodAfter := defaultKnownGoodAfter
+	if changed.Spec.Rollout.AutoRollback.KnownGoodAfter.Duration > 0 {
+		knownGoodAfter = changed.Spec.Rollout.AutoRollback.KnownGoodAfter.Duration
+	}
+	deadline := defaultProgressDeadline
+	if changed.Spec.Rollout.AutoRollback.ProgressDeadline.Duration > 0 {
+		deadline = changed.Spec.Rollout.AutoRollback.ProgressDeadline.Duration
+	}
+
+	state, err := checkRollout(ctx, params.Reader, changed, hash)
+	if err != nil {
+		return 0, err
+	}
+	rollout := changed.Status.Rollout
+	switch {
+	case state.unhealthy == "":
+		if !state.complete {
+			return rolloutCheckInterval, nil
+		}
+		if remaining := knownGoodAfter - time.Since(state.readySince); remaining > 0 {
+			return min(remaining, rolloutCheckInterval), nil
+		}
+		changed.Status.Rollout = &v1beta1.RolloutStatus{
+			Phase:            v1beta1.RolloutPhaseStable,
+			StableConfigHash: hash,
+			Message:          fmt.Sprintf("every pod was ready for %s, the configuration is known to be good", knownGoodAfter),
+		}
+		params.Recorder.Event(changed, eventTypeNormal, reasonRolloutKnownGood, changed.Status.Rollout.Message)
+		return 0, nil
+	case time.Since(rollout.StartedAt.Time) < deadline:
+		return rolloutCheckInterval, nil
+	case rollout.StableConfigHash == "":
+		rollout.Message = fmt.Sprintf("%s after %s, but there's no known-good configuration to roll back to", state.unhealthy, deadline)
+		return rolloutCheckInterval, nil
+	default:
+		rollBack(params, changed, fmt.Sprintf("%s after %s", state.unhealthy, deadline))
+		return 0, nil
+	}
+}
+
+func rollBack(params manifests.Params, changed *v1beta1.OpenTelemetryCollector, reason string) {
+	changed.Status.Rollout.Phase = v1beta1.RolloutPhaseRolledBack
+	changed.Status.Rollout.Message = reason
+	params.Recorder.Event(changed, eventTypeWarning, reasonRolloutRolledBack, "rolled back the new configuration: "+reason)
+}
+
+// checkCanary looks at the pods running the configuration with the given hash. A single restart of their collector
+// container is enough to roll the configuration back.
+func checkCanary(ctx context.Context, reader client.Reader, otelcol *v1beta1.OpenTelemetryCollector, hash string) (canaryState, error) {
+	state := canaryState{}
+	pods, err := listCollectorPods(ctx, reader, otelcol)
+	if err != nil {
+		return state, err
+	}
+	var canaries []corev1.Pod
+	for _, pod := range pods {
+		if pod.DeletionTimestamp == nil && pod.Annotations[manifestutils.ConfigHashAnnotation] == hash {
+			canaries = append(canaries, pod)
+		}
+	}
+
+	for i := range canaries {
+		container := collectorContainerStatus(&canaries[i])
+		if container == nil {
+			continue
+		}
+		if container.RestartCount > 0 {
+			state.failure = fmt.Sprintf("the collector container of canary pod %s restarted", canaries[i].Name)
+			if terminated := container.LastTerminationState.Terminated; terminated != nil && terminated.Message != "" {
+				state.failure += ": " + terminated.Message
+			}
+			return state, nil
+		}
+		if container.Ready {
+			state.ready++
+		}
+	}
+
+	maxFailures := otelcol.Spec.Rollout.Canary.MaxExportFailurePercent
+	if maxFailures == nil {
+		return state, nil
+	}
+	port, err := otelcol.Spec.Config.Service.MetricsPort()
+	if err != nil {
+		state.metricsErr = err
+		return state, nil
+	}
+	var sent, failed float64
+	for _, pod := range canaries {
+		if pod.Status.PodIP == "" {
+			continue
+		}
+		url := fmt.Sprintf("http://%s/metrics", net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(int(port))))
+		podSent, podFailed, scrapeErr := scrapeExportCounters(ctx, url)
+		if scrapeErr != nil {
+			state.metricsErr = fmt.Errorf("pod %s: %w", pod.Name, scrapeErr)
+			continue
+		}
+		sent += podSent
+		failed += podFailed
+	}
+	if total := sent + failed; total > 0 && failed/total*100 > float64(*maxFailures) {
+		state.failure = fmt.Sprintf("the canary replicas failed to export %.1f%% of the telemetry, more than the %d%% allowed", failed/total*100, *maxFailures)
+	}
+	return state, nil
+}
+
+// rolloutState is what's known about the pods while a configuration is rolled out to every replica.
+type rolloutState struct {
+	// complete is whether every pod runs the configuration and is ready
+	complete bool
+	// readySince is when the last pod became ready
+	readySince time.Time
+	// unhealthy is why some of the pods running the configuration are unhealthy, if they are
+	unhealthy string
+}
+
+func checkRollout(ctx context.Context, reader client.Reader, otelcol *v1beta1.OpenTelemetryCollector, hash string) (rolloutState, error) {
+	state := rolloutState{}
+	pods, err := listCollectorPods(ctx, reader, otelcol)
+	if err != nil {
+		return state, err
+	}
+	outdated := 0
+	running := 0
+	for i := range pods {
+		pod := &pods[i]
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		if pod.Annotations[manifestutils.ConfigHashAnnotation] != hash {
+			outdated++
+			continue
+		}
+		running++
+		container := collectorContainerStatus(pod)
+		switch {
+		case container != nil && container.State.Waiting != nil && container.State.Waiting.Reason == reasonCrashLoopBackOff:
+			state.unhealthy = fmt.Sprintf("the collector container of pod %s was crashlooping", pod.Name)
+			if terminated := container.LastTerminationState.Terminated; terminated != nil && terminated.Message != "" {
+				state.unhealthy += ": " + terminated.Message
+			}
+		case container == nil || !container.Ready:
+			if state.unhealthy == "" {
+				state.unhealthy = fmt.Sprintf("the collector container of pod %s wasn't ready", pod.Name)
+			}
+		}
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == corev1.PodReady && condition.Status == corev1.ConditionTrue && condition.LastTransitionTime.After(state.readySince) {
+				state.readySince = condition.LastTransitionTime.Time
+			}
+		}
+	}
+	state.complete = running > 0 && outdated == 0 && state.unhealthy == ""
+	return state, nil
+}
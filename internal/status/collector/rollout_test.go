@@ -0,0 +1,354 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+)
+
+func canaryPod(name, hash string, status corev1.ContainerStatus) *corev1.Pod {
+	pod := collectorPod(name, "node-a", map[string]string{
+		"app.kubernetes.io/managed-by": "opentelemetry-operator",
+		"app.kubernetes.io/instance":   "default.test",
+		"app.kubernetes.io/part-of":    "opentelemetry",
+		"app.kubernetes.io/component":  "opentelemetry-collector",
+	}, status)
+	pod.Annotations = map[string]string{manifestutils.ConfigHashAnnotation: hash}
+	pod.Status.PodIP = "10.0.0.1"
+	return pod
+}
+
+func TestUpdateRolloutStatus(t *testing.T) {
+	scrape := scrapeExportCounters
+	t.Cleanup(func() { scrapeExportCounters = scrape })
+	maxFailures := int32(10)
+	otelcol := v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+		},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode: v1beta1.ModeDeployment,
+			Rollout: &v1beta1.RolloutSpec{
+				Strategy: v1beta1.RolloutStrategyCanary,
+				Canary: v1beta1.CanaryRolloutSpec{
+					Replicas:                1,
+					BakeDuration:            metav1.Duration{Duration: time.Minute},
+					MaxExportFailurePercent: &maxFailures,
+				},
+			},
+		},
+	}
+	hash, err := manifestutils.GetConfigMapSHA(otelcol.Spec.Config)
+	require.NoError(t, err)
+	stable := strings.Repeat("a", 64)
+	started := metav1.NewTime(time.Now().Add(-2 * time.Minute))
+	progressing := func() *v1beta1.RolloutStatus {
+		return &v1beta1.RolloutStatus{
+			Phase:            v1beta1.RolloutPhaseProgressing,
+			StableConfigHash: stable,
+			CanaryConfigHash: hash,
+			StartedAt:        &started,
+		}
+	}
+
+	for _, tc := range []struct {
+		name     string
+		status   *v1beta1.RolloutStatus
+		pod      *corev1.Pod
+		failures float64
+		phase    v1beta1.RolloutPhase
+		stable   string
+		requeue  bool
+	}{
+		{
+			name:   "first reconcile",
+			phase:  v1beta1.RolloutPhaseStable,
+			stable: hash,
+		},
+		{
+			name: "configuration changed",
+			status: &v1beta1.RolloutStatus{
+				Phase:            v1beta1.RolloutPhaseStable,
+				StableConfigHash: stable,
+			},
+			phase:   v1beta1.RolloutPhaseProgressing,
+			stable:  stable,
+			requeue: true,
+		},
+		{
+			name:   "baked",
+			status: progressing(),
+			pod:    canaryPod("canary", hash, corev1.ContainerStatus{Ready: true}),
+			phase:  v1beta1.RolloutPhaseStable,
+			stable: hash,
+		},
+		{
+			name:   "canary restarted",
+			status: progressing(),
+			pod:    canaryPod("canary", hash, corev1.ContainerStatus{RestartCount: 1}),
+			phase:  v1beta1.RolloutPhaseRolledBack,
+			stable: stable,
+		},
+		{
+			name:   "canary never ready",
+			status: progressing(),
+			pod:    canaryPod("canary", hash, corev1.ContainerStatus{}),
+			phase:  v1beta1.RolloutPhaseRolledBack,
+			stable: stable,
+		},
+		{
+			name:     "canary failing to export",
+			status:   progressing(),
+			pod:      canaryPod("canary", hash, corev1.ContainerStatus{Ready: true}),
+			failures: 50,
+			phase:    v1beta1.RolloutPhaseRolledBack,
+			stable:   stable,
+		},
+		{
+			name: "still baking",
+			status: &v1beta1.RolloutStatus{
+				Phase:            v1beta1.RolloutPhaseProgressing,
+				StableConfigHash: stable,
+				CanaryConfigHash: hash,
+				StartedAt:        &metav1.Time{Time: time.Now()},
+			},
+			pod:     canaryPod("canary", hash, corev1.ContainerStatus{Ready: true}),
+			phase:   v1beta1.RolloutPhaseProgressing,
+			stable:  stable,
+			requeue: true,
+		},
+		{
+			name: "rolled back",
+			status: &v1beta1.RolloutStatus{
+				Phase:            v1beta1.RolloutPhaseRolledBack,
+				StableConfigHash: stable,
+				CanaryConfigHash: hash,
+			},
+			phase:  v1beta1.RolloutPhaseRolledBack,
+			stable: stable,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			scrapeExportCounters = func(context.Context, string) (float64, float64, error) {
+				return 100 - tc.failures, tc.failures, nil
+			}
+			builder := fake.NewClientBuilder()
+			if tc.pod != nil {
+				builder = builder.WithObjects(tc.pod)
+			}
+			changed := otelcol.DeepCopy()
+			changed.Status.Rollout = tc.status
+			cli := builder.Build()
+			params := manifests.Params{
+				Client:   cli,
+				Reader:   cli,
+				Recorder: record.NewFakeRecorder(10),
+				OtelCol:  *changed,
+			}
+
+			requeue, err := updateRolloutStatus(context.Background(), params, changed)
+			require.NoError(t, err)
+
+			require.NotNil(t, changed.Status.Rollout)
+			assert.Equal(t, tc.phase, changed.Status.Rollout.Phase, changed.Status.Rollout.Message)
+			assert.Equal(t, tc.stable, changed.Status.Rollout.StableConfigHash)
+			assert.Equal(t, tc.requeue, requeue > 0)
+		})
+	}
+}
+
+func readyPod(name, hash string, since time.Time) *corev1.Pod {
+	pod := canaryPod(name, hash, corev1.ContainerStatus{Ready: true})
+	pod.Status.Conditions = []corev1.PodCondition{{
+		Type:               corev1.PodReady,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.NewTime(since),
+	}}
+	return pod
+}
+
+func TestUpdateRolloutStatusAutoRollback(t *testing.T) {
+	otelcol := v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+		},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode: v1beta1.ModeDeployment,
+			Rollout: &v1beta1.RolloutSpec{
+				AutoRollback: v1beta1.AutoRollbackSpec{
+					Enabled:          true,
+					KnownGoodAfter:   metav1.Duration{Duration: time.Minute},
+					ProgressDeadline: metav1.Duration{Duration: 5 * time.Minute},
+				},
+			},
+		},
+	}
+	hash, err := manifestutils.GetConfigMapSHA(otelcol.Spec.Config)
+	require.NoError(t, err)
+	stable := strings.Repeat("a", 64)
+	progressing := func(stable string, started time.Time) *v1beta1.RolloutStatus {
+		return &v1beta1.RolloutStatus{
+			Phase:            v1beta1.RolloutPhaseProgressing,
+			StableConfigHash: stable,
+			CanaryConfigHash: hash,
+			StartedAt:        &metav1.Time{Time: started},
+		}
+	}
+	crashlooping := canaryPod("crashlooping", hash, corev1.ContainerStatus{
+		RestartCount: 3,
+		State: corev1.ContainerState{
+			Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+		},
+	})
+
+	for _, tc := range []struct {
+		name       string
+		status     *v1beta1.RolloutStatus
+		pods       []*corev1.Pod
+		phase      v1beta1.RolloutPhase
+		stable     string
+		rolledBack metav1.ConditionStatus
+		requeue    bool
+	}{
+		{
+			name:       "first reconcile",
+			phase:      v1beta1.RolloutPhaseProgressing,
+			rolledBack: metav1.ConditionFalse,
+			requeue:    true,
+		},
+		{
+			name:       "known-good",
+			status:     progressing(stable, time.Now().Add(-3*time.Minute)),
+			pods:       []*corev1.Pod{readyPod("ready", hash, time.Now().Add(-2*time.Minute))},
+			phase:      v1beta1.RolloutPhaseStable,
+			stable:     hash,
+			rolledBack: metav1.ConditionFalse,
+		},
+		{
+			name:       "ready too recently",
+			status:     progressing(stable, time.Now().Add(-3*time.Minute)),
+			pods:       []*corev1.Pod{readyPod("ready", hash, time.Now())},
+			phase:      v1beta1.RolloutPhaseProgressing,
+			stable:     stable,
+			rolledBack: metav1.ConditionFalse,
+			requeue:    true,
+		},
+		{
+			name:   "still rolling out",
+			status: progressing(stable, time.Now().Add(-3*time.Minute)),
+			pods: []*corev1.Pod{
+				readyPod("ready", hash, time.Now().Add(-2*time.Minute)),
+				readyPod("outdated", stable, time.Now().Add(-time.Hour)),
+			},
+			phase:      v1beta1.RolloutPhaseProgressing,
+			stable:     stable,
+			rolledBack: metav1.ConditionFalse,
+			requeue:    true,
+		},
+		{
+			name:       "crashlooping within the deadline",
+			status:     progressing(stable, time.Now().Add(-3*time.Minute)),
+			pods:       []*corev1.Pod{crashlooping},
+			phase:      v1beta1.RolloutPhaseProgressing,
+			stable:     stable,
+			rolledBack: metav1.ConditionFalse,
+			requeue:    true,
+		},
+		{
+			name:       "crashlooping past the deadline",
+			status:     progressing(stable, time.Now().Add(-10*time.Minute)),
+			pods:       []*corev1.Pod{crashlooping},
+			phase:      v1beta1.RolloutPhaseRolledBack,
+			stable:     stable,
+			rolledBack: metav1.ConditionTrue,
+		},
+		{
+			name:       "nothing known-good to roll back to",
+			status:     progressing("", time.Now().Add(-10*time.Minute)),
+			pods:       []*corev1.Pod{crashlooping},
+			phase:      v1beta1.RolloutPhaseProgressing,
+			rolledBack: metav1.ConditionFalse,
+			requeue:    true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			builder := fake.NewClientBuilder()
+			for _, pod := range tc.pods {
+				builder = builder.WithObjects(pod.DeepCopy())
+			}
+			changed := otelcol.DeepCopy()
+			changed.Status.Rollout = tc.status
+			cli := builder.Build()
+			params := manifests.Params{
+				Client:   cli,
+				Reader:   cli,
+				Recorder: record.NewFakeRecorder(10),
+				OtelCol:  *changed,
+			}
+
+			requeue, err := updateRolloutStatus(context.Background(), params, changed)
+			require.NoError(t, err)
+
+			require.NotNil(t, changed.Status.Rollout)
+			assert.Equal(t, tc.phase, changed.Status.Rollout.Phase, changed.Status.Rollout.Message)
+			assert.Equal(t, tc.stable, changed.Status.Rollout.StableConfigHash)
+			assert.Equal(t, tc.requeue, requeue > 0)
+			condition := meta.FindStatusCondition(changed.Status.Conditions, v1beta1.CollectorConditionConfigRolledBack)
+			require.NotNil(t, condition)
+			assert.Equal(t, tc.rolledBack, condition.Status)
+		})
+	}
+}
+
+func TestConfigHash(t *testing.T) {
+	spec := v1beta1.Config{
+		Exporters: v1beta1.AnyConfig{Object: map[string]interface{}{"debug": nil}},
+	}
+	merged := v1beta1.Config{
+		Receivers: v1beta1.AnyConfig{Object: map[string]interface{}{"otlp": nil}},
+		Exporters: v1beta1.AnyConfig{Object: map[string]interface{}{"debug": nil}},
+	}
+	changed := &v1beta1.OpenTelemetryCollector{Spec: v1beta1.OpenTelemetryCollectorSpec{Config: spec}}
+	params := manifests.Params{OtelCol: *changed.DeepCopy()}
+	params.OtelCol.Spec.Config = merged
+
+	hash, err := configHash(params)
+	require.NoError(t, err)
+
+	// the pods are annotated with the hash of the configuration the manifests are built from
+	mergedHash, err := manifestutils.GetConfigMapSHA(merged)
+	require.NoError(t, err)
+	assert.Equal(t, mergedHash, hash)
+	specHash, err := manifestutils.GetConfigMapSHA(changed.Spec.Config)
+	require.NoError(t, err)
+	assert.NotEqual(t, specHash, hash)
+}
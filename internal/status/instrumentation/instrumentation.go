@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"context"
+	"sort"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/instrumentation"
+)
+
+// maxWorkloads is the maximum number of workloads listed in the status, for it to stay small when the instrumentation
+// is injected into many workloads.
+const maxWorkloads = 100
+
+// languageKey identifies an auto-instrumentation of a workload, the image carrying its version.
+type languageKey struct {
+	language v1alpha1.InstrumentationLanguage
+	image    string
+}
+
+// UpdateInstrumentationStatus aggregates per workload the injections of the instrumentation recorded on the pods.
+func UpdateInstrumentationStatus(ctx context.Context, cli client.Client, changed *v1alpha1.Instrumentation) error {
+	key := client.ObjectKeyFromObject(changed).String()
+	pods, err := instrumentation.ListInjectedPods(ctx, cli, changed)
+	if err != nil {
+		return err
+	}
+
+	// the pods are visited from the oldest, for the error of the most recent one to be reported
+	sort.SliceStable(pods, func(i, j int) bool {
+		return pods[i].CreationTimestamp.Before(&pods[j].CreationTimestamp)
+	})
+
+	workloads := map[instrumentation.Workload]*v1alpha1.InstrumentedWorkload{}
+	containers := map[instrumentation.Workload]map[languageKey]map[string]bool{}
+	for i := range pods {
+		pod := &pods[i]
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		injected, lastError := false, ""
+		langContainers := map[languageKey][]string{}
+		for _, injection := range instrumentation.Injections(pod) {
+			if injection.Instrumentation != key {
+				continue
+			}
+			if len(injection.Containers) > 0 {
+				injected = true
+				lk := languageKey{language: injection.Language, image: injection.Image}
+				langContainers[lk] = append(langContainers[lk], injection.Containers...)
+			}
+			if injection.Error != "" {
+				lastError = injection.Error
+			}
+		}
+		if !injected && lastError == "" {
+			continue
+		}
+
+		wk, err := instrumentation.OwningWorkload(ctx, cli, pod)
+		if err != nil {
+			return err
+		}
+		workload, ok := workloads[wk]
+		if !ok {
+			workload = &v1alpha1.InstrumentedWorkload{Kind: wk.Kind, Name: wk.Name, Namespace: wk.Namespace}
+			workloads[wk] = workload
+			containers[wk] = map[languageKey]map[string]bool{}
+		}
+		if injected {
+			workload.Pods++
+		}
+		if lastError != "" {
+			workload.Failures++
+			workload.LastError = lastError
+		}
+		for lk, names := range langContainers {
+			if containers[wk][lk] == nil {
+				containers[wk][lk] = map[string]bool{}
+			}
+			for _, name := range names {
+				containers[wk][lk][name] = true
+			}
+		}
+	}
+
+	var status []v1alpha1.InstrumentedWorkload
+	for wk, workload := range workloads {
+		for lk, names := range containers[wk] {
+			language := v1alpha1.InstrumentedLanguage{Language: lk.language, Image: lk.image}
+			for name := range names {
+				language.Containers = append(language.Containers, name)
+			}
+			sort.Strings(language.Containers)
+			workload.Languages = append(workload.Languages, language)
+		}
+		sort.Slice(workload.Languages, func(i, j int) bool {
+			if workload.Languages[i].Language != workload.Languages[j].Language {
+				return workload.Languages[i].Language < workload.Languages[j].Language
+			}
+			return workload.Languages[i].Image < workload.Languages[j].Image
+		})
+		status = append(status, *workload)
+	}
+	// the workloads with failures are listed first, for them not to be left out when the list is capped
+	sort.Slice(status, func(i, j int) bool {
+		if failing := status[i].Failures > 0; failing != (status[j].Failures > 0) {
+			return failing
+		}
+		if status[i].Namespace != status[j].Namespace {
+			return status[i].Namespace < status[j].Namespace
+		}
+		if status[i].Kind != status[j].Kind {
+			return status[i].Kind < status[j].Kind
+		}
+		return status[i].Name < status[j].Name
+	})
+	changed.Status.WorkloadCount = int32(len(status))
+	if len(status) > maxWorkloads {
+		status = status[:maxWorkloads]
+	}
+	changed.Status.Workloads = status
+	return nil
+}
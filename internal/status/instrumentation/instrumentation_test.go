@@ -0,0 +1,186 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/instrumentation"
+)
+
+func controllerRef(kind, name string) []metav1.OwnerReference {
+	isController := true
+	return []metav1.OwnerReference{{Kind: kind, Name: name, Controller: &isController}}
+}
+
+func injectedPod(name string, created time.Time, owners []metav1.OwnerReference, injections string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "apps",
+			CreationTimestamp: metav1.NewTime(created),
+			OwnerReferences:   owners,
+			Annotations:       map[string]string{instrumentation.InjectionStatusAnnotation: injections},
+		},
+	}
+}
+
+func TestUpdateInstrumentationStatus(t *testing.T) {
+	// prepare
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(v1alpha1.AddToScheme(scheme))
+
+	now := time.Now()
+	inst := &v1alpha1.Instrumentation{ObjectMeta: metav1.ObjectMeta{Name: "java", Namespace: "observability"}}
+	rs := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "checkout-5d4f8",
+			Namespace:       "apps",
+			OwnerReferences: controllerRef("Deployment", "checkout"),
+		},
+	}
+	objects := []client.Object{
+		rs,
+		injectedPod("checkout-5d4f8-a", now.Add(-2*time.Minute), controllerRef("ReplicaSet", "checkout-5d4f8"),
+			`[{"instrumentation":"observability/java","language":"java","image":"otel/java:1.32","containers":["app"]}]`),
+		injectedPod("checkout-5d4f8-b", now.Add(-time.Minute), controllerRef("ReplicaSet", "checkout-5d4f8"),
+			`[{"instrumentation":"observability/java","language":"java","image":"otel/java:2.1","containers":["app","worker"]}]`),
+		injectedPod("checkout-5d4f8-c", now, controllerRef("ReplicaSet", "checkout-5d4f8"),
+			`[{"instrumentation":"observability/java","language":"java","error":"support for Java auto instrumentation is not enabled"}]`),
+		injectedPod("ledger-0", now, controllerRef("StatefulSet", "ledger"),
+			`[{"instrumentation":"observability/java","language":"java","image":"otel/java:2.1","containers":["ledger"],"error":"container sidecar: the container defines env var value via ValueFrom, envVar: JAVA_TOOL_OPTIONS"}]`),
+		injectedPod("debug", now, nil,
+			`[{"instrumentation":"observability/java","language":"java","image":"otel/java:2.1","containers":["debug"]}]`),
+		injectedPod("cart-7c9d6-a", now, controllerRef("ReplicaSet", "cart-7c9d6"),
+			`[{"instrumentation":"observability/python","language":"python","image":"otel/python:1","containers":["app"]}]`),
+	}
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objects...).
+		WithIndex(instrumentation.PodMetadata(), instrumentation.InjectedPodsIndex, instrumentation.InjectedPodsIndexValues).
+		Build()
+
+	// test
+	err := UpdateInstrumentationStatus(context.Background(), cli, inst)
+	require.NoError(t, err)
+
+	// verify
+	assert.Equal(t, []v1alpha1.InstrumentedWorkload{
+		{
+			Kind:      "Deployment",
+			Name:      "checkout",
+			Namespace: "apps",
+			Languages: []v1alpha1.InstrumentedLanguage{
+				{Language: v1alpha1.InstrumentationLanguageJava, Image: "otel/java:1.32", Containers: []string{"app"}},
+				{Language: v1alpha1.InstrumentationLanguageJava, Image: "otel/java:2.1", Containers: []string{"app", "worker"}},
+			},
+			Pods:      2,
+			Failures:  1,
+			LastError: "support for Java auto instrumentation is not enabled",
+		},
+		{
+			Kind:      "StatefulSet",
+			Name:      "ledger",
+			Namespace: "apps",
+			Languages: []v1alpha1.InstrumentedLanguage{
+				{Language: v1alpha1.InstrumentationLanguageJava, Image: "otel/java:2.1", Containers: []string{"ledger"}},
+			},
+			Pods:      1,
+			Failures:  1,
+			LastError: "container sidecar: the container defines env var value via ValueFrom, envVar: JAVA_TOOL_OPTIONS",
+		},
+		{
+			Kind:      "Pod",
+			Name:      "debug",
+			Namespace: "apps",
+			Languages: []v1alpha1.InstrumentedLanguage{
+				{Language: v1alpha1.InstrumentationLanguageJava, Image: "otel/java:2.1", Containers: []string{"debug"}},
+			},
+			Pods: 1,
+		},
+	}, inst.Status.Workloads)
+	assert.Equal(t, int32(3), inst.Status.WorkloadCount)
+}
+
+func TestUpdateInstrumentationStatusCapsWorkloads(t *testing.T) {
+	// prepare
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(v1alpha1.AddToScheme(scheme))
+	inst := &v1alpha1.Instrumentation{ObjectMeta: metav1.ObjectMeta{Name: "java", Namespace: "observability"}}
+	now := time.Now()
+	var objects []client.Object
+	for i := 0; i < maxWorkloads+5; i++ {
+		objects = append(objects, injectedPod(fmt.Sprintf("pod-%03d", i), now, nil,
+			`[{"instrumentation":"observability/java","language":"java","image":"otel/java:2.1","containers":["app"]}]`))
+	}
+	objects = append(objects, injectedPod("zz-failing", now, nil,
+		`[{"instrumentation":"observability/java","language":"java","error":"support for Java auto instrumentation is not enabled"}]`))
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objects...).
+		WithIndex(instrumentation.PodMetadata(), instrumentation.InjectedPodsIndex, instrumentation.InjectedPodsIndexValues).
+		Build()
+
+	// test
+	err := UpdateInstrumentationStatus(context.Background(), cli, inst)
+	require.NoError(t, err)
+
+	// verify
+	require.Len(t, inst.Status.Workloads, maxWorkloads)
+	assert.Equal(t, int32(maxWorkloads+6), inst.Status.WorkloadCount)
+	assert.Equal(t, "zz-failing", inst.Status.Workloads[0].Name)
+	assert.Equal(t, "pod-000", inst.Status.Workloads[1].Name)
+}
+
+func TestUpdateInstrumentationStatusNoPods(t *testing.T) {
+	// prepare
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(v1alpha1.AddToScheme(scheme))
+	inst := &v1alpha1.Instrumentation{
+		ObjectMeta: metav1.ObjectMeta{Name: "java", Namespace: "observability"},
+		Status: v1alpha1.InstrumentationStatus{
+			Workloads: []v1alpha1.InstrumentedWorkload{{Kind: "Deployment", Name: "checkout", Namespace: "apps", Pods: 1}},
+		},
+	}
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithIndex(instrumentation.PodMetadata(), instrumentation.InjectedPodsIndex, instrumentation.InjectedPodsIndexValues).
+		Build()
+
+	// test
+	err := UpdateInstrumentationStatus(context.Background(), cli, inst)
+	require.NoError(t, err)
+
+	// verify
+	assert.Empty(t, inst.Status.Workloads)
+}
@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package targetallocator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/targetallocator"
+)
+
+const (
+	eventTypeNormal  = "Normal"
+	eventTypeWarning = "Warning"
+
+	reasonError         = "Error"
+	reasonStatusFailure = "StatusFailure"
+	reasonInfo          = "Info"
+)
+
+func HandleReconcileStatus(ctx context.Context, log logr.Logger, params targetallocator.Params, err error) (ctrl.Result, error) {
+	log.V(2).Info("updating targetallocator status")
+	if err != nil {
+		params.Recorder.Event(&params.TargetAllocator, eventTypeWarning, reasonError, err.Error())
+		return ctrl.Result{}, err
+	}
+	changed := params.TargetAllocator.DeepCopy()
+
+	statusErr := UpdateTargetAllocatorStatus(ctx, params.Client, changed)
+	if statusErr != nil {
+		params.Recorder.Event(changed, eventTypeWarning, reasonStatusFailure, statusErr.Error())
+		return ctrl.Result{}, statusErr
+	}
+	statusPatch := client.MergeFrom(&params.TargetAllocator)
+	if err := params.Client.Status().Patch(ctx, changed, statusPatch); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to apply status changes to the TargetAllocator CR: %w", err)
+	}
+	params.Recorder.Event(changed, eventTypeNormal, reasonInfo, "applied status changes")
+	return ctrl.Result{}, nil
+}
@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package targetallocator
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+	"github.com/open-telemetry/opentelemetry-operator/internal/version"
+)
+
+func UpdateTargetAllocatorStatus(ctx context.Context, cli client.Client, changed *v1alpha1.TargetAllocator) error {
+	if changed.Status.Version == "" {
+		// a version is not set, otherwise let the upgrade mechanism take care of it!
+		changed.Status.Version = version.TargetAllocator()
+	}
+
+	objKey := client.ObjectKey{
+		Namespace: changed.GetNamespace(),
+		Name:      naming.TargetAllocator(changed.Name),
+	}
+	obj := &appsv1.Deployment{}
+	if err := cli.Get(ctx, objKey, obj); err != nil {
+		return fmt.Errorf("failed to get deployment status: %w", err)
+	}
+	changed.Status.Image = obj.Spec.Template.Spec.Containers[0].Image
+	return nil
+}
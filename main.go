@@ -53,8 +53,10 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/openshift"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/prometheus"
+	autoRBAC "github.com/open-telemetry/opentelemetry-operator/internal/autodetect/rbac"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/rbac"
+	"github.com/open-telemetry/opentelemetry-operator/internal/render"
 	"github.com/open-telemetry/opentelemetry-operator/internal/version"
 	"github.com/open-telemetry/opentelemetry-operator/internal/webhook/podmutation"
 	collectorupgrade "github.com/open-telemetry/opentelemetry-operator/pkg/collector/upgrade"
@@ -95,6 +97,15 @@ func stringFlagOrEnv(p *string, name string, envName string, defaultValue string
 }
 
 func main() {
+	// the render command builds the manifests of a collector offline, without starting the manager
+	if len(os.Args) > 1 && os.Args[1] == render.Command {
+		if err := render.Run(os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// registers any flags that underlying libraries might use
 	opts := zap.Options{}
 	flagset := featuregate.Flags(colfeaturegate.GlobalRegistry())
@@ -132,6 +143,7 @@ func main() {
 		autoInstrumentationGo            string
 		labelsFilter                     []string
 		annotationsFilter                []string
+		instrumentationAdminNamespaces   []string
 		webhookPort                      int
 		tlsOpt                           tlsConfig
 		encodeMessageKey                 string
@@ -169,6 +181,7 @@ func main() {
 	stringFlagOrEnv(&autoInstrumentationNginx, "auto-instrumentation-nginx-image", "RELATED_IMAGE_AUTO_INSTRUMENTATION_NGINX", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/autoinstrumentation-apache-httpd:%s", v.AutoInstrumentationNginx), "The default OpenTelemetry Nginx instrumentation image. This image is used when no image is specified in the CustomResource.")
 	pflag.StringArrayVar(&labelsFilter, "label", []string{}, "Labels to filter away from propagating onto deploys. It should be a string array containing patterns, which are literal strings optionally containing a * wildcard character. Example: --labels-filter=.*filter.out will filter out labels that looks like: label.filter.out: true")
 	pflag.StringArrayVar(&annotationsFilter, "annotations-filter", []string{}, "Annotations to filter away from propagating onto deploys. It should be a string array containing patterns, which are literal strings optionally containing a * wildcard character. Example: --annotations-filter=.*filter.out will filter out annotations that looks like: annotation.filter.out: true")
+	pflag.StringSliceVar(&instrumentationAdminNamespaces, "instrumentation-admin-namespaces", nil, "Comma-separated list of namespaces whose Instrumentations may select the pods of other namespaces with spec.selector.namespaceSelector, in addition to the namespace of the operator.")
 	pflag.StringVar(&tlsOpt.minVersion, "tls-min-version", "VersionTLS12", "Minimum TLS version supported. Value must match version names from https://golang.org/pkg/crypto/tls/#pkg-constants.")
 	pflag.StringSliceVar(&tlsOpt.cipherSuites, "tls-cipher-suites", nil, "Comma-separated list of cipher suites for the server. Values are from tls package constants (https://golang.org/pkg/crypto/tls/#pkg-constants). If omitted, the default Go cipher suites will be used")
 	pflag.StringVar(&encodeMessageKey, "zap-message-key", "message", "The message key to be used in the customized Log Encoder")
@@ -286,6 +299,12 @@ func main() {
 		os.Exit(1)
 	}
 
+	// the instrumentations of the namespace of the operator may select the pods of other namespaces too
+	if operatorNamespace, nsErr := autoRBAC.OperatorNamespace(); nsErr != nil {
+		setupLog.Info("the namespace of the operator couldn't be determined, only the instrumentation admin namespaces may select the pods of other namespaces", "error", nsErr.Error())
+	} else {
+		instrumentationAdminNamespaces = append(instrumentationAdminNamespaces, operatorNamespace)
+	}
 	cfg := config.New(
 		config.WithLogger(ctrl.Log.WithName("config")),
 		config.WithVersion(v),
@@ -310,11 +329,13 @@ func main() {
 		config.WithAutoDetect(ad),
 		config.WithLabelFilters(labelsFilter),
 		config.WithAnnotationFilters(annotationsFilter),
+		config.WithInstrumentationAdminNamespaces(instrumentationAdminNamespaces),
 	)
 	err = cfg.AutoDetect()
 	if err != nil {
 		setupLog.Error(err, "failed to autodetect config variables")
 	}
+
 	// Only add these to the scheme if they are available
 	if cfg.PrometheusCRAvailability() == prometheus.Available {
 		setupLog.Info("Prometheus CRDs are installed, adding to scheme.")
@@ -354,11 +375,12 @@ func main() {
 	}
 
 	if err = controllers.NewReconciler(controllers.Params{
-		Client:   mgr.GetClient(),
-		Log:      ctrl.Log.WithName("controllers").WithName("OpenTelemetryCollector"),
-		Scheme:   mgr.GetScheme(),
-		Config:   cfg,
-		Recorder: mgr.GetEventRecorderFor("opentelemetry-operator"),
+		Client:    mgr.GetClient(),
+		APIReader: mgr.GetAPIReader(),
+		Log:       ctrl.Log.WithName("controllers").WithName("OpenTelemetryCollector"),
+		Scheme:    mgr.GetScheme(),
+		Config:    cfg,
+		Recorder:  mgr.GetEventRecorderFor("opentelemetry-operator"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "OpenTelemetryCollector")
 		os.Exit(1)
@@ -375,6 +397,30 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err = controllers.NewInstrumentationReconciler(controllers.InstrumentationReconcilerParams{
+		Client:   mgr.GetClient(),
+		Log:      ctrl.Log.WithName("controllers").WithName("Instrumentation"),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("opentelemetry-operator"),
+		Config:   cfg,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Instrumentation")
+		os.Exit(1)
+	}
+
+	if featuregate.CollectorUsesTargetAllocatorCR.IsEnabled() {
+		if err = controllers.NewTargetAllocatorReconciler(controllers.TargetAllocatorReconcilerParams{
+			Client:   mgr.GetClient(),
+			Log:      ctrl.Log.WithName("controllers").WithName("TargetAllocator"),
+			Scheme:   mgr.GetScheme(),
+			Config:   cfg,
+			Recorder: mgr.GetEventRecorderFor("targetallocator"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "TargetAllocator")
+			os.Exit(1)
+		}
+	}
+
 	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
 		var crdMetrics *otelv1beta1.Metrics
 
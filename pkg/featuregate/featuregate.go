@@ -40,6 +40,22 @@ var (
 		featuregate.WithRegisterDescription("enables feature to set GOMEMLIMIT and GOMAXPROCS automatically"),
 		featuregate.WithRegisterFromVersion("v0.100.0"),
 	)
+	// CollectorUsesTargetAllocatorCR is the feature gate that enables the OpenTelemetryCollector reconciler to generate
+	// TargetAllocator CRs instead of generating the manifests for its resources directly.
+	CollectorUsesTargetAllocatorCR = featuregate.GlobalRegistry().MustRegister(
+		"operator.collector.targetallocatorcr",
+		featuregate.StageAlpha,
+		featuregate.WithRegisterDescription("causes collector reconciliation to create a target allocator CR instead of creating resources directly"),
+		featuregate.WithRegisterFromVersion("v0.103.0"),
+	)
+	// TargetAllocatorHighAvailability is the feature gate that makes target allocators with more than one replica elect
+	// a leader, which is the only replica to allocate targets.
+	TargetAllocatorHighAvailability = featuregate.GlobalRegistry().MustRegister(
+		"operator.targetallocator.highavailability",
+		featuregate.StageAlpha,
+		featuregate.WithRegisterDescription("enables leader election between target allocator replicas, requires the target allocator to be allowed to manage leases"),
+		featuregate.WithRegisterFromVersion("v0.103.0"),
+	)
 )
 
 // Flags creates a new FlagSet that represents the available featuregate flags using the supplied featuregate registry.
@@ -42,6 +42,8 @@ const (
 	annotationInjectApacheHttpdContainersName = "instrumentation.opentelemetry.io/apache-httpd-container-names"
 	annotationInjectNginx                     = "instrumentation.opentelemetry.io/inject-nginx"
 	annotationInjectNginxContainersName       = "instrumentation.opentelemetry.io/inject-nginx-container-names"
+	annotationInjectAuto                      = "instrumentation.opentelemetry.io/inject-auto"
+	annotationDetectedLanguages               = "instrumentation.opentelemetry.io/detected-languages"
 )
 
 // annotationValue returns the effective annotationInjectJava value, based on the annotations from the pod and namespace.
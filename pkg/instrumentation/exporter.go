@@ -0,0 +1,161 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+const (
+	envOtelExporterOTLPTracesEndpoint    = "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"
+	envOtelExporterOTLPMetricsEndpoint   = "OTEL_EXPORTER_OTLP_METRICS_ENDPOINT"
+	envOtelExporterOTLPLogsEndpoint      = "OTEL_EXPORTER_OTLP_LOGS_ENDPOINT"
+	envOtelExporterOTLPProtocol          = "OTEL_EXPORTER_OTLP_PROTOCOL"
+	envOtelExporterOTLPHeaders           = "OTEL_EXPORTER_OTLP_HEADERS"
+	envOtelExporterOTLPCertificate       = "OTEL_EXPORTER_OTLP_CERTIFICATE"
+	envOtelExporterOTLPClientCertificate = "OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE"
+	envOtelExporterOTLPClientKey         = "OTEL_EXPORTER_OTLP_CLIENT_KEY"
+	exporterSecretMountPath              = "/otel-auto-instrumentation-secret-"
+	exporterConfigMapMountPath           = "/otel-auto-instrumentation-configmap-"
+)
+
+// injectExporterConfig sets the per-signal endpoints, protocol, headers and certificates of the OTLP exporter on the
+// container, and mounts the Secret and ConfigMap holding the certificates. The env vars the container already sets
+// take precedence.
+func injectExporterConfig(exporter v1alpha1.Exporter, pod corev1.Pod, index int) corev1.Pod {
+	container := &pod.Spec.Containers[index]
+
+	container.Env = appendEnvIfMissing(container.Env, envOtelExporterOTLPTracesEndpoint, exporter.TracesEndpoint)
+	container.Env = appendEnvIfMissing(container.Env, envOtelExporterOTLPMetricsEndpoint, exporter.MetricsEndpoint)
+	container.Env = appendEnvIfMissing(container.Env, envOtelExporterOTLPLogsEndpoint, exporter.LogsEndpoint)
+	container.Env = appendEnvIfMissing(container.Env, envOtelExporterOTLPProtocol, string(exporter.Protocol))
+
+	if len(exporter.Headers) > 0 && getIndexOfEnv(container.Env, envOtelExporterOTLPHeaders) == -1 {
+		var headers []string
+		for _, header := range exporter.Headers {
+			if header.SecretKeyRef == nil {
+				headers = append(headers, fmt.Sprintf("%s=%s", header.Name, percentEncode(header.Value)))
+				continue
+			}
+			// the value is read from the secret into its own env var, which OTEL_EXPORTER_OTLP_HEADERS references
+			envName := header.EnvName()
+			if getIndexOfEnv(container.Env, envName) == -1 {
+				container.Env = append(container.Env, corev1.EnvVar{
+					Name:      envName,
+					ValueFrom: &corev1.EnvVarSource{SecretKeyRef: header.SecretKeyRef.DeepCopy()},
+				})
+			}
+			headers = append(headers, fmt.Sprintf("%s=$(%s)", header.Name, envName))
+		}
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name:  envOtelExporterOTLPHeaders,
+			Value: strings.Join(headers, ","),
+		})
+	}
+
+	tls := exporter.TLS
+	if tls == nil {
+		return pod
+	}
+	secretPath, configMapPath := "", ""
+	if tls.SecretName != "" {
+		secretPath = exporterSecretMountPath + tls.SecretName
+		pod = mountExporterVolume(pod, index, naming.DNSName(naming.Truncate("otel-auto-secret-%s", 63, tls.SecretName)), secretPath, corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{SecretName: tls.SecretName},
+		})
+	}
+	if tls.ConfigMapName != "" {
+		configMapPath = exporterConfigMapMountPath + tls.ConfigMapName
+		pod = mountExporterVolume(pod, index, naming.DNSName(naming.Truncate("otel-auto-configmap-%s", 63, tls.ConfigMapName)), configMapPath, corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: tls.ConfigMapName}},
+		})
+	}
+	container = &pod.Spec.Containers[index]
+
+	caPath := secretPath
+	if configMapPath != "" {
+		caPath = configMapPath
+	}
+	container.Env = appendEnvIfMissing(container.Env, envOtelExporterOTLPCertificate, certificatePath(caPath, tls.CA))
+	container.Env = appendEnvIfMissing(container.Env, envOtelExporterOTLPClientCertificate, certificatePath(secretPath, tls.Cert))
+	container.Env = appendEnvIfMissing(container.Env, envOtelExporterOTLPClientKey, certificatePath(secretPath, tls.Key))
+	return pod
+}
+
+// mountExporterVolume mounts the volume read-only on the container, adding it to the pod unless another container
+// already mounts it.
+func mountExporterVolume(pod corev1.Pod, index int, volumeName, mountPath string, source corev1.VolumeSource) corev1.Pod {
+	found := false
+	for _, volume := range pod.Spec.Volumes {
+		if volume.Name == volumeName {
+			found = true
+			break
+		}
+	}
+	if !found {
+		pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{Name: volumeName, VolumeSource: source})
+	}
+
+	container := &pod.Spec.Containers[index]
+	for _, mount := range container.VolumeMounts {
+		if mount.Name == volumeName {
+			return pod
+		}
+	}
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      volumeName,
+		MountPath: mountPath,
+		ReadOnly:  true,
+	})
+	return pod
+}
+
+// certificatePath returns the path of the certificate, which is either absolute or a key of the Secret or ConfigMap
+// mounted at mountPath.
+func certificatePath(mountPath, certificate string) string {
+	if certificate == "" || filepath.IsAbs(certificate) {
+		return certificate
+	}
+	return filepath.Join(mountPath, certificate)
+}
+
+// percentEncode encodes the characters of the header value which the W3C baggage format of OTEL_EXPORTER_OTLP_HEADERS
+// doesn't allow, e.g. spaces, commas and semicolons.
+func percentEncode(value string) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if c > 0x20 && c < 0x7f && c != '"' && c != ',' && c != ';' && c != '\\' && c != '%' {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
+
+func appendEnvIfMissing(envs []corev1.EnvVar, name, value string) []corev1.EnvVar {
+	if value == "" || getIndexOfEnv(envs, name) != -1 {
+		return envs
+	}
+	return append(envs, corev1.EnvVar{Name: name, Value: value})
+}
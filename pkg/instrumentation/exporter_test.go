@@ -0,0 +1,160 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestInjectExporterConfig(t *testing.T) {
+	tokenRef := &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "gateway-token"},
+		Key:                  "authorization",
+	}
+	tests := []struct {
+		name     string
+		exporter v1alpha1.Exporter
+		pod      corev1.Pod
+		expected corev1.Pod
+	}{
+		{
+			name:     "endpoint only",
+			exporter: v1alpha1.Exporter{Endpoint: "http://collector:4317"},
+			pod:      corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}}},
+			expected: corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}}},
+		},
+		{
+			name: "per-signal endpoints, protocol and headers",
+			exporter: v1alpha1.Exporter{
+				TracesEndpoint:  "http://traces:4318/v1/traces",
+				MetricsEndpoint: "http://metrics:4318/v1/metrics",
+				LogsEndpoint:    "http://logs:4318/v1/logs",
+				Protocol:        v1alpha1.ExporterProtocolHTTPProtobuf,
+				Headers: []v1alpha1.ExporterHeader{
+					{Name: "X-Tenant", Value: "payments"},
+					{Name: "Authorization", SecretKeyRef: tokenRef},
+				},
+			},
+			pod: corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{
+				Name: "app",
+				Env:  []corev1.EnvVar{{Name: "OTEL_EXPORTER_OTLP_PROTOCOL", Value: "grpc"}},
+			}}}},
+			expected: corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{
+				Name: "app",
+				Env: []corev1.EnvVar{
+					{Name: "OTEL_EXPORTER_OTLP_PROTOCOL", Value: "grpc"},
+					{Name: "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", Value: "http://traces:4318/v1/traces"},
+					{Name: "OTEL_EXPORTER_OTLP_METRICS_ENDPOINT", Value: "http://metrics:4318/v1/metrics"},
+					{Name: "OTEL_EXPORTER_OTLP_LOGS_ENDPOINT", Value: "http://logs:4318/v1/logs"},
+					{Name: "OTEL_EXPORTER_OTLP_HEADER_AUTHORIZATION", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: tokenRef}},
+					{Name: "OTEL_EXPORTER_OTLP_HEADERS", Value: "X-Tenant=payments,Authorization=$(OTEL_EXPORTER_OTLP_HEADER_AUTHORIZATION)"},
+				},
+			}}}},
+		},
+		{
+			name: "percent-encoded header values",
+			exporter: v1alpha1.Exporter{
+				Headers: []v1alpha1.ExporterHeader{{Name: "X-Scope", Value: "team a,b;c=100%"}},
+			},
+			pod: corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}}},
+			expected: corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{
+				Name: "app",
+				Env:  []corev1.EnvVar{{Name: "OTEL_EXPORTER_OTLP_HEADERS", Value: "X-Scope=team%20a%2Cb%3Bc=100%25"}},
+			}}}},
+		},
+		{
+			name: "headers set by the container",
+			exporter: v1alpha1.Exporter{
+				Headers: []v1alpha1.ExporterHeader{{Name: "Authorization", SecretKeyRef: tokenRef}},
+			},
+			pod: corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{
+				Name: "app",
+				Env:  []corev1.EnvVar{{Name: "OTEL_EXPORTER_OTLP_HEADERS", Value: "X-Tenant=ledger"}},
+			}}}},
+			expected: corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{
+				Name: "app",
+				Env:  []corev1.EnvVar{{Name: "OTEL_EXPORTER_OTLP_HEADERS", Value: "X-Tenant=ledger"}},
+			}}}},
+		},
+		{
+			name: "tls from secret and configmap",
+			exporter: v1alpha1.Exporter{
+				TLS: &v1alpha1.TLS{
+					SecretName:    "gateway-client",
+					ConfigMapName: "gateway-ca",
+					CA:            "ca.crt",
+					Cert:          "tls.crt",
+					Key:           "tls.key",
+				},
+			},
+			pod: corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}, {Name: "worker"}}}},
+			expected: corev1.Pod{Spec: corev1.PodSpec{
+				Volumes: []corev1.Volume{
+					{
+						Name:         "otel-auto-secret-gateway-client",
+						VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "gateway-client"}},
+					},
+					{
+						Name: "otel-auto-configmap-gateway-ca",
+						VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "gateway-ca"},
+						}},
+					},
+				},
+				Containers: []corev1.Container{
+					{Name: "app"},
+					{
+						Name: "worker",
+						Env: []corev1.EnvVar{
+							{Name: "OTEL_EXPORTER_OTLP_CERTIFICATE", Value: "/otel-auto-instrumentation-configmap-gateway-ca/ca.crt"},
+							{Name: "OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE", Value: "/otel-auto-instrumentation-secret-gateway-client/tls.crt"},
+							{Name: "OTEL_EXPORTER_OTLP_CLIENT_KEY", Value: "/otel-auto-instrumentation-secret-gateway-client/tls.key"},
+						},
+						VolumeMounts: []corev1.VolumeMount{
+							{Name: "otel-auto-secret-gateway-client", MountPath: "/otel-auto-instrumentation-secret-gateway-client", ReadOnly: true},
+							{Name: "otel-auto-configmap-gateway-ca", MountPath: "/otel-auto-instrumentation-configmap-gateway-ca", ReadOnly: true},
+						},
+					},
+				},
+			}},
+		},
+		{
+			name: "tls with CA on the filesystem",
+			exporter: v1alpha1.Exporter{
+				TLS: &v1alpha1.TLS{CA: "/var/run/secrets/kubernetes.io/serviceaccount/service-ca.crt"},
+			},
+			pod: corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}}},
+			expected: corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{
+				Name: "app",
+				Env: []corev1.EnvVar{
+					{Name: "OTEL_EXPORTER_OTLP_CERTIFICATE", Value: "/var/run/secrets/kubernetes.io/serviceaccount/service-ca.crt"},
+				},
+			}}}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			index := len(test.pod.Spec.Containers) - 1
+			pod := injectExporterConfig(test.exporter, test.pod, index)
+			assert.Equal(t, test.expected, pod)
+		})
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+// InjectionStatusAnnotation records on the pods the outcome of the injection of the instrumentations, as a JSON
+// list of Injection.
+const InjectionStatusAnnotation = "instrumentation.opentelemetry.io/injection-status"
+
+// InjectedPodsIndex indexes the pods by the namespace and name of the instrumentations injected into them.
+const InjectedPodsIndex = "instrumentation.opentelemetry.io/injections"
+
+// Injection is the outcome of the injection of an instrumentation into the containers of a pod for a language.
+type Injection struct {
+	// Instrumentation is the namespace and name of the instrumentation, e.g. observability/java.
+	Instrumentation string `json:"instrumentation"`
+	// Generation is the generation of the instrumentation when it was injected.
+	Generation int64 `json:"generation,omitempty"`
+	// SpecHash is the hash of the fields of the spec of the instrumentation which were injected, see SpecHash.
+	SpecHash string                           `json:"specHash,omitempty"`
+	Language v1alpha1.InstrumentationLanguage `json:"language"`
+	Image    string                           `json:"image,omitempty"`
+	// Containers are the containers the instrumentation was injected into.
+	Containers []string `json:"containers,omitempty"`
+	// Error is why the instrumentation wasn't injected into some of the containers.
+	Error string `json:"error,omitempty"`
+}
+
+// Injections returns the injections recorded on the pod, or on its metadata. An invalid annotation is ignored.
+func Injections(pod metav1.Object) []Injection {
+	value, ok := pod.GetAnnotations()[InjectionStatusAnnotation]
+	if !ok {
+		return nil
+	}
+	var injections []Injection
+	if err := json.Unmarshal([]byte(value), &injections); err != nil {
+		return nil
+	}
+	return injections
+}
+
+// PodMetadata returns the metadata-only pod the pods are cached and indexed as by the instrumentation controller.
+func PodMetadata() *metav1.PartialObjectMetadata {
+	pod := &metav1.PartialObjectMetadata{}
+	pod.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Pod"))
+	return pod
+}
+
+// InjectedPodsIndexValues returns the values of InjectedPodsIndex for the pod.
+func InjectedPodsIndexValues(obj client.Object) []string {
+	var values []string
+	seen := map[string]bool{}
+	for _, injection := range Injections(obj) {
+		if !seen[injection.Instrumentation] {
+			seen[injection.Instrumentation] = true
+			values = append(values, injection.Instrumentation)
+		}
+	}
+	return values
+}
+
+// ListInjectedPods returns the metadata of the pods the instrumentation was injected into, or failed to be injected
+// into, with InjectedPodsIndex. Only the metadata of the pods is cached.
+func ListInjectedPods(ctx context.Context, cli client.Client, inst *v1alpha1.Instrumentation) ([]metav1.PartialObjectMetadata, error) {
+	pods := &metav1.PartialObjectMetadataList{}
+	pods.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("PodList"))
+	if err := cli.List(ctx, pods, client.MatchingFields{InjectedPodsIndex: client.ObjectKeyFromObject(inst).String()}); err != nil {
+		return nil, fmt.Errorf("failed to list the pods the instrumentation was injected into: %w", err)
+	}
+	return pods.Items, nil
+}
+
+// SpecHash returns the hash of the fields of the spec of the instrumentation which are injected into the pods, which
+// leaves out the rollout and the selector: a change of the other fields only applies to the pods injected after it.
+func SpecHash(inst v1alpha1.Instrumentation) string {
+	spec := inst.Spec
+	spec.Rollout = v1alpha1.InstrumentationRollout{}
+	spec.Selector = nil
+	b, err := json.Marshal(spec)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(b))[:16]
+}
+
+// newInjection returns the injection of the instrumentation for the language, with the image the instrumentation
+// uses for it.
+func newInjection(inst v1alpha1.Instrumentation, language v1alpha1.InstrumentationLanguage) Injection {
+	injection := Injection{
+		Instrumentation: types.NamespacedName{Namespace: inst.Namespace, Name: inst.Name}.String(),
+		Generation:      inst.Generation,
+		SpecHash:        SpecHash(inst),
+		Language:        language,
+	}
+	switch language {
+	case v1alpha1.InstrumentationLanguageJava:
+		injection.Image = inst.Spec.Java.Image
+	case v1alpha1.InstrumentationLanguageNodeJS:
+		injection.Image = inst.Spec.NodeJS.Image
+	case v1alpha1.InstrumentationLanguagePython:
+		injection.Image = inst.Spec.Python.Image
+	case v1alpha1.InstrumentationLanguageDotNet:
+		injection.Image = inst.Spec.DotNet.Image
+	case v1alpha1.InstrumentationLanguageGo:
+		injection.Image = inst.Spec.Go.Image
+	case v1alpha1.InstrumentationLanguageApacheHttpd:
+		injection.Image = inst.Spec.ApacheHttpd.Image
+	case v1alpha1.InstrumentationLanguageNginx:
+		injection.Image = inst.Spec.Nginx.Image
+	}
+	return injection
+}
+
+// failedInjection returns the injection of the instrumentation for the language that failed with the error.
+func failedInjection(inst v1alpha1.Instrumentation, language v1alpha1.InstrumentationLanguage, err string) Injection {
+	injection := newInjection(inst, language)
+	injection.Image = ""
+	injection.Error = err
+	return injection
+}
+
+// recordInjections appends the injections to the ones recorded on the pod.
+func recordInjections(pod corev1.Pod, injections ...Injection) corev1.Pod {
+	if len(injections) == 0 {
+		return pod
+	}
+	recorded, err := json.Marshal(append(Injections(&pod), injections...))
+	if err != nil {
+		return pod
+	}
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	pod.Annotations[InjectionStatusAnnotation] = string(recorded)
+	return pod
+}
@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestRecordInjections(t *testing.T) {
+	inst := v1alpha1.Instrumentation{
+		ObjectMeta: metav1.ObjectMeta{Name: "example-inst", Namespace: "observability"},
+		Spec: v1alpha1.InstrumentationSpec{
+			Java:   v1alpha1.Java{Image: "otel/java:2.1"},
+			NodeJS: v1alpha1.NodeJS{Image: "otel/nodejs:1"},
+		},
+	}
+	java := newInjection(inst, v1alpha1.InstrumentationLanguageJava)
+	java.Containers = []string{"app"}
+	nodejs := failedInjection(inst, v1alpha1.InstrumentationLanguageNodeJS, "support for NodeJS auto instrumentation is not enabled")
+
+	pod := recordInjections(corev1.Pod{}, java)
+	pod = recordInjections(pod, nodejs)
+
+	hash := SpecHash(inst)
+	assert.Equal(t, `[{"instrumentation":"observability/example-inst","specHash":"`+hash+`","language":"java","image":"otel/java:2.1","containers":["app"]},`+
+		`{"instrumentation":"observability/example-inst","specHash":"`+hash+`","language":"nodejs","error":"support for NodeJS auto instrumentation is not enabled"}]`,
+		pod.Annotations[InjectionStatusAnnotation])
+	assert.Equal(t, []Injection{java, nodejs}, Injections(&pod))
+}
+
+func TestInjectionsInvalidAnnotation(t *testing.T) {
+	pod := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{InjectionStatusAnnotation: "java"}}}
+	assert.Empty(t, Injections(&pod))
+}
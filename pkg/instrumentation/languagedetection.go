@@ -0,0 +1,179 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+// defaultLanguageDetectionRules detect the languages from the executables containers run first, then from their
+// images, and last from the environment variables their images usually declare. The webhook doesn't pull the images,
+// so their labels aren't known.
+var defaultLanguageDetectionRules = []v1alpha1.LanguageDetectionRule{
+	{Language: v1alpha1.InstrumentationLanguageJava, Command: `^java$`},
+	{Language: v1alpha1.InstrumentationLanguageNodeJS, Command: `^(node|nodejs|npm|yarn)$`},
+	{Language: v1alpha1.InstrumentationLanguagePython, Command: `^(python[0-9.]*|gunicorn|uvicorn|celery|flask)$`},
+	{Language: v1alpha1.InstrumentationLanguageDotNet, Command: `^dotnet$`},
+	{Language: v1alpha1.InstrumentationLanguageJava, Image: `(^|/)(openjdk|eclipse-temurin|amazoncorretto|ibm-semeru-runtimes|sapmachine|tomcat|jetty)(:|@|$)`},
+	{Language: v1alpha1.InstrumentationLanguageNodeJS, Image: `(^|/)node(:|@|$)`},
+	{Language: v1alpha1.InstrumentationLanguagePython, Image: `(^|/)(python|pypy)(:|@|$)`},
+	{Language: v1alpha1.InstrumentationLanguageDotNet, Image: `(^|/)dotnet/(aspnet|runtime)(:|@|$)`},
+	{Language: v1alpha1.InstrumentationLanguageJava, Env: "JAVA_HOME"},
+	{Language: v1alpha1.InstrumentationLanguageNodeJS, Env: "NODE_VERSION"},
+	{Language: v1alpha1.InstrumentationLanguagePython, Env: "PYTHON_VERSION"},
+	{Language: v1alpha1.InstrumentationLanguageDotNet, Env: "DOTNET_VERSION"},
+}
+
+// compiledDefaultLanguageDetectionRules are compiled once, the rules of instrumentations once per pod.
+var compiledDefaultLanguageDetectionRules = compileLanguageDetectionRules(defaultLanguageDetectionRules)
+
+// languageDetectionRule is a rule with its regular expressions compiled.
+type languageDetectionRule struct {
+	v1alpha1.LanguageDetectionRule
+	command *regexp.Regexp
+	image   *regexp.Regexp
+	// invalid is set when an expression doesn't compile, the rule then doesn't match anything. The webhook validates
+	// the expressions of the rules.
+	invalid bool
+}
+
+func compileLanguageDetectionRules(rules []v1alpha1.LanguageDetectionRule) []languageDetectionRule {
+	compiled := make([]languageDetectionRule, 0, len(rules))
+	for _, rule := range rules {
+		c := languageDetectionRule{LanguageDetectionRule: rule}
+		var err error
+		if rule.Command != "" {
+			if c.command, err = regexp.Compile(rule.Command); err != nil {
+				c.invalid = true
+			}
+		}
+		if rule.Image != "" {
+			if c.image, err = regexp.Compile(rule.Image); err != nil {
+				c.invalid = true
+			}
+		}
+		compiled = append(compiled, c)
+	}
+	return compiled
+}
+
+// languageDetection is the language detected for a container, and the rule it was detected by.
+type languageDetection struct {
+	Container string
+	Language  v1alpha1.InstrumentationLanguage
+	Reason    string
+}
+
+// detectLanguages detects the language of every container of the pod with the rules of the instrumentation, then
+// the default ones. The language of the containers no rule matches is empty.
+func detectLanguages(inst *v1alpha1.Instrumentation, pod corev1.Pod) []languageDetection {
+	rules := append(compileLanguageDetectionRules(inst.Spec.LanguageDetection), compiledDefaultLanguageDetectionRules...)
+	var detections []languageDetection
+	for _, container := range pod.Spec.Containers {
+		detection := languageDetection{Container: container.Name}
+		for _, rule := range rules {
+			if reason, ok := ruleMatches(rule, container); ok {
+				detection.Language = rule.Language
+				detection.Reason = reason
+				break
+			}
+		}
+		detections = append(detections, detection)
+	}
+	return detections
+}
+
+// ruleMatches returns whether all the attributes the rule sets match the container, and why.
+func ruleMatches(rule languageDetectionRule, container corev1.Container) (string, bool) {
+	if rule.invalid {
+		return "", false
+	}
+	var reasons []string
+	if rule.command != nil {
+		executable := containerExecutable(container)
+		if executable == "" || !rule.command.MatchString(executable) {
+			return "", false
+		}
+		reasons = append(reasons, fmt.Sprintf("command %s", executable))
+	}
+	if rule.image != nil {
+		if !rule.image.MatchString(container.Image) {
+			return "", false
+		}
+		reasons = append(reasons, fmt.Sprintf("image %s", container.Image))
+	}
+	if rule.Env != "" {
+		if getIndexOfEnv(container.Env, rule.Env) == -1 {
+			return "", false
+		}
+		reasons = append(reasons, fmt.Sprintf("env %s", rule.Env))
+	}
+	return strings.Join(reasons, ", "), len(reasons) > 0
+}
+
+// containerExecutable returns the name of the executable the container runs, from its command or from its args when
+// the command isn't set, so that the entrypoint of the image runs them. The script of sh -c and bash -c is looked
+// into.
+func containerExecutable(container corev1.Container) string {
+	argv := container.Command
+	if len(argv) == 0 {
+		argv = container.Args
+	}
+	if len(argv) == 0 {
+		return ""
+	}
+	executable := path.Base(argv[0])
+	switch executable {
+	case "sh", "bash", "ash", "dash":
+		if len(argv) > 2 && argv[1] == "-c" {
+			fields := strings.Fields(argv[2])
+			if len(fields) > 1 && fields[0] == "exec" {
+				fields = fields[1:]
+			}
+			if len(fields) > 0 {
+				executable = path.Base(fields[0])
+			}
+		}
+	case "env":
+		// env runs the first argument which isn't a variable assignment
+		for _, arg := range argv[1:] {
+			if !strings.Contains(arg, "=") && !strings.HasPrefix(arg, "-") {
+				return path.Base(arg)
+			}
+		}
+	}
+	return executable
+}
+
+// detectedLanguagesAnnotation formats the languages detected for the containers of the pod as the value of the
+// instrumentation.opentelemetry.io/detected-languages annotation, e.g. app=java,proxy=unknown.
+func detectedLanguagesAnnotation(detections []languageDetection) string {
+	values := make([]string, 0, len(detections))
+	for _, detection := range detections {
+		language := string(detection.Language)
+		if language == "" {
+			language = "unknown"
+		}
+		values = append(values, fmt.Sprintf("%s=%s", detection.Container, language))
+	}
+	return strings.Join(values, ",")
+}
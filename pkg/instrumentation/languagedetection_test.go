@@ -0,0 +1,161 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+func TestContainerExecutable(t *testing.T) {
+	for _, tt := range []struct {
+		desc      string
+		container corev1.Container
+		expected  string
+	}{
+		{"command", corev1.Container{Command: []string{"/usr/bin/java", "-jar", "app.jar"}}, "java"},
+		{"args", corev1.Container{Args: []string{"node", "server.js"}}, "node"},
+		{"shell", corev1.Container{Command: []string{"sh", "-c", "exec python3 -m app"}}, "python3"},
+		{"env", corev1.Container{Command: []string{"/usr/bin/env", "FOO=bar", "dotnet", "app.dll"}}, "dotnet"},
+		{"entrypoint", corev1.Container{Image: "openjdk:17"}, ""},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.expected, containerExecutable(tt.container))
+		})
+	}
+}
+
+func TestDetectLanguages(t *testing.T) {
+	// prepare
+	inst := &v1alpha1.Instrumentation{
+		Spec: v1alpha1.InstrumentationSpec{
+			LanguageDetection: []v1alpha1.LanguageDetectionRule{
+				// an invalid expression doesn't match anything
+				{Language: v1alpha1.InstrumentationLanguageSdk, Command: `(`},
+				{Language: v1alpha1.InstrumentationLanguageSdk, Image: `(^|/)legacy-java(:|$)`},
+			},
+		},
+	}
+	pod := corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "command", Image: "registry.example.com/app:1.0", Command: []string{"java", "-jar", "app.jar"}},
+				{Name: "image", Image: "docker.io/library/node:20-alpine"},
+				{Name: "env", Image: "registry.example.com/worker:1.0", Env: []corev1.EnvVar{{Name: "PYTHON_VERSION", Value: "3.12"}}},
+				{Name: "override", Image: "registry.example.com/legacy-java:8", Command: []string{"java"}},
+				{Name: "proxy", Image: "envoyproxy/envoy:v1.30"},
+			},
+		},
+	}
+
+	// test
+	detections := detectLanguages(inst, pod)
+
+	// verify
+	require.Len(t, detections, 5)
+	assert.Equal(t, languageDetection{Container: "command", Language: v1alpha1.InstrumentationLanguageJava, Reason: "command java"}, detections[0])
+	assert.Equal(t, v1alpha1.InstrumentationLanguageNodeJS, detections[1].Language)
+	assert.Equal(t, v1alpha1.InstrumentationLanguagePython, detections[2].Language)
+	assert.Equal(t, v1alpha1.InstrumentationLanguageSdk, detections[3].Language)
+	assert.Equal(t, v1alpha1.InstrumentationLanguage(""), detections[4].Language)
+	assert.Equal(t, "command=java,image=nodejs,env=python,override=sdk,proxy=unknown", detectedLanguagesAnnotation(detections))
+}
+
+func TestDefaultLanguageDetectionRulesCompile(t *testing.T) {
+	require.Len(t, compiledDefaultLanguageDetectionRules, len(defaultLanguageDetectionRules))
+	for _, rule := range compiledDefaultLanguageDetectionRules {
+		assert.False(t, rule.invalid, "%+v", rule.LanguageDetectionRule)
+	}
+}
+
+func TestMutatePodDetectedLanguages(t *testing.T) {
+	// prepare
+	ns := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "apps"}}
+	inst := &v1alpha1.Instrumentation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "auto",
+			Namespace: "apps",
+		},
+		Spec: v1alpha1.InstrumentationSpec{
+			Java:   v1alpha1.Java{Image: "otel/java:1"},
+			NodeJS: v1alpha1.NodeJS{Image: "otel/nodejs:1"},
+		},
+	}
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "apps",
+			Annotations: map[string]string{annotationInjectAuto: "true"},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "proxy", Image: "envoyproxy/envoy:v1.30"},
+				{Name: "app", Image: "eclipse-temurin:21"},
+			},
+		},
+	}
+	recorder := record.NewFakeRecorder(10)
+	cli := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(inst).Build()
+	mutator := NewMutator(logr.Discard(), cli, recorder, config.New())
+
+	// test
+	mutated, err := mutator.Mutate(context.Background(), ns, pod)
+	require.NoError(t, err)
+
+	// verify
+	assert.Equal(t, "proxy=unknown,app=java", mutated.Annotations[annotationDetectedLanguages])
+	assert.Equal(t, -1, getIndexOfEnv(mutated.Spec.Containers[0].Env, "JAVA_TOOL_OPTIONS"))
+	assert.NotEqual(t, -1, getIndexOfEnv(mutated.Spec.Containers[1].Env, "JAVA_TOOL_OPTIONS"))
+	require.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, "InstrumentationLanguageDetected proxy: unknown, app: java (image eclipse-temurin:21)")
+}
+
+func TestMutatePodDetectedLanguagesNotEnabled(t *testing.T) {
+	// prepare
+	ns := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "apps"}}
+	inst := &v1alpha1.Instrumentation{ObjectMeta: metav1.ObjectMeta{Name: "auto", Namespace: "apps"}}
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "apps",
+			Annotations: map[string]string{annotationInjectAuto: "auto"},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "app", Command: []string{"node", "server.js"}}},
+		},
+	}
+	recorder := record.NewFakeRecorder(10)
+	cli := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(inst).Build()
+	mutator := NewMutator(logr.Discard(), cli, recorder, config.New())
+
+	// test
+	mutated, err := mutator.Mutate(context.Background(), ns, pod)
+	require.NoError(t, err)
+
+	// verify
+	assert.Equal(t, "app=nodejs", mutated.Annotations[annotationDetectedLanguages])
+	assert.Len(t, mutated.Spec.InitContainers, 0)
+	require.Len(t, recorder.Events, 2)
+	assert.Contains(t, <-recorder.Events, "support for NodeJS auto instrumentation is not enabled")
+}
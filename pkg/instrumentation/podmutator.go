@@ -192,6 +192,70 @@ func (langInsts *languageInstrumentations) setInstrumentationLanguageContainers(
 	}
 }
 
+// forLanguage returns the instrumentation of the language.
+func (langInsts *languageInstrumentations) forLanguage(language v1alpha1.InstrumentationLanguage) *instrumentationWithContainers {
+	switch language {
+	case v1alpha1.InstrumentationLanguageJava:
+		return &langInsts.Java
+	case v1alpha1.InstrumentationLanguageNodeJS:
+		return &langInsts.NodeJS
+	case v1alpha1.InstrumentationLanguagePython:
+		return &langInsts.Python
+	case v1alpha1.InstrumentationLanguageDotNet:
+		return &langInsts.DotNet
+	case v1alpha1.InstrumentationLanguageGo:
+		return &langInsts.Go
+	case v1alpha1.InstrumentationLanguageApacheHttpd:
+		return &langInsts.ApacheHttpd
+	case v1alpha1.InstrumentationLanguageNginx:
+		return &langInsts.Nginx
+	default:
+		return &langInsts.Sdk
+	}
+}
+
+// failedInjections returns the injections of the instrumentations of every language failing with the error.
+func (langInsts *languageInstrumentations) failedInjections(err error) []Injection {
+	var injections []Injection
+	for _, language := range []v1alpha1.InstrumentationLanguage{
+		v1alpha1.InstrumentationLanguageJava,
+		v1alpha1.InstrumentationLanguageNodeJS,
+		v1alpha1.InstrumentationLanguagePython,
+		v1alpha1.InstrumentationLanguageDotNet,
+		v1alpha1.InstrumentationLanguageGo,
+		v1alpha1.InstrumentationLanguageApacheHttpd,
+		v1alpha1.InstrumentationLanguageNginx,
+		v1alpha1.InstrumentationLanguageSdk,
+	} {
+		if inst := langInsts.forLanguage(language).Instrumentation; inst != nil {
+			injections = append(injections, failedInjection(*inst, language, err.Error()))
+		}
+	}
+	return injections
+}
+
+// languageEnabled returns whether the auto-instrumentation of the language is enabled, and the name of the language.
+func languageEnabled(cfg config.Config, language v1alpha1.InstrumentationLanguage) (bool, string) {
+	switch language {
+	case v1alpha1.InstrumentationLanguageJava:
+		return cfg.EnableJavaAutoInstrumentation(), "Java"
+	case v1alpha1.InstrumentationLanguageNodeJS:
+		return cfg.EnableNodeJSAutoInstrumentation(), "NodeJS"
+	case v1alpha1.InstrumentationLanguagePython:
+		return cfg.EnablePythonAutoInstrumentation(), "Python"
+	case v1alpha1.InstrumentationLanguageDotNet:
+		return cfg.EnableDotNetAutoInstrumentation(), ".NET"
+	case v1alpha1.InstrumentationLanguageGo:
+		return cfg.EnableGoAutoInstrumentation(), "Go"
+	case v1alpha1.InstrumentationLanguageApacheHttpd:
+		return cfg.EnableApacheHttpdAutoInstrumentation(), "Apache HTTPD"
+	case v1alpha1.InstrumentationLanguageNginx:
+		return cfg.EnableNginxAutoInstrumentation(), "Nginx"
+	default:
+		return true, "SDK"
+	}
+}
+
 var _ podmutation.PodMutator = (*instPodMutator)(nil)
 
 func NewMutator(logger logr.Logger, client client.Client, recorder record.EventRecorder, cfg config.Config) *instPodMutator {
@@ -224,11 +288,18 @@ func (pm *instPodMutator) Mutate(ctx context.Context, ns corev1.Namespace, pod c
 	var inst *v1alpha1.Instrumentation
 	var err error
 
+	// Instrumentations can select the pod without it being annotated
+	selecting, err := pm.selectingInstrumentations(ctx, ns, pod)
+	if err != nil {
+		logger.Error(err, "failed to list the OpenTelemetry Instrumentation instances selecting this pod")
+		return pod, err
+	}
+
 	insts := languageInstrumentations{}
 
 	// We bail out if any annotation fails to process.
 
-	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectJava); err != nil {
+	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectJava, selecting[v1alpha1.InstrumentationLanguageJava]); err != nil {
 		// we still allow the pod to be created, but we log a message to the operator's logs
 		logger.Error(err, "failed to select an OpenTelemetry Instrumentation instance for this pod")
 		return pod, err
@@ -238,9 +309,10 @@ func (pm *instPodMutator) Mutate(ctx context.Context, ns corev1.Namespace, pod c
 	} else {
 		logger.Error(nil, "support for Java auto instrumentation is not enabled")
 		pm.Recorder.Event(pod.DeepCopy(), "Warning", "InstrumentationRequestRejected", "support for Java auto instrumentation is not enabled")
+		pod = recordInjections(pod, failedInjection(*inst, v1alpha1.InstrumentationLanguageJava, "support for Java auto instrumentation is not enabled"))
 	}
 
-	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectNodeJS); err != nil {
+	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectNodeJS, selecting[v1alpha1.InstrumentationLanguageNodeJS]); err != nil {
 		// we still allow the pod to be created, but we log a message to the operator's logs
 		logger.Error(err, "failed to select an OpenTelemetry Instrumentation instance for this pod")
 		return pod, err
@@ -250,9 +322,10 @@ func (pm *instPodMutator) Mutate(ctx context.Context, ns corev1.Namespace, pod c
 	} else {
 		logger.Error(nil, "support for NodeJS auto instrumentation is not enabled")
 		pm.Recorder.Event(pod.DeepCopy(), "Warning", "InstrumentationRequestRejected", "support for NodeJS auto instrumentation is not enabled")
+		pod = recordInjections(pod, failedInjection(*inst, v1alpha1.InstrumentationLanguageNodeJS, "support for NodeJS auto instrumentation is not enabled"))
 	}
 
-	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectPython); err != nil {
+	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectPython, selecting[v1alpha1.InstrumentationLanguagePython]); err != nil {
 		// we still allow the pod to be created, but we log a message to the operator's logs
 		logger.Error(err, "failed to select an OpenTelemetry Instrumentation instance for this pod")
 		return pod, err
@@ -262,9 +335,10 @@ func (pm *instPodMutator) Mutate(ctx context.Context, ns corev1.Namespace, pod c
 	} else {
 		logger.Error(nil, "support for Python auto instrumentation is not enabled")
 		pm.Recorder.Event(pod.DeepCopy(), "Warning", "InstrumentationRequestRejected", "support for Python auto instrumentation is not enabled")
+		pod = recordInjections(pod, failedInjection(*inst, v1alpha1.InstrumentationLanguagePython, "support for Python auto instrumentation is not enabled"))
 	}
 
-	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectDotNet); err != nil {
+	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectDotNet, selecting[v1alpha1.InstrumentationLanguageDotNet]); err != nil {
 		// we still allow the pod to be created, but we log a message to the operator's logs
 		logger.Error(err, "failed to select an OpenTelemetry Instrumentation instance for this pod")
 		return pod, err
@@ -275,9 +349,10 @@ func (pm *instPodMutator) Mutate(ctx context.Context, ns corev1.Namespace, pod c
 	} else {
 		logger.Error(nil, "support for .NET auto instrumentation is not enabled")
 		pm.Recorder.Event(pod.DeepCopy(), "Warning", "InstrumentationRequestRejected", "support for .NET auto instrumentation is not enabled")
+		pod = recordInjections(pod, failedInjection(*inst, v1alpha1.InstrumentationLanguageDotNet, "support for .NET auto instrumentation is not enabled"))
 	}
 
-	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectGo); err != nil {
+	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectGo, selecting[v1alpha1.InstrumentationLanguageGo]); err != nil {
 		// we still allow the pod to be created, but we log a message to the operator's logs
 		logger.Error(err, "failed to select an OpenTelemetry Instrumentation instance for this pod")
 		return pod, err
@@ -287,9 +362,10 @@ func (pm *instPodMutator) Mutate(ctx context.Context, ns corev1.Namespace, pod c
 	} else {
 		logger.Error(err, "support for Go auto instrumentation is not enabled")
 		pm.Recorder.Event(pod.DeepCopy(), "Warning", "InstrumentationRequestRejected", "support for Go auto instrumentation is not enabled")
+		pod = recordInjections(pod, failedInjection(*inst, v1alpha1.InstrumentationLanguageGo, "support for Go auto instrumentation is not enabled"))
 	}
 
-	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectApacheHttpd); err != nil {
+	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectApacheHttpd, selecting[v1alpha1.InstrumentationLanguageApacheHttpd]); err != nil {
 		// we still allow the pod to be created, but we log a message to the operator's logs
 		logger.Error(err, "failed to select an OpenTelemetry Instrumentation instance for this pod")
 		return pod, err
@@ -299,9 +375,10 @@ func (pm *instPodMutator) Mutate(ctx context.Context, ns corev1.Namespace, pod c
 	} else {
 		logger.Error(nil, "support for Apache HTTPD auto instrumentation is not enabled")
 		pm.Recorder.Event(pod.DeepCopy(), "Warning", "InstrumentationRequestRejected", "support for Apache HTTPD auto instrumentation is not enabled")
+		pod = recordInjections(pod, failedInjection(*inst, v1alpha1.InstrumentationLanguageApacheHttpd, "support for Apache HTTPD auto instrumentation is not enabled"))
 	}
 
-	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectNginx); err != nil {
+	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectNginx, selecting[v1alpha1.InstrumentationLanguageNginx]); err != nil {
 		// we still allow the pod to be created, but we log a message to the operator's logs
 		logger.Error(err, "failed to select an OpenTelemetry Instrumentation instance for this pod")
 		return pod, err
@@ -311,9 +388,10 @@ func (pm *instPodMutator) Mutate(ctx context.Context, ns corev1.Namespace, pod c
 	} else {
 		logger.Error(nil, "support for Nginx auto instrumentation is not enabled")
 		pm.Recorder.Event(pod.DeepCopy(), "Warning", "InstrumentationRequestRejected", "support for Nginx auto instrumentation is not enabled")
+		pod = recordInjections(pod, failedInjection(*inst, v1alpha1.InstrumentationLanguageNginx, "support for Nginx auto instrumentation is not enabled"))
 	}
 
-	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectSdk); err != nil {
+	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectSdk, selecting[v1alpha1.InstrumentationLanguageSdk]); err != nil {
 		// we still allow the pod to be created, but we log a message to the operator's logs
 		logger.Error(err, "failed to select an OpenTelemetry Instrumentation instance for this pod")
 		return pod, err
@@ -325,7 +403,17 @@ func (pm *instPodMutator) Mutate(ctx context.Context, ns corev1.Namespace, pod c
 		insts.Nginx.Instrumentation == nil &&
 		insts.Sdk.Instrumentation == nil {
 
-		logger.V(1).Info("annotation not present in deployment, skipping instrumentation injection")
+		// without language specific annotations or selectors, the languages of the containers may be detected
+		if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectAuto, nil); err != nil {
+			// we still allow the pod to be created, but we log a message to the operator's logs
+			logger.Error(err, "failed to select an OpenTelemetry Instrumentation instance for this pod")
+			return pod, err
+		}
+		if inst != nil {
+			return pm.injectDetectedLanguages(ctx, logger, inst, ns, pod), nil
+		}
+
+		logger.V(1).Info("annotation not present in deployment and no instrumentation selects the pod, skipping instrumentation injection")
 		return pod, nil
 	}
 
@@ -345,7 +433,7 @@ func (pm *instPodMutator) Mutate(ctx context.Context, ns corev1.Namespace, pod c
 		ok, msg := insts.areContainerNamesConfiguredForMultipleInstrumentations()
 		if !ok {
 			logger.V(1).Error(msg, "skipping instrumentation injection")
-			return pod, nil
+			return recordInjections(pod, insts.failedInjections(msg)...), nil
 		}
 	} else {
 		// We use general annotation for container names
@@ -355,8 +443,9 @@ func (pm *instPodMutator) Mutate(ctx context.Context, ns corev1.Namespace, pod c
 			generalContainerNames := annotationValue(ns.ObjectMeta, pod.ObjectMeta, annotationInjectContainerName)
 			insts.setInstrumentationLanguageContainers(generalContainerNames)
 		} else {
-			logger.V(1).Error(fmt.Errorf("multiple injection annotations present"), "skipping instrumentation injection")
-			return pod, nil
+			err = fmt.Errorf("multiple injection annotations present")
+			logger.V(1).Error(err, "skipping instrumentation injection")
+			return recordInjections(pod, insts.failedInjections(err)...), nil
 		}
 
 	}
@@ -369,10 +458,69 @@ func (pm *instPodMutator) Mutate(ctx context.Context, ns corev1.Namespace, pod c
 	return modifiedPod, nil
 }
 
-func (pm *instPodMutator) getInstrumentationInstance(ctx context.Context, ns corev1.Namespace, pod corev1.Pod, instAnnotation string) (*v1alpha1.Instrumentation, error) {
+// injectDetectedLanguages injects the instrumentation into the containers of the pod according to their detected
+// language. The detected languages are recorded in the instrumentation.opentelemetry.io/detected-languages annotation
+// of the pod and in an event.
+func (pm *instPodMutator) injectDetectedLanguages(ctx context.Context, logger logr.Logger, inst *v1alpha1.Instrumentation, ns corev1.Namespace, pod corev1.Pod) corev1.Pod {
+	detections := detectLanguages(inst, pod)
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	pod.Annotations[annotationDetectedLanguages] = detectedLanguagesAnnotation(detections)
+
+	insts := languageInstrumentations{}
+	var detected []string
+	languages := 0
+	for _, detection := range detections {
+		if detection.Language == "" {
+			detected = append(detected, fmt.Sprintf("%s: unknown", detection.Container))
+			continue
+		}
+		detected = append(detected, fmt.Sprintf("%s: %s (%s)", detection.Container, detection.Language, detection.Reason))
+		if enabled, name := languageEnabled(pm.config, detection.Language); !enabled {
+			logger.Error(nil, fmt.Sprintf("support for %s auto instrumentation is not enabled", name), "container", detection.Container)
+			pm.Recorder.Event(pod.DeepCopy(), "Warning", "InstrumentationRequestRejected", fmt.Sprintf("support for %s auto instrumentation is not enabled", name))
+			pod = recordInjections(pod, failedInjection(*inst, detection.Language, fmt.Sprintf("support for %s auto instrumentation is not enabled", name)))
+			continue
+		}
+		langInst := insts.forLanguage(detection.Language)
+		if langInst.Instrumentation == nil {
+			langInst.Instrumentation = inst
+			languages++
+		} else {
+			langInst.Containers += ","
+		}
+		langInst.Containers += detection.Container
+	}
+	logger.V(1).Info("detected the languages of the containers", "languages", pod.Annotations[annotationDetectedLanguages])
+	pm.Recorder.Event(pod.DeepCopy(), "Normal", "InstrumentationLanguageDetected", strings.Join(detected, ", "))
+
+	if languages == 0 {
+		logger.V(1).Info("no language detected, skipping instrumentation injection")
+		return pod
+	}
+	if languages > 1 && !pm.config.EnableMultiInstrumentation() {
+		err := fmt.Errorf("multiple languages detected, which requires multi instrumentation to be enabled")
+		logger.V(1).Error(err, "skipping instrumentation injection")
+		pm.Recorder.Event(pod.DeepCopy(), "Warning", "InstrumentationRequestRejected", err.Error())
+		return recordInjections(pod, insts.failedInjections(err)...)
+	}
+	if insts.DotNet.Instrumentation != nil {
+		insts.DotNet.AdditionalAnnotations = map[string]string{annotationDotNetRuntime: annotationValue(ns.ObjectMeta, pod.ObjectMeta, annotationDotNetRuntime)}
+	}
+	return pm.sdkInjector.inject(ctx, insts, ns, pod, pm.config)
+}
+
+// getInstrumentationInstance returns the instrumentation the annotation asks for, or the one selecting the pod when
+// neither the pod nor its namespace is annotated. An annotation set to false opts the pod out of both.
+func (pm *instPodMutator) getInstrumentationInstance(ctx context.Context, ns corev1.Namespace, pod corev1.Pod, instAnnotation string, selecting []*v1alpha1.Instrumentation) (*v1alpha1.Instrumentation, error) {
 	instValue := annotationValue(ns.ObjectMeta, pod.ObjectMeta, instAnnotation)
 
-	if len(instValue) == 0 || strings.EqualFold(instValue, "false") {
+	if len(instValue) == 0 {
+		return selectedInstrumentation(selecting)
+	}
+
+	if strings.EqualFold(instValue, "false") {
 		return nil, nil
 	}
 
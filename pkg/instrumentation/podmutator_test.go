@@ -17,6 +17,7 @@ package instrumentation
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/go-logr/logr"
@@ -123,7 +124,8 @@ func TestMutatePod(t *testing.T) {
 			expected: corev1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
 					Annotations: map[string]string{
-						annotationInjectJava: "true",
+						annotationInjectJava:      "true",
+						InjectionStatusAnnotation: `[{"instrumentation":"javaagent/example-inst","generation":1,"language":"java","containers":["app"]}]`,
 					},
 				},
 				Spec: corev1.PodSpec{
@@ -329,6 +331,7 @@ func TestMutatePod(t *testing.T) {
 					Annotations: map[string]string{
 						annotationInjectJava:          "true",
 						annotationInjectContainerName: "app1,app2",
+						InjectionStatusAnnotation:     `[{"instrumentation":"javaagent-multiple-containers/example-inst","generation":1,"language":"java","containers":["app1","app2"]}]`,
 					},
 				},
 				Spec: corev1.PodSpec{
@@ -618,7 +621,8 @@ func TestMutatePod(t *testing.T) {
 			expected: corev1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
 					Annotations: map[string]string{
-						annotationInjectJava: "true",
+						annotationInjectJava:      "true",
+						InjectionStatusAnnotation: `[{"instrumentation":"javaagent-disabled/example-inst","generation":1,"language":"java","error":"support for Java auto instrumentation is not enabled"}]`,
 					},
 				},
 				Spec: corev1.PodSpec{
@@ -701,7 +705,8 @@ func TestMutatePod(t *testing.T) {
 			expected: corev1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
 					Annotations: map[string]string{
-						annotationInjectNodeJS: "true",
+						annotationInjectNodeJS:    "true",
+						InjectionStatusAnnotation: `[{"instrumentation":"nodejs/example-inst","generation":1,"language":"nodejs","image":"otel/nodejs:1","containers":["app"]}]`,
 					},
 				},
 				Spec: corev1.PodSpec{
@@ -891,6 +896,7 @@ func TestMutatePod(t *testing.T) {
 					Annotations: map[string]string{
 						annotationInjectNodeJS:        "true",
 						annotationInjectContainerName: "app1,app2",
+						InjectionStatusAnnotation:     `[{"instrumentation":"nodejs-multiple-containers/example-inst","generation":1,"language":"nodejs","image":"otel/nodejs:1","containers":["app1","app2"]}]`,
 					},
 				},
 				Spec: corev1.PodSpec{
@@ -1157,7 +1163,8 @@ func TestMutatePod(t *testing.T) {
 			expected: corev1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
 					Annotations: map[string]string{
-						annotationInjectNodeJS: "true",
+						annotationInjectNodeJS:    "true",
+						InjectionStatusAnnotation: `[{"instrumentation":"nodejs-disabled/example-inst","generation":1,"language":"nodejs","error":"support for NodeJS auto instrumentation is not enabled"}]`,
 					},
 				},
 				Spec: corev1.PodSpec{
@@ -1243,7 +1250,8 @@ func TestMutatePod(t *testing.T) {
 			expected: corev1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
 					Annotations: map[string]string{
-						annotationInjectPython: "true",
+						annotationInjectPython:    "true",
+						InjectionStatusAnnotation: `[{"instrumentation":"python/example-inst","generation":1,"language":"python","image":"otel/python:1","containers":["app"]}]`,
 					},
 				},
 				Spec: corev1.PodSpec{
@@ -1369,6 +1377,163 @@ func TestMutatePod(t *testing.T) {
 			},
 			config: config.New(config.WithEnablePythonInstrumentation(true)),
 		},
+		{
+			name: "python injection, exporter protocol and headers",
+			ns: corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "python-exporter",
+				},
+			},
+			inst: v1alpha1.Instrumentation{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "example-inst",
+					Namespace: "python-exporter",
+				},
+				Spec: v1alpha1.InstrumentationSpec{
+					Python: v1alpha1.Python{
+						Image: "otel/python:1",
+					},
+					Exporter: v1alpha1.Exporter{
+						Endpoint: "http://collector:4317",
+						Protocol: v1alpha1.ExporterProtocolGRPC,
+						Headers: []v1alpha1.ExporterHeader{
+							{Name: "X-Tenant", Value: "team a"},
+						},
+					},
+				},
+			},
+			pod: corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						annotationInjectPython: "true",
+					},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: "app",
+						},
+					},
+				},
+			},
+			expected: corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						annotationInjectPython:    "true",
+						InjectionStatusAnnotation: `[{"instrumentation":"python-exporter/example-inst","generation":1,"language":"python","image":"otel/python:1","containers":["app"]}]`,
+					},
+				},
+				Spec: corev1.PodSpec{
+					Volumes: []corev1.Volume{
+						{
+							Name: pythonVolumeName,
+							VolumeSource: corev1.VolumeSource{
+								EmptyDir: &corev1.EmptyDirVolumeSource{
+									SizeLimit: &defaultVolumeLimitSize,
+								},
+							},
+						},
+					},
+					InitContainers: []corev1.Container{
+						{
+							Name:    pythonInitContainerName,
+							Image:   "otel/python:1",
+							Command: []string{"cp", "-r", "/autoinstrumentation/.", pythonInstrMountPath},
+							VolumeMounts: []corev1.VolumeMount{{
+								Name:      pythonVolumeName,
+								MountPath: pythonInstrMountPath,
+							}},
+						},
+					},
+					Containers: []corev1.Container{
+						{
+							Name: "app",
+							Env: []corev1.EnvVar{
+								{
+									Name: "OTEL_NODE_IP",
+									ValueFrom: &corev1.EnvVarSource{
+										FieldRef: &corev1.ObjectFieldSelector{
+											FieldPath: "status.hostIP",
+										},
+									},
+								},
+								{
+									Name: "OTEL_POD_IP",
+									ValueFrom: &corev1.EnvVarSource{
+										FieldRef: &corev1.ObjectFieldSelector{
+											FieldPath: "status.podIP",
+										},
+									},
+								},
+								{
+									Name:  "PYTHONPATH",
+									Value: fmt.Sprintf("%s:%s", pythonPathPrefix, pythonPathSuffix),
+								},
+								{
+									Name:  "OTEL_TRACES_EXPORTER",
+									Value: "otlp",
+								},
+								{
+									Name:  "OTEL_EXPORTER_OTLP_TRACES_PROTOCOL",
+									Value: "grpc",
+								},
+								{
+									Name:  "OTEL_METRICS_EXPORTER",
+									Value: "otlp",
+								},
+								{
+									Name:  "OTEL_EXPORTER_OTLP_METRICS_PROTOCOL",
+									Value: "grpc",
+								},
+								{
+									Name:  "OTEL_SERVICE_NAME",
+									Value: "app",
+								},
+								{
+									Name:  "OTEL_EXPORTER_OTLP_ENDPOINT",
+									Value: "http://collector:4317",
+								},
+								{
+									Name:  "OTEL_EXPORTER_OTLP_PROTOCOL",
+									Value: "grpc",
+								},
+								{
+									Name:  "OTEL_EXPORTER_OTLP_HEADERS",
+									Value: "X-Tenant=team%20a",
+								},
+								{
+									Name: "OTEL_RESOURCE_ATTRIBUTES_POD_NAME",
+									ValueFrom: &corev1.EnvVarSource{
+										FieldRef: &corev1.ObjectFieldSelector{
+											FieldPath: "metadata.name",
+										},
+									},
+								},
+								{
+									Name: "OTEL_RESOURCE_ATTRIBUTES_NODE_NAME",
+									ValueFrom: &corev1.EnvVarSource{
+										FieldRef: &corev1.ObjectFieldSelector{
+											FieldPath: "spec.nodeName",
+										},
+									},
+								},
+								{
+									Name:  "OTEL_RESOURCE_ATTRIBUTES",
+									Value: "k8s.container.name=app,k8s.namespace.name=python-exporter,k8s.node.name=$(OTEL_RESOURCE_ATTRIBUTES_NODE_NAME),k8s.pod.name=$(OTEL_RESOURCE_ATTRIBUTES_POD_NAME),service.instance.id=python-exporter.$(OTEL_RESOURCE_ATTRIBUTES_POD_NAME).app",
+								},
+							},
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      pythonVolumeName,
+									MountPath: pythonInstrMountPath,
+								},
+							},
+						},
+					},
+				},
+			},
+			config: config.New(config.WithEnablePythonInstrumentation(true)),
+		},
 		{
 			name: "python injection multiple containers, true",
 			ns: corev1.Namespace{
@@ -1449,6 +1614,7 @@ func TestMutatePod(t *testing.T) {
 					Annotations: map[string]string{
 						annotationInjectPython:        "true",
 						annotationInjectContainerName: "app1,app2",
+						InjectionStatusAnnotation:     `[{"instrumentation":"python-multiple-containers/example-inst","generation":1,"language":"python","image":"otel/python:1","containers":["app1","app2"]}]`,
 					},
 				},
 				Spec: corev1.PodSpec{
@@ -1743,7 +1909,8 @@ func TestMutatePod(t *testing.T) {
 			expected: corev1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
 					Annotations: map[string]string{
-						annotationInjectPython: "true",
+						annotationInjectPython:    "true",
+						InjectionStatusAnnotation: `[{"instrumentation":"python-disabled/example-inst","generation":1,"language":"python","error":"support for Python auto instrumentation is not enabled"}]`,
 					},
 				},
 				Spec: corev1.PodSpec{
@@ -1822,8 +1989,9 @@ func TestMutatePod(t *testing.T) {
 			expected: corev1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
 					Annotations: map[string]string{
-						annotationInjectDotNet:  "true",
-						annotationDotNetRuntime: dotNetRuntimeLinuxMusl,
+						annotationInjectDotNet:    "true",
+						annotationDotNetRuntime:   dotNetRuntimeLinuxMusl,
+						InjectionStatusAnnotation: `[{"instrumentation":"dotnet/example-inst","generation":1,"language":"dotnet","image":"otel/dotnet:1","containers":["app"]}]`,
 					},
 				},
 				Spec: corev1.PodSpec{
@@ -2021,7 +2189,11 @@ func TestMutatePod(t *testing.T) {
 				},
 			},
 			expected: corev1.Pod{
-				ObjectMeta: metav1.ObjectMeta{},
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						InjectionStatusAnnotation: `[{"instrumentation":"dotnet-by-namespace-annotation/example-inst","generation":1,"language":"dotnet","image":"otel/dotnet:1","containers":["app"]}]`,
+					},
+				},
 				Spec: corev1.PodSpec{
 					Volumes: []corev1.Volume{
 						{
@@ -2225,6 +2397,7 @@ func TestMutatePod(t *testing.T) {
 					Annotations: map[string]string{
 						annotationInjectDotNet:        "true",
 						annotationInjectContainerName: "app1,app2",
+						InjectionStatusAnnotation:     `[{"instrumentation":"dotnet-multiple-containers/example-inst","generation":1,"language":"dotnet","image":"otel/dotnet:1","containers":["app1","app2"]}]`,
 					},
 				},
 				Spec: corev1.PodSpec{
@@ -2527,7 +2700,8 @@ func TestMutatePod(t *testing.T) {
 			expected: corev1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
 					Annotations: map[string]string{
-						annotationInjectDotNet: "true",
+						annotationInjectDotNet:    "true",
+						InjectionStatusAnnotation: `[{"instrumentation":"dotnet-disabled/example-inst","generation":1,"language":"dotnet","error":"support for .NET auto instrumentation is not enabled"}]`,
 					},
 				},
 				Spec: corev1.PodSpec{
@@ -2607,8 +2781,9 @@ func TestMutatePod(t *testing.T) {
 			expected: corev1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
 					Annotations: map[string]string{
-						annotationInjectGo:   "true",
-						annotationGoExecPath: "/app",
+						annotationInjectGo:        "true",
+						annotationGoExecPath:      "/app",
+						InjectionStatusAnnotation: `[{"instrumentation":"go/example-inst","generation":1,"language":"go","image":"otel/go:1","containers":["app"]}]`,
 					},
 				},
 				Spec: corev1.PodSpec{
@@ -2783,8 +2958,9 @@ func TestMutatePod(t *testing.T) {
 			expected: corev1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
 					Annotations: map[string]string{
-						annotationInjectGo:   "true",
-						annotationGoExecPath: "/app",
+						annotationInjectGo:        "true",
+						annotationGoExecPath:      "/app",
+						InjectionStatusAnnotation: `[{"instrumentation":"go-disabled/example-inst","generation":1,"language":"go","error":"support for Go auto instrumentation is not enabled"}]`,
 					},
 				},
 				Spec: corev1.PodSpec{
@@ -2837,6 +3013,7 @@ func TestMutatePod(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{
 					Annotations: map[string]string{
 						annotationInjectApacheHttpd: "true",
+						InjectionStatusAnnotation:   `[{"instrumentation":"apache-httpd/example-inst","generation":1,"language":"apache-httpd","image":"otel/apache-httpd:1","containers":["app"]}]`,
 					},
 				},
 				Spec: corev1.PodSpec{
@@ -3029,6 +3206,7 @@ func TestMutatePod(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{
 					Annotations: map[string]string{
 						annotationInjectApacheHttpd: "true",
+						InjectionStatusAnnotation:   `[{"instrumentation":"apache-httpd-disabled/example-inst","generation":1,"language":"apache-httpd","error":"support for Apache HTTPD auto instrumentation is not enabled"}]`,
 					},
 				},
 				Spec: corev1.PodSpec{
@@ -3087,7 +3265,8 @@ func TestMutatePod(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "my-nginx-6c44bcbdd",
 					Annotations: map[string]string{
-						annotationInjectNginx: "true",
+						annotationInjectNginx:     "true",
+						InjectionStatusAnnotation: `[{"instrumentation":"req-namespace/my-nginx-6c44bcbdd","generation":1,"language":"nginx","image":"otel/nginx-inj:1","containers":["nginx"]}]`,
 					},
 				},
 				Spec: corev1.PodSpec{
@@ -3279,7 +3458,8 @@ func TestMutatePod(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{
 					Name: "my-nginx-6c44bcbdd",
 					Annotations: map[string]string{
-						annotationInjectNginx: "true",
+						annotationInjectNginx:     "true",
+						InjectionStatusAnnotation: `[{"instrumentation":"nginx-disabled/my-nginx-6c44bcbdd","generation":1,"language":"nginx","error":"support for Nginx auto instrumentation is not enabled"}]`,
 					},
 				},
 				Spec: corev1.PodSpec{
@@ -3527,6 +3707,7 @@ func TestMutatePod(t *testing.T) {
 						annotationInjectJavaContainersName:   "java1,java2",
 						annotationInjectNodeJSContainersName: "nodejs1,nodejs2",
 						annotationInjectPythonContainersName: "python1,python2",
+						InjectionStatusAnnotation:            `[{"instrumentation":"multi-instrumentation-multi-containers/example-inst","generation":1,"language":"java","image":"otel/java:1","containers":["java1","java2"]},{"instrumentation":"multi-instrumentation-multi-containers/example-inst","generation":1,"language":"nodejs","image":"otel/nodejs:1","containers":["nodejs1","nodejs2"]},{"instrumentation":"multi-instrumentation-multi-containers/example-inst","generation":1,"language":"python","image":"otel/python:1","containers":["python1","python2"]},{"instrumentation":"multi-instrumentation-multi-containers/example-inst","generation":1,"language":"dotnet","image":"otel/dotnet:1","containers":["dotnet1","dotnet2"]}]`,
 					},
 				},
 				Spec: corev1.PodSpec{
@@ -4312,6 +4493,7 @@ func TestMutatePod(t *testing.T) {
 						annotationInjectNodeJSContainersName: "nodejs1,nodejs2",
 						annotationInjectPythonContainersName: "python1,python2",
 						annotationInjectContainerName:        "should-not-be-instrumented1,should-not-be-instrumented2",
+						InjectionStatusAnnotation:            `[{"instrumentation":"multi-instrumentation-multi-containers-cn/example-inst","generation":1,"language":"java","image":"otel/java:1","containers":["java1","java2"]},{"instrumentation":"multi-instrumentation-multi-containers-cn/example-inst","generation":1,"language":"nodejs","image":"otel/nodejs:1","containers":["nodejs1","nodejs2"]},{"instrumentation":"multi-instrumentation-multi-containers-cn/example-inst","generation":1,"language":"python","image":"otel/python:1","containers":["python1","python2"]},{"instrumentation":"multi-instrumentation-multi-containers-cn/example-inst","generation":1,"language":"dotnet","image":"otel/dotnet:1","containers":["dotnet1","dotnet2"]}]`,
 					},
 				},
 				Spec: corev1.PodSpec{
@@ -5103,6 +5285,7 @@ func TestMutatePod(t *testing.T) {
 						annotationInjectNodeJSContainersName: "nodejs1,nodejs2",
 						annotationInjectPythonContainersName: "python1,python2",
 						annotationInjectContainerName:        "should-not-be-instrumented1,should-not-be-instrumented2",
+						InjectionStatusAnnotation:            `[{"instrumentation":"multi-instrumentation-multi-containers-dis-cn/example-inst","generation":1,"language":"java","error":"support for Java auto instrumentation is not enabled"},{"instrumentation":"multi-instrumentation-multi-containers-dis-cn/example-inst","generation":1,"language":"nodejs","error":"support for NodeJS auto instrumentation is not enabled"},{"instrumentation":"multi-instrumentation-multi-containers-dis-cn/example-inst","generation":1,"language":"python","error":"support for Python auto instrumentation is not enabled"},{"instrumentation":"multi-instrumentation-multi-containers-dis-cn/example-inst","generation":1,"language":"dotnet","error":"support for .NET auto instrumentation is not enabled"}]`,
 					},
 				},
 				Spec: corev1.PodSpec{
@@ -5243,10 +5426,11 @@ func TestMutatePod(t *testing.T) {
 			expected: corev1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
 					Annotations: map[string]string{
-						annotationInjectDotNet: "true",
-						annotationInjectJava:   "true",
-						annotationInjectNodeJS: "true",
-						annotationInjectPython: "true",
+						annotationInjectDotNet:    "true",
+						annotationInjectJava:      "true",
+						annotationInjectNodeJS:    "true",
+						annotationInjectPython:    "true",
+						InjectionStatusAnnotation: `[{"instrumentation":"multi-instrumentation-multi-containers-no-cont/example-inst","generation":1,"language":"java","error":"support for Java auto instrumentation is not enabled"},{"instrumentation":"multi-instrumentation-multi-containers-no-cont/example-inst","generation":1,"language":"nodejs","error":"support for NodeJS auto instrumentation is not enabled"},{"instrumentation":"multi-instrumentation-multi-containers-no-cont/example-inst","generation":1,"language":"python","error":"support for Python auto instrumentation is not enabled"},{"instrumentation":"multi-instrumentation-multi-containers-no-cont/example-inst","generation":1,"language":"dotnet","error":"support for .NET auto instrumentation is not enabled"}]`,
 					},
 				},
 				Spec: corev1.PodSpec{
@@ -5360,7 +5544,8 @@ func TestMutatePod(t *testing.T) {
 			expected: corev1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
 					Annotations: map[string]string{
-						annotationInjectDotNet: "true",
+						annotationInjectDotNet:    "true",
+						InjectionStatusAnnotation: `[{"instrumentation":"multi-instrumentation-single-container-no-cont/example-inst","generation":1,"language":"dotnet","image":"otel/dotnet:1","containers":["dotnet1"]}]`,
 					},
 				},
 				Spec: corev1.PodSpec{
@@ -5557,6 +5742,7 @@ func TestMutatePod(t *testing.T) {
 						annotationInjectDotNet:               "true",
 						annotationInjectDotnetContainersName: "dotnet1",
 						annotationInjectNodeJSContainersName: "should-not-be-instrumented1",
+						InjectionStatusAnnotation:            `[{"instrumentation":"multi-instrumentation-single-container-spec-cont/example-inst","generation":1,"language":"dotnet","error":"support for .NET auto instrumentation is not enabled"}]`,
 					},
 				},
 				Spec: corev1.PodSpec{
@@ -5610,6 +5796,11 @@ func TestMutatePod(t *testing.T) {
 			pod, err := mutator.Mutate(context.Background(), test.ns, test.pod)
 			if test.err == "" {
 				require.NoError(t, err)
+				// the hash of the spec of the instrumentation depends on the defaults it was created with
+				if status, ok := test.expected.Annotations[InjectionStatusAnnotation]; ok {
+					test.expected.Annotations[InjectionStatusAnnotation] = strings.ReplaceAll(status, `"generation":1,`,
+						fmt.Sprintf(`"generation":1,"specHash":%q,`, SpecHash(test.inst)))
+				}
 				assert.Equal(t, test.expected, pod)
 			} else {
 				assert.Contains(t, err.Error(), test.err)
@@ -35,7 +35,7 @@ const (
 	pythonInitContainerName            = initContainerName + "-python"
 )
 
-func injectPythonSDK(pythonSpec v1alpha1.Python, pod corev1.Pod, index int) (corev1.Pod, error) {
+func injectPythonSDK(pythonSpec v1alpha1.Python, protocol v1alpha1.ExporterProtocol, pod corev1.Pod, index int) (corev1.Pod, error) {
 	// caller checks if there is at least one container.
 	container := &pod.Spec.Containers[index]
 
@@ -71,12 +71,16 @@ func injectPythonSDK(pythonSpec v1alpha1.Python, pod corev1.Pod, index int) (cor
 		})
 	}
 
-	// Set OTEL_EXPORTER_OTLP_TRACES_PROTOCOL to http/protobuf if not set by user because it is what our autoinstrumentation supports.
+	// Set OTEL_EXPORTER_OTLP_TRACES_PROTOCOL to the protocol of the exporter, or to http/protobuf if not set by user
+	// because it is what our autoinstrumentation supports.
+	if protocol == "" {
+		protocol = v1alpha1.ExporterProtocolHTTPProtobuf
+	}
 	idx = getIndexOfEnv(container.Env, envOtelExporterOTLPTracesProtocol)
 	if idx == -1 {
 		container.Env = append(container.Env, corev1.EnvVar{
 			Name:  envOtelExporterOTLPTracesProtocol,
-			Value: "http/protobuf",
+			Value: string(protocol),
 		})
 	}
 
@@ -89,12 +93,13 @@ func injectPythonSDK(pythonSpec v1alpha1.Python, pod corev1.Pod, index int) (cor
 		})
 	}
 
-	// Set OTEL_EXPORTER_OTLP_METRICS_PROTOCOL to http/protobuf if not set by user because it is what our autoinstrumentation supports.
+	// Set OTEL_EXPORTER_OTLP_METRICS_PROTOCOL to the protocol of the exporter, or to http/protobuf if not set by user
+	// because it is what our autoinstrumentation supports.
 	idx = getIndexOfEnv(container.Env, envOtelExporterOTLPMetricsProtocol)
 	if idx == -1 {
 		container.Env = append(container.Env, corev1.EnvVar{
 			Name:  envOtelExporterOTLPMetricsProtocol,
-			Value: "http/protobuf",
+			Value: string(protocol),
 		})
 	}
 
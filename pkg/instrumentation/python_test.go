@@ -367,7 +367,7 @@ func TestInjectPythonSDK(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			pod, err := injectPythonSDK(test.Python, test.pod, 0)
+			pod, err := injectPythonSDK(test.Python, "", test.pod, 0)
 			assert.Equal(t, test.expected, pod)
 			assert.Equal(t, test.err, err)
 		})
@@ -0,0 +1,333 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rollout
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/instrumentation"
+)
+
+const (
+	// PausedAnnotation pauses the restarts of the workloads of the instrumentation it's set on when set to "true".
+	PausedAnnotation = "instrumentation.opentelemetry.io/rollout-paused"
+	// RestartedForAnnotation is set on the workloads restarted by the operator to the instrumentation and the hash of
+	// its spec they were restarted for, e.g. observability/java@5d41402abc4b2a76, see instrumentation.SpecHash.
+	RestartedForAnnotation = "instrumentation.opentelemetry.io/restarted-for"
+	// RestartedAtAnnotation is set on the pod templates of the workloads restarted by the operator, for their pods to
+	// be rolled out.
+	RestartedAtAnnotation = "instrumentation.opentelemetry.io/restarted-at"
+
+	// RequeueAfter is how long to wait before checking again the workloads rolling out, or whose restart was deferred.
+	RequeueAfter = 30 * time.Second
+)
+
+// workload is a Deployment, a StatefulSet or a DaemonSet.
+type workload struct {
+	instrumentation.Workload
+	object   client.Object
+	template *corev1.PodTemplateSpec
+	// rolledOut is whether all the pods of the workload were updated and are available.
+	rolledOut bool
+	// notRestartable is why restarting the workload doesn't replace its pods, e.g. its OnDelete update strategy.
+	notRestartable string
+}
+
+type Rollout struct {
+	Client   client.Client
+	Logger   logr.Logger
+	Recorder record.EventRecorder
+	// AdminNamespaces are the namespaces whose instrumentations may restart the workloads of other namespaces.
+	AdminNamespaces []string
+}
+
+func NewRollout(client client.Client, logger logr.Logger, recorder record.EventRecorder, adminNamespaces []string) *Rollout {
+	return &Rollout{
+		Client:          client,
+		Logger:          logger,
+		Recorder:        recorder,
+		AdminNamespaces: adminNamespaces,
+	}
+}
+
+// +kubebuilder:rbac:groups=apps,resources=deployments;statefulsets;daemonsets,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch
+
+// Restart restarts the workloads whose pods were injected with an outdated spec of the instrumentation, when its
+// rollout policy asks for it, with at most spec.rollout.maxConcurrent of them rolling out at the same time. It
+// returns whether workloads are rolling out or remain to be restarted. Only the workloads of the namespace of the
+// instrumentation are restarted, unless it's in one of the admin namespaces. The pods recorded without the hash of
+// the spec, injected by a previous version of the operator, aren't known to be outdated and don't restart their
+// workloads. The workloads whose pods aren't replaced on a restart, i.e. the StatefulSets and DaemonSets with the
+// OnDelete update strategy and the paused Deployments, are skipped with an event.
+func (r *Rollout) Restart(ctx context.Context, inst *v1alpha1.Instrumentation) (bool, error) {
+	if !inst.Spec.Rollout.Restart {
+		return false, nil
+	}
+	if inst.Annotations[PausedAnnotation] == "true" {
+		r.Logger.V(2).Info("restarts paused", "instrumentation", client.ObjectKeyFromObject(inst))
+		return false, nil
+	}
+
+	key := client.ObjectKeyFromObject(inst).String()
+	specHash := instrumentation.SpecHash(*inst)
+	restartedFor := fmt.Sprintf("%s@%s", key, specHash)
+	allNamespaces := slices.Contains(r.AdminNamespaces, inst.Namespace)
+	pods, err := instrumentation.ListInjectedPods(ctx, r.Client, inst)
+	if err != nil {
+		return false, err
+	}
+
+	owners := map[instrumentation.Workload]bool{}
+	stale := map[instrumentation.Workload]bool{}
+	for i := range pods {
+		pod := &pods[i]
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		if pod.Namespace != inst.Namespace && !allNamespaces {
+			continue
+		}
+		owner, ownerErr := instrumentation.OwningWorkload(ctx, r.Client, pod)
+		if ownerErr != nil {
+			return false, ownerErr
+		}
+		owners[owner] = true
+		for _, injection := range instrumentation.Injections(pod) {
+			// a missing hash means the pod was injected before it was recorded, which doesn't tell it's outdated
+			if injection.Instrumentation == key && injection.SpecHash != "" && injection.SpecHash != specHash {
+				stale[owner] = true
+			}
+		}
+	}
+	var sorted []instrumentation.Workload
+	for owner := range owners {
+		sorted = append(sorted, owner)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Namespace != sorted[j].Namespace {
+			return sorted[i].Namespace < sorted[j].Namespace
+		}
+		if sorted[i].Kind != sorted[j].Kind {
+			return sorted[i].Kind < sorted[j].Kind
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	// the workloads restarted for any spec of the instrumentation count against the concurrency until they're rolled
+	// out
+	rollingOut := 0
+	var toRestart []*workload
+	for _, owner := range sorted {
+		w, getErr := r.getWorkload(ctx, owner)
+		if getErr != nil {
+			return false, getErr
+		}
+		if w == nil {
+			continue
+		}
+		if w.notRestartable != "" {
+			if stale[owner] {
+				r.Recorder.Event(inst, corev1.EventTypeWarning, "RolloutSkipped",
+					fmt.Sprintf("the restart of %s %s/%s is skipped, %s", w.Kind, w.Namespace, w.Name, w.notRestartable))
+			}
+			continue
+		}
+		restarted := w.object.GetAnnotations()[RestartedForAnnotation]
+		if strings.HasPrefix(restarted, key+"@") && !w.rolledOut {
+			rollingOut++
+		}
+		if restarted == restartedFor {
+			continue
+		}
+		if stale[owner] {
+			toRestart = append(toRestart, w)
+		}
+	}
+
+	maxConcurrent := int(inst.Spec.Rollout.MaxConcurrent)
+	if maxConcurrent < 1 {
+		maxConcurrent = 1
+	}
+	pending := rollingOut > 0
+	for _, w := range toRestart {
+		pending = true
+		if rollingOut >= maxConcurrent {
+			break
+		}
+		pdb, allowed, pdbErr := r.disruptionAllowed(ctx, w)
+		if pdbErr != nil {
+			return false, pdbErr
+		}
+		if !allowed {
+			r.Recorder.Event(inst, corev1.EventTypeWarning, "RolloutDeferred",
+				fmt.Sprintf("the restart of %s %s/%s waits for the PodDisruptionBudget %s to allow disruptions", w.Kind, w.Namespace, w.Name, pdb))
+			continue
+		}
+		if restartErr := r.restart(ctx, w, restartedFor); restartErr != nil {
+			return false, restartErr
+		}
+		r.Recorder.Event(inst, corev1.EventTypeNormal, "RolloutRestarted",
+			fmt.Sprintf("restarted %s %s/%s, whose pods were injected with an outdated spec of the instrumentation", w.Kind, w.Namespace, w.Name))
+		rollingOut++
+	}
+	return pending, nil
+}
+
+// getWorkload returns the Deployment, StatefulSet or DaemonSet, or nil for the other kinds of workloads and the
+// workloads which don't exist anymore.
+func (r *Rollout) getWorkload(ctx context.Context, owner instrumentation.Workload) (*workload, error) {
+	w := &workload{Workload: owner}
+	switch owner.Kind {
+	case "Deployment":
+		deployment := &appsv1.Deployment{}
+		w.object, w.template = deployment, &deployment.Spec.Template
+	case "StatefulSet":
+		statefulSet := &appsv1.StatefulSet{}
+		w.object, w.template = statefulSet, &statefulSet.Spec.Template
+	case "DaemonSet":
+		daemonSet := &appsv1.DaemonSet{}
+		w.object, w.template = daemonSet, &daemonSet.Spec.Template
+	default:
+		return nil, nil
+	}
+	if err := r.Client.Get(ctx, types.NamespacedName{Namespace: owner.Namespace, Name: owner.Name}, w.object); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get the %s %s/%s: %w", owner.Kind, owner.Namespace, owner.Name, err)
+	}
+	w.rolledOut = rolledOut(w.object)
+	w.notRestartable = notRestartable(w.object)
+	return w, nil
+}
+
+// notRestartable returns why restarting the workload doesn't replace its pods, if it doesn't.
+func notRestartable(obj client.Object) string {
+	switch o := obj.(type) {
+	case *appsv1.Deployment:
+		if o.Spec.Paused {
+			return "the Deployment is paused"
+		}
+	case *appsv1.StatefulSet:
+		if o.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType {
+			return "its pods are only replaced when deleted, with the OnDelete update strategy"
+		}
+	case *appsv1.DaemonSet:
+		if o.Spec.UpdateStrategy.Type == appsv1.OnDeleteDaemonSetStrategyType {
+			return "its pods are only replaced when deleted, with the OnDelete update strategy"
+		}
+	}
+	return ""
+}
+
+// rolledOut returns whether all the pods of the workload were updated and are available.
+func rolledOut(obj client.Object) bool {
+	if obj.GetGeneration() > observedGeneration(obj) {
+		return false
+	}
+	switch o := obj.(type) {
+	case *appsv1.Deployment:
+		replicas := int32(1)
+		if o.Spec.Replicas != nil {
+			replicas = *o.Spec.Replicas
+		}
+		return o.Status.UpdatedReplicas >= replicas && o.Status.Replicas == o.Status.UpdatedReplicas &&
+			o.Status.AvailableReplicas >= o.Status.UpdatedReplicas
+	case *appsv1.StatefulSet:
+		replicas := int32(1)
+		if o.Spec.Replicas != nil {
+			replicas = *o.Spec.Replicas
+		}
+		return o.Status.UpdatedReplicas >= replicas && o.Status.ReadyReplicas >= replicas &&
+			o.Status.CurrentRevision == o.Status.UpdateRevision
+	case *appsv1.DaemonSet:
+		return o.Status.UpdatedNumberScheduled >= o.Status.DesiredNumberScheduled &&
+			o.Status.NumberAvailable >= o.Status.DesiredNumberScheduled
+	}
+	return true
+}
+
+func observedGeneration(obj client.Object) int64 {
+	switch o := obj.(type) {
+	case *appsv1.Deployment:
+		return o.Status.ObservedGeneration
+	case *appsv1.StatefulSet:
+		return o.Status.ObservedGeneration
+	case *appsv1.DaemonSet:
+		return o.Status.ObservedGeneration
+	}
+	return obj.GetGeneration()
+}
+
+// disruptionAllowed returns whether the PodDisruptionBudgets selecting the pods of the workload allow disruptions, or
+// the name of the one which doesn't. It's only checked before the restart: the rolling update of the workload then
+// replaces its pods according to its own update strategy, which the PodDisruptionBudgets don't limit.
+func (r *Rollout) disruptionAllowed(ctx context.Context, w *workload) (string, bool, error) {
+	pdbs := &policyv1.PodDisruptionBudgetList{}
+	if err := r.Client.List(ctx, pdbs, client.InNamespace(w.Namespace)); err != nil {
+		return "", false, fmt.Errorf("failed to list the PodDisruptionBudgets: %w", err)
+	}
+	for _, pdb := range pdbs.Items {
+		if pdb.Spec.Selector == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil {
+			continue
+		}
+		if selector.Matches(labels.Set(w.template.Labels)) && pdb.Status.DisruptionsAllowed < 1 {
+			return pdb.Name, false, nil
+		}
+	}
+	return "", true, nil
+}
+
+// restart rolls the pods of the workload out, like kubectl rollout restart does.
+func (r *Rollout) restart(ctx context.Context, w *workload, restartedFor string) error {
+	patch := client.MergeFrom(w.object.DeepCopyObject().(client.Object))
+	annotations := w.object.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[RestartedForAnnotation] = restartedFor
+	w.object.SetAnnotations(annotations)
+	if w.template.Annotations == nil {
+		w.template.Annotations = map[string]string{}
+	}
+	w.template.Annotations[RestartedAtAnnotation] = time.Now().Format(time.RFC3339)
+	if err := r.Client.Patch(ctx, w.object, patch); err != nil {
+		return fmt.Errorf("failed to restart the %s %s/%s: %w", w.Kind, w.Namespace, w.Name, err)
+	}
+	r.Logger.Info("restarted workload", "kind", w.Kind, "namespace", w.Namespace, "name", w.Name, "for", restartedFor)
+	return nil
+}
@@ -0,0 +1,273 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rollout
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/instrumentation"
+)
+
+var testScheme = func() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(v1alpha1.AddToScheme(scheme))
+	return scheme
+}()
+
+func controllerRef(kind, name string) []metav1.OwnerReference {
+	isController := true
+	return []metav1.OwnerReference{{Kind: kind, Name: name, Controller: &isController}}
+}
+
+func injectedPod(name string, owners []metav1.OwnerReference, injections string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       "apps",
+			OwnerReferences: owners,
+			Annotations:     map[string]string{instrumentation.InjectionStatusAnnotation: injections},
+		},
+	}
+}
+
+func podLabels(app string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{Labels: map[string]string{"app": app}}
+}
+
+var (
+	injectedSpec = v1alpha1.InstrumentationSpec{Exporter: v1alpha1.Exporter{Endpoint: "http://collector:4317"}}
+	updatedSpec  = v1alpha1.InstrumentationSpec{Exporter: v1alpha1.Exporter{Endpoint: "http://otel-collector:4317"}}
+)
+
+// injection returns the injection status of a pod whose container was injected with the instrumentation of the spec.
+func injection(spec v1alpha1.InstrumentationSpec, container string) string {
+	if spec.Exporter.Endpoint == "" {
+		return fmt.Sprintf(`[{"instrumentation":"observability/java","generation":1,"language":"java","image":"otel/java:2.1","containers":[%q]}]`, container)
+	}
+	return fmt.Sprintf(`[{"instrumentation":"observability/java","generation":1,"specHash":%q,"language":"java","image":"otel/java:2.1","containers":[%q]}]`,
+		instrumentation.SpecHash(v1alpha1.Instrumentation{Spec: spec}), container)
+}
+
+// workloads returns a Deployment and a StatefulSet, both rolled out, whose pods were injected with the instrumentation
+// of the spec.
+func workloads(spec v1alpha1.InstrumentationSpec) []client.Object {
+	one := int32(1)
+	return []client.Object{
+		&appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "checkout-5d4f8", Namespace: "apps", OwnerReferences: controllerRef("Deployment", "checkout")},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "checkout", Namespace: "apps"},
+			Spec:       appsv1.DeploymentSpec{Replicas: &one, Template: corev1.PodTemplateSpec{ObjectMeta: podLabels("checkout")}},
+			Status:     appsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1, AvailableReplicas: 1},
+		},
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "ledger", Namespace: "apps"},
+			Spec:       appsv1.StatefulSetSpec{Replicas: &one, Template: corev1.PodTemplateSpec{ObjectMeta: podLabels("ledger")}},
+			Status:     appsv1.StatefulSetStatus{Replicas: 1, UpdatedReplicas: 1, ReadyReplicas: 1},
+		},
+		injectedPod("checkout-5d4f8-a", controllerRef("ReplicaSet", "checkout-5d4f8"), injection(spec, "app")),
+		injectedPod("ledger-0", controllerRef("StatefulSet", "ledger"), injection(spec, "ledger")),
+		injectedPod("debug", nil, injection(spec, "debug")),
+	}
+}
+
+func newInstrumentation(spec v1alpha1.InstrumentationSpec, rollout v1alpha1.InstrumentationRollout) *v1alpha1.Instrumentation {
+	spec.Rollout = rollout
+	return &v1alpha1.Instrumentation{
+		ObjectMeta: metav1.ObjectMeta{Name: "java", Namespace: "observability"},
+		Spec:       spec,
+	}
+}
+
+func newRollout(adminNamespaces []string, objects ...client.Object) (*Rollout, client.Client, *record.FakeRecorder) {
+	cli := fake.NewClientBuilder().
+		WithScheme(testScheme).
+		WithObjects(objects...).
+		WithIndex(instrumentation.PodMetadata(), instrumentation.InjectedPodsIndex, instrumentation.InjectedPodsIndexValues).
+		Build()
+	recorder := record.NewFakeRecorder(10)
+	return NewRollout(cli, logr.Discard(), recorder, adminNamespaces), cli, recorder
+}
+
+func restartedFor(t *testing.T, cli client.Client, obj client.Object) string {
+	require.NoError(t, cli.Get(context.Background(), client.ObjectKeyFromObject(obj), obj))
+	return obj.GetAnnotations()[RestartedForAnnotation]
+}
+
+func TestRestart(t *testing.T) {
+	checkout := types.NamespacedName{Namespace: "apps", Name: "checkout"}
+	ledger := types.NamespacedName{Namespace: "apps", Name: "ledger"}
+	admin := []string{"observability"}
+	updatedFor := "observability/java@" + instrumentation.SpecHash(v1alpha1.Instrumentation{Spec: updatedSpec})
+
+	t.Run("disabled", func(t *testing.T) {
+		r, cli, _ := newRollout(admin, workloads(injectedSpec)...)
+		pending, err := r.Restart(context.Background(), newInstrumentation(updatedSpec, v1alpha1.InstrumentationRollout{}))
+		require.NoError(t, err)
+		assert.False(t, pending)
+		assert.Empty(t, restartedFor(t, cli, &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: checkout.Name, Namespace: checkout.Namespace}}))
+	})
+
+	t.Run("paused", func(t *testing.T) {
+		r, cli, _ := newRollout(admin, workloads(injectedSpec)...)
+		inst := newInstrumentation(updatedSpec, v1alpha1.InstrumentationRollout{Restart: true, MaxConcurrent: 2})
+		inst.Annotations = map[string]string{PausedAnnotation: "true"}
+		pending, err := r.Restart(context.Background(), inst)
+		require.NoError(t, err)
+		assert.False(t, pending)
+		assert.Empty(t, restartedFor(t, cli, &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: checkout.Name, Namespace: checkout.Namespace}}))
+	})
+
+	t.Run("up to date", func(t *testing.T) {
+		r, cli, recorder := newRollout(admin, workloads(injectedSpec)...)
+		pending, err := r.Restart(context.Background(), newInstrumentation(injectedSpec, v1alpha1.InstrumentationRollout{Restart: true, MaxConcurrent: 2}))
+		require.NoError(t, err)
+		assert.False(t, pending)
+		assert.Empty(t, restartedFor(t, cli, &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: checkout.Name, Namespace: checkout.Namespace}}))
+		assert.Empty(t, recorder.Events)
+	})
+
+	t.Run("rollout changed", func(t *testing.T) {
+		r, cli, recorder := newRollout(admin, workloads(injectedSpec)...)
+		inst := newInstrumentation(injectedSpec, v1alpha1.InstrumentationRollout{Restart: true, MaxConcurrent: 5})
+		inst.Generation = 7
+		pending, err := r.Restart(context.Background(), inst)
+		require.NoError(t, err)
+		assert.False(t, pending)
+		assert.Empty(t, restartedFor(t, cli, &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: checkout.Name, Namespace: checkout.Namespace}}))
+		assert.Empty(t, recorder.Events)
+	})
+
+	t.Run("unknown spec", func(t *testing.T) {
+		r, cli, recorder := newRollout(admin, workloads(v1alpha1.InstrumentationSpec{})...)
+		pending, err := r.Restart(context.Background(), newInstrumentation(updatedSpec, v1alpha1.InstrumentationRollout{Restart: true, MaxConcurrent: 2}))
+		require.NoError(t, err)
+		assert.False(t, pending)
+		assert.Empty(t, restartedFor(t, cli, &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: checkout.Name, Namespace: checkout.Namespace}}))
+		assert.Empty(t, recorder.Events)
+	})
+
+	t.Run("other namespace", func(t *testing.T) {
+		r, cli, recorder := newRollout(nil, workloads(injectedSpec)...)
+		pending, err := r.Restart(context.Background(), newInstrumentation(updatedSpec, v1alpha1.InstrumentationRollout{Restart: true, MaxConcurrent: 2}))
+		require.NoError(t, err)
+		assert.False(t, pending)
+		assert.Empty(t, restartedFor(t, cli, &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: checkout.Name, Namespace: checkout.Namespace}}))
+		assert.Empty(t, restartedFor(t, cli, &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: ledger.Name, Namespace: ledger.Namespace}}))
+		assert.Empty(t, recorder.Events)
+	})
+
+	t.Run("max concurrent unset", func(t *testing.T) {
+		r, cli, _ := newRollout(admin, workloads(injectedSpec)...)
+		pending, err := r.Restart(context.Background(), newInstrumentation(updatedSpec, v1alpha1.InstrumentationRollout{Restart: true}))
+		require.NoError(t, err)
+		assert.True(t, pending)
+		assert.Equal(t, updatedFor, restartedFor(t, cli, &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: checkout.Name, Namespace: checkout.Namespace}}))
+		assert.Empty(t, restartedFor(t, cli, &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: ledger.Name, Namespace: ledger.Namespace}}))
+	})
+
+	t.Run("max concurrent", func(t *testing.T) {
+		r, cli, recorder := newRollout(admin, workloads(injectedSpec)...)
+		inst := newInstrumentation(updatedSpec, v1alpha1.InstrumentationRollout{Restart: true, MaxConcurrent: 1})
+
+		pending, err := r.Restart(context.Background(), inst)
+		require.NoError(t, err)
+		assert.True(t, pending)
+		deployment := &appsv1.Deployment{}
+		require.NoError(t, cli.Get(context.Background(), checkout, deployment))
+		assert.Equal(t, updatedFor, deployment.Annotations[RestartedForAnnotation])
+		assert.NotEmpty(t, deployment.Spec.Template.Annotations[RestartedAtAnnotation])
+		assert.Empty(t, restartedFor(t, cli, &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: ledger.Name, Namespace: ledger.Namespace}}))
+		assert.Equal(t, "Normal RolloutRestarted restarted Deployment apps/checkout, whose pods were injected with an outdated spec of the instrumentation", <-recorder.Events)
+
+		// the deployment is rolling out, the statefulset waits for it
+		deployment.Generation = 2
+		require.NoError(t, cli.Update(context.Background(), deployment))
+		deployment.Status.ObservedGeneration = 1
+		require.NoError(t, cli.Status().Update(context.Background(), deployment))
+		pending, err = r.Restart(context.Background(), inst)
+		require.NoError(t, err)
+		assert.True(t, pending)
+		assert.Empty(t, restartedFor(t, cli, &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: ledger.Name, Namespace: ledger.Namespace}}))
+
+		// the deployment, restarted for an earlier spec, still counts against the concurrency after another change
+		changed := newInstrumentation(v1alpha1.InstrumentationSpec{Exporter: v1alpha1.Exporter{Endpoint: "http://otlp:4317"}},
+			v1alpha1.InstrumentationRollout{Restart: true, MaxConcurrent: 1})
+		pending, err = r.Restart(context.Background(), changed)
+		require.NoError(t, err)
+		assert.True(t, pending)
+		assert.Equal(t, updatedFor, restartedFor(t, cli, &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: checkout.Name, Namespace: checkout.Namespace}}))
+		assert.Empty(t, restartedFor(t, cli, &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: ledger.Name, Namespace: ledger.Namespace}}))
+
+		// the deployment rolled out
+		deployment.Status.ObservedGeneration = deployment.Generation
+		require.NoError(t, cli.Status().Update(context.Background(), deployment))
+		pending, err = r.Restart(context.Background(), inst)
+		require.NoError(t, err)
+		assert.True(t, pending)
+		assert.Equal(t, updatedFor, restartedFor(t, cli, &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: ledger.Name, Namespace: ledger.Namespace}}))
+		assert.Equal(t, "Normal RolloutRestarted restarted StatefulSet apps/ledger, whose pods were injected with an outdated spec of the instrumentation", <-recorder.Events)
+	})
+
+	t.Run("pod disruption budget", func(t *testing.T) {
+		pdb := &policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{Name: "checkout", Namespace: "apps"},
+			Spec:       policyv1.PodDisruptionBudgetSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "checkout"}}},
+			Status:     policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: 0},
+		}
+		r, cli, recorder := newRollout(admin, append(workloads(injectedSpec), pdb)...)
+		pending, err := r.Restart(context.Background(), newInstrumentation(updatedSpec, v1alpha1.InstrumentationRollout{Restart: true, MaxConcurrent: 1}))
+		require.NoError(t, err)
+		assert.True(t, pending)
+		assert.Empty(t, restartedFor(t, cli, &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: checkout.Name, Namespace: checkout.Namespace}}))
+		assert.Equal(t, updatedFor, restartedFor(t, cli, &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: ledger.Name, Namespace: ledger.Namespace}}))
+		assert.Equal(t, "Warning RolloutDeferred the restart of Deployment apps/checkout waits for the PodDisruptionBudget checkout to allow disruptions", <-recorder.Events)
+		assert.Equal(t, "Normal RolloutRestarted restarted StatefulSet apps/ledger, whose pods were injected with an outdated spec of the instrumentation", <-recorder.Events)
+	})
+
+	t.Run("not restartable", func(t *testing.T) {
+		objects := workloads(injectedSpec)
+		objects[1].(*appsv1.Deployment).Spec.Paused = true
+		objects[2].(*appsv1.StatefulSet).Spec.UpdateStrategy.Type = appsv1.OnDeleteStatefulSetStrategyType
+		r, cli, recorder := newRollout(admin, objects...)
+		pending, err := r.Restart(context.Background(), newInstrumentation(updatedSpec, v1alpha1.InstrumentationRollout{Restart: true, MaxConcurrent: 1}))
+		require.NoError(t, err)
+		assert.False(t, pending)
+		assert.Empty(t, restartedFor(t, cli, &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: checkout.Name, Namespace: checkout.Namespace}}))
+		assert.Empty(t, restartedFor(t, cli, &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: ledger.Name, Namespace: ledger.Namespace}}))
+		assert.Equal(t, "Warning RolloutSkipped the restart of Deployment apps/checkout is skipped, the Deployment is paused", <-recorder.Events)
+		assert.Equal(t, "Warning RolloutSkipped the restart of StatefulSet apps/ledger is skipped, its pods are only replaced when deleted, with the OnDelete update strategy", <-recorder.Events)
+	})
+}
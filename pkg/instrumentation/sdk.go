@@ -57,24 +57,29 @@ func (i *sdkInjector) inject(ctx context.Context, insts languageInstrumentations
 	if len(pod.Spec.Containers) < 1 {
 		return pod
 	}
+	var injections []Injection
 	if insts.Java.Instrumentation != nil {
 		otelinst := *insts.Java.Instrumentation
 		var err error
 		i.logger.V(1).Info("injecting Java instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
 
 		javaContainers := insts.Java.Containers
+		injection := newInjection(otelinst, v1alpha1.InstrumentationLanguageJava)
 
 		for _, container := range strings.Split(javaContainers, ",") {
 			index := getContainerIndex(container, pod)
 			pod, err = injectJavaagent(otelinst.Spec.Java, pod, index)
 			if err != nil {
 				i.logger.Info("Skipping javaagent injection", "reason", err.Error(), "container", pod.Spec.Containers[index].Name)
+				injection.Error = fmt.Sprintf("container %s: %s", pod.Spec.Containers[index].Name, err)
 			} else {
 				pod = i.injectCommonEnvVar(otelinst, pod, index)
 				pod = i.injectCommonSDKConfig(ctx, otelinst, ns, pod, index, index)
 				pod = i.setInitContainerSecurityContext(pod, pod.Spec.Containers[index].SecurityContext, javaInitContainerName)
+				injection.Containers = append(injection.Containers, pod.Spec.Containers[index].Name)
 			}
 		}
+		injections = append(injections, injection)
 	}
 	if insts.NodeJS.Instrumentation != nil {
 		otelinst := *insts.NodeJS.Instrumentation
@@ -82,18 +87,22 @@ func (i *sdkInjector) inject(ctx context.Context, insts languageInstrumentations
 		i.logger.V(1).Info("injecting NodeJS instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
 
 		nodejsContainers := insts.NodeJS.Containers
+		injection := newInjection(otelinst, v1alpha1.InstrumentationLanguageNodeJS)
 
 		for _, container := range strings.Split(nodejsContainers, ",") {
 			index := getContainerIndex(container, pod)
 			pod, err = injectNodeJSSDK(otelinst.Spec.NodeJS, pod, index)
 			if err != nil {
 				i.logger.Info("Skipping NodeJS SDK injection", "reason", err.Error(), "container", pod.Spec.Containers[index].Name)
+				injection.Error = fmt.Sprintf("container %s: %s", pod.Spec.Containers[index].Name, err)
 			} else {
 				pod = i.injectCommonEnvVar(otelinst, pod, index)
 				pod = i.injectCommonSDKConfig(ctx, otelinst, ns, pod, index, index)
 				pod = i.setInitContainerSecurityContext(pod, pod.Spec.Containers[index].SecurityContext, nodejsInitContainerName)
+				injection.Containers = append(injection.Containers, pod.Spec.Containers[index].Name)
 			}
 		}
+		injections = append(injections, injection)
 	}
 	if insts.Python.Instrumentation != nil {
 		otelinst := *insts.Python.Instrumentation
@@ -101,18 +110,22 @@ func (i *sdkInjector) inject(ctx context.Context, insts languageInstrumentations
 		i.logger.V(1).Info("injecting Python instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
 
 		pythonContainers := insts.Python.Containers
+		injection := newInjection(otelinst, v1alpha1.InstrumentationLanguagePython)
 
 		for _, container := range strings.Split(pythonContainers, ",") {
 			index := getContainerIndex(container, pod)
-			pod, err = injectPythonSDK(otelinst.Spec.Python, pod, index)
+			pod, err = injectPythonSDK(otelinst.Spec.Python, otelinst.Spec.Exporter.Protocol, pod, index)
 			if err != nil {
 				i.logger.Info("Skipping Python SDK injection", "reason", err.Error(), "container", pod.Spec.Containers[index].Name)
+				injection.Error = fmt.Sprintf("container %s: %s", pod.Spec.Containers[index].Name, err)
 			} else {
 				pod = i.injectCommonEnvVar(otelinst, pod, index)
 				pod = i.injectCommonSDKConfig(ctx, otelinst, ns, pod, index, index)
 				pod = i.setInitContainerSecurityContext(pod, pod.Spec.Containers[index].SecurityContext, pythonInitContainerName)
+				injection.Containers = append(injection.Containers, pod.Spec.Containers[index].Name)
 			}
 		}
+		injections = append(injections, injection)
 	}
 	if insts.DotNet.Instrumentation != nil {
 		otelinst := *insts.DotNet.Instrumentation
@@ -120,18 +133,22 @@ func (i *sdkInjector) inject(ctx context.Context, insts languageInstrumentations
 		i.logger.V(1).Info("injecting DotNet instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
 
 		dotnetContainers := insts.DotNet.Containers
+		injection := newInjection(otelinst, v1alpha1.InstrumentationLanguageDotNet)
 
 		for _, container := range strings.Split(dotnetContainers, ",") {
 			index := getContainerIndex(container, pod)
 			pod, err = injectDotNetSDK(otelinst.Spec.DotNet, pod, index, insts.DotNet.AdditionalAnnotations[annotationDotNetRuntime])
 			if err != nil {
 				i.logger.Info("Skipping DotNet SDK injection", "reason", err.Error(), "container", pod.Spec.Containers[index].Name)
+				injection.Error = fmt.Sprintf("container %s: %s", pod.Spec.Containers[index].Name, err)
 			} else {
 				pod = i.injectCommonEnvVar(otelinst, pod, index)
 				pod = i.injectCommonSDKConfig(ctx, otelinst, ns, pod, index, index)
 				pod = i.setInitContainerSecurityContext(pod, pod.Spec.Containers[index].SecurityContext, dotnetInitContainerName)
+				injection.Containers = append(injection.Containers, pod.Spec.Containers[index].Name)
 			}
 		}
+		injections = append(injections, injection)
 	}
 	if insts.Go.Instrumentation != nil {
 		origPod := pod
@@ -140,12 +157,14 @@ func (i *sdkInjector) inject(ctx context.Context, insts languageInstrumentations
 		i.logger.V(1).Info("injecting Go instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
 
 		goContainers := insts.Go.Containers
+		injection := newInjection(otelinst, v1alpha1.InstrumentationLanguageGo)
 
 		// Go instrumentation supports only single container instrumentation.
 		index := getContainerIndex(goContainers, pod)
 		pod, err = injectGoSDK(otelinst.Spec.Go, pod, cfg)
 		if err != nil {
 			i.logger.Info("Skipping Go SDK injection", "reason", err.Error(), "container", pod.Spec.Containers[index].Name)
+			injection.Error = fmt.Sprintf("container %s: %s", pod.Spec.Containers[index].Name, err)
 		} else {
 			// Common env vars and config need to be applied to the agent contain.
 			pod = i.injectCommonEnvVar(otelinst, pod, len(pod.Spec.Containers)-1)
@@ -156,14 +175,19 @@ func (i *sdkInjector) inject(ctx context.Context, insts languageInstrumentations
 			if idx == -1 {
 				i.logger.Info("Skipping Go SDK injection", "reason", "OTEL_GO_AUTO_TARGET_EXE not set", "container", pod.Spec.Containers[index].Name)
 				pod = origPod
+				injection.Error = fmt.Sprintf("container %s: OTEL_GO_AUTO_TARGET_EXE not set", pod.Spec.Containers[index].Name)
+			} else {
+				injection.Containers = append(injection.Containers, pod.Spec.Containers[index].Name)
 			}
 		}
+		injections = append(injections, injection)
 	}
 	if insts.ApacheHttpd.Instrumentation != nil {
 		otelinst := *insts.ApacheHttpd.Instrumentation
 		i.logger.V(1).Info("injecting Apache Httpd instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
 
 		apacheHttpdContainers := insts.ApacheHttpd.Containers
+		injection := newInjection(otelinst, v1alpha1.InstrumentationLanguageApacheHttpd)
 
 		for _, container := range strings.Split(apacheHttpdContainers, ",") {
 			index := getContainerIndex(container, pod)
@@ -174,7 +198,9 @@ func (i *sdkInjector) inject(ctx context.Context, insts languageInstrumentations
 			pod = i.injectCommonSDKConfig(ctx, otelinst, ns, pod, index, index)
 			pod = i.setInitContainerSecurityContext(pod, pod.Spec.Containers[index].SecurityContext, apacheAgentInitContainerName)
 			pod = i.setInitContainerSecurityContext(pod, pod.Spec.Containers[index].SecurityContext, apacheAgentCloneContainerName)
+			injection.Containers = append(injection.Containers, pod.Spec.Containers[index].Name)
 		}
+		injections = append(injections, injection)
 	}
 
 	if insts.Nginx.Instrumentation != nil {
@@ -182,6 +208,7 @@ func (i *sdkInjector) inject(ctx context.Context, insts languageInstrumentations
 		i.logger.V(1).Info("injecting Nginx instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
 
 		nginxContainers := insts.Nginx.Containers
+		injection := newInjection(otelinst, v1alpha1.InstrumentationLanguageNginx)
 
 		for _, container := range strings.Split(nginxContainers, ",") {
 			index := getContainerIndex(container, pod)
@@ -190,7 +217,9 @@ func (i *sdkInjector) inject(ctx context.Context, insts languageInstrumentations
 			pod = injectNginxSDK(i.logger, otelinst.Spec.Nginx, pod, index, otelinst.Spec.Endpoint, i.createResourceMap(ctx, otelinst, ns, pod, index))
 			pod = i.injectCommonEnvVar(otelinst, pod, index)
 			pod = i.injectCommonSDKConfig(ctx, otelinst, ns, pod, index, index)
+			injection.Containers = append(injection.Containers, pod.Spec.Containers[index].Name)
 		}
+		injections = append(injections, injection)
 	}
 
 	if insts.Sdk.Instrumentation != nil {
@@ -198,15 +227,18 @@ func (i *sdkInjector) inject(ctx context.Context, insts languageInstrumentations
 		i.logger.V(1).Info("injecting sdk-only instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
 
 		sdkContainers := insts.Sdk.Containers
+		injection := newInjection(otelinst, v1alpha1.InstrumentationLanguageSdk)
 
 		for _, container := range strings.Split(sdkContainers, ",") {
 			index := getContainerIndex(container, pod)
 			pod = i.injectCommonEnvVar(otelinst, pod, index)
 			pod = i.injectCommonSDKConfig(ctx, otelinst, ns, pod, index, index)
+			injection.Containers = append(injection.Containers, pod.Spec.Containers[index].Name)
 		}
+		injections = append(injections, injection)
 	}
 
-	return pod
+	return recordInjections(pod, injections...)
 }
 
 func (i *sdkInjector) setInitContainerSecurityContext(pod corev1.Pod, securityContext *corev1.SecurityContext, instrInitContainerName string) corev1.Pod {
@@ -294,6 +326,8 @@ func (i *sdkInjector) injectCommonSDKConfig(ctx context.Context, otelinst v1alph
 			})
 		}
 	}
+	pod = injectExporterConfig(otelinst.Spec.Exporter, pod, agentIndex)
+	container = &pod.Spec.Containers[agentIndex]
 
 	// Some attributes might be empty, we should get them via k8s downward API
 	if resourceMap[string(semconv.K8SPodNameKey)] == "" {
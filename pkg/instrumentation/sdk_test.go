@@ -531,6 +531,11 @@ func TestInjectJava(t *testing.T) {
 			},
 		}, config)
 	assert.Equal(t, corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				InjectionStatusAnnotation: `[{"instrumentation":"/","specHash":"d831c09fd57b7ed5","language":"java","image":"img:1","containers":["app"]}]`,
+			},
+		},
 		Spec: corev1.PodSpec{
 			Volumes: []corev1.Volume{
 				{
@@ -652,6 +657,11 @@ func TestInjectNodeJS(t *testing.T) {
 			},
 		}, config)
 	assert.Equal(t, corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				InjectionStatusAnnotation: `[{"instrumentation":"/","specHash":"3fe019bbf1b75ce2","language":"nodejs","image":"img:1","containers":["app"]}]`,
+			},
+		},
 		Spec: corev1.PodSpec{
 			Volumes: []corev1.Volume{
 				{
@@ -773,6 +783,11 @@ func TestInjectPython(t *testing.T) {
 			},
 		}, config)
 	assert.Equal(t, corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				InjectionStatusAnnotation: `[{"instrumentation":"/","specHash":"ec9656130b0bc69e","language":"python","image":"img:1","containers":["app"]}]`,
+			},
+		},
 		Spec: corev1.PodSpec{
 			Volumes: []corev1.Volume{
 				{
@@ -908,6 +923,11 @@ func TestInjectDotNet(t *testing.T) {
 			},
 		}, config)
 	assert.Equal(t, corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				InjectionStatusAnnotation: `[{"instrumentation":"/","specHash":"24075b013d3c7c8e","language":"dotnet","image":"img:1","containers":["app"]}]`,
+			},
+		},
 		Spec: corev1.PodSpec{
 			Volumes: []corev1.Volume{
 				{
@@ -1055,6 +1075,11 @@ func TestInjectGo(t *testing.T) {
 				},
 			},
 			expected: corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						InjectionStatusAnnotation: `[{"instrumentation":"/","specHash":"0ca3412161aa126e","language":"go","image":"otel/go:1","error":"container app: shared process namespace has been explicitly disabled"}]`,
+					},
+				},
 				Spec: corev1.PodSpec{
 					ShareProcessNamespace: &falsee,
 					Containers: []corev1.Container{
@@ -1087,6 +1112,11 @@ func TestInjectGo(t *testing.T) {
 				},
 			},
 			expected: corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						InjectionStatusAnnotation: `[{"instrumentation":"/","specHash":"0ca3412161aa126e","language":"go","image":"otel/go:1","error":"container app: OTEL_GO_AUTO_TARGET_EXE not set"}]`,
+					},
+				},
 				Spec: corev1.PodSpec{
 					Containers: []corev1.Container{
 						{
@@ -1125,6 +1155,11 @@ func TestInjectGo(t *testing.T) {
 				},
 			},
 			expected: corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						InjectionStatusAnnotation: `[{"instrumentation":"/","specHash":"2916ab0cca32f56a","language":"go","image":"otel/go:1","containers":["app"]}]`,
+					},
+				},
 				Spec: corev1.PodSpec{
 					ShareProcessNamespace: &true,
 					Containers: []corev1.Container{
@@ -1240,6 +1275,7 @@ func TestInjectGo(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{
 					Annotations: map[string]string{
 						"instrumentation.opentelemetry.io/otel-go-auto-target-exe": "foo",
+						InjectionStatusAnnotation:                                  `[{"instrumentation":"/","specHash":"0ca3412161aa126e","language":"go","image":"otel/go:1","containers":["app"]}]`,
 					},
 				},
 				Spec: corev1.PodSpec{
@@ -1373,6 +1409,11 @@ func TestInjectApacheHttpd(t *testing.T) {
 				},
 			},
 			expected: corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						InjectionStatusAnnotation: `[{"instrumentation":"/","specHash":"fd0d70e3db374662","language":"apache-httpd","image":"img:1","containers":["app"]}]`,
+					},
+				},
 				Spec: corev1.PodSpec{
 					Volumes: []corev1.Volume{
 						{
@@ -1555,6 +1596,9 @@ func TestInjectNginx(t *testing.T) {
 			},
 			expected: corev1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						InjectionStatusAnnotation: `[{"instrumentation":"/","specHash":"698974e806a24918","language":"nginx","image":"img:1","containers":["app"]}]`,
+					},
 					Name: "my-nginx-6c44bcbdd",
 				},
 				Spec: corev1.PodSpec{
@@ -1719,6 +1763,11 @@ func TestInjectSdkOnly(t *testing.T) {
 			},
 		}, config)
 	assert.Equal(t, corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				InjectionStatusAnnotation: `[{"instrumentation":"/","specHash":"5927c22146fe9273","language":"sdk","containers":["app"]}]`,
+			},
+		},
 		Spec: corev1.PodSpec{
 			Containers: []corev1.Container{
 				{
@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"context"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+// selectingInstrumentations returns the instrumentations whose selector selects the pod, by language. When
+// instrumentations of the namespace of the pod and of other namespaces select it for a language, the ones of its
+// namespace take precedence.
+func (pm *instPodMutator) selectingInstrumentations(ctx context.Context, ns corev1.Namespace, pod corev1.Pod) (map[v1alpha1.InstrumentationLanguage][]*v1alpha1.Instrumentation, error) {
+	var otelInsts v1alpha1.InstrumentationList
+	if err := pm.Client.List(ctx, &otelInsts); err != nil {
+		return nil, err
+	}
+
+	selecting := map[v1alpha1.InstrumentationLanguage][]*v1alpha1.Instrumentation{}
+	local := map[v1alpha1.InstrumentationLanguage]bool{}
+	for i := range otelInsts.Items {
+		inst := &otelInsts.Items[i]
+		selected, err := selects(inst, ns, pod, pm.config.InstrumentationAdminNamespaces())
+		if err != nil {
+			return nil, err
+		}
+		if !selected {
+			continue
+		}
+		language := inst.Spec.Selector.Language
+		switch isLocal := inst.Namespace == ns.Name; {
+		case isLocal && !local[language]:
+			selecting[language] = []*v1alpha1.Instrumentation{inst}
+			local[language] = true
+		case isLocal || !local[language]:
+			selecting[language] = append(selecting[language], inst)
+		}
+	}
+	return selecting, nil
+}
+
+// selects returns whether the selector of the instrumentation selects the pod. The namespace selector only selects
+// the pods of other namespaces for the instrumentations of the admin namespaces.
+func selects(inst *v1alpha1.Instrumentation, ns corev1.Namespace, pod corev1.Pod, adminNamespaces []string) (bool, error) {
+	if inst.Spec.Selector == nil {
+		return false, nil
+	}
+	if inst.Namespace != ns.Name && !SelectsOtherNamespaces(inst, adminNamespaces) {
+		return false, nil
+	}
+	if inst.Spec.Selector.NamespaceSelector != nil {
+		if selected, err := labelSelectorMatches(inst.Spec.Selector.NamespaceSelector, ns.Labels); err != nil || !selected {
+			return false, err
+		}
+	}
+	if inst.Spec.Selector.PodSelector == nil {
+		return true, nil
+	}
+	return labelSelectorMatches(inst.Spec.Selector.PodSelector, pod.Labels)
+}
+
+// SelectsOtherNamespaces returns whether the instrumentation may select the pods of other namespaces than its own: it
+// has a namespace selector, and it's in one of the admin namespaces, which include the namespace of the operator.
+// Otherwise, the instrumentations of a namespace could instrument the pods of every namespace.
+func SelectsOtherNamespaces(inst *v1alpha1.Instrumentation, adminNamespaces []string) bool {
+	return inst.Spec.Selector != nil && inst.Spec.Selector.NamespaceSelector != nil && slices.Contains(adminNamespaces, inst.Namespace)
+}
+
+func labelSelectorMatches(selector *metav1.LabelSelector, set map[string]string) (bool, error) {
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return false, err
+	}
+	return s.Matches(labels.Set(set)), nil
+}
+
+// selectedInstrumentation returns the instrumentation selecting the pod for a language, if there's a single one.
+func selectedInstrumentation(selecting []*v1alpha1.Instrumentation) (*v1alpha1.Instrumentation, error) {
+	switch len(selecting) {
+	case 0:
+		return nil, nil
+	case 1:
+		return selecting[0], nil
+	default:
+		return nil, errMultipleInstancesPossible
+	}
+}
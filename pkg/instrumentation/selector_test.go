@@ -0,0 +1,209 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+func selectorInstrumentation(name, namespace string, language v1alpha1.InstrumentationLanguage, podSelector, namespaceSelector *metav1.LabelSelector) *v1alpha1.Instrumentation {
+	return &v1alpha1.Instrumentation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: v1alpha1.InstrumentationSpec{
+			Selector: &v1alpha1.InstrumentationSelector{
+				Language:          language,
+				PodSelector:       podSelector,
+				NamespaceSelector: namespaceSelector,
+			},
+		},
+	}
+}
+
+func TestSelects(t *testing.T) {
+	ns := corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "apps",
+			Labels: map[string]string{"team": "payments"},
+		},
+	}
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{"app": "checkout"},
+		},
+	}
+	for _, tt := range []struct {
+		desc     string
+		inst     *v1alpha1.Instrumentation
+		expected bool
+	}{
+		{
+			desc:     "no-selector",
+			inst:     &v1alpha1.Instrumentation{ObjectMeta: metav1.ObjectMeta{Namespace: "apps"}},
+			expected: false,
+		},
+		{
+			desc:     "empty-selector-same-namespace",
+			inst:     selectorInstrumentation("inst", "apps", v1alpha1.InstrumentationLanguageJava, nil, nil),
+			expected: true,
+		},
+		{
+			desc:     "empty-selector-other-namespace",
+			inst:     selectorInstrumentation("inst", "observability", v1alpha1.InstrumentationLanguageJava, nil, nil),
+			expected: false,
+		},
+		{
+			desc:     "pod-selector-matching",
+			inst:     selectorInstrumentation("inst", "apps", v1alpha1.InstrumentationLanguageJava, &metav1.LabelSelector{MatchLabels: map[string]string{"app": "checkout"}}, nil),
+			expected: true,
+		},
+		{
+			desc:     "pod-selector-not-matching",
+			inst:     selectorInstrumentation("inst", "apps", v1alpha1.InstrumentationLanguageJava, &metav1.LabelSelector{MatchLabels: map[string]string{"app": "cart"}}, nil),
+			expected: false,
+		},
+		{
+			desc:     "namespace-selector-matching",
+			inst:     selectorInstrumentation("inst", "observability", v1alpha1.InstrumentationLanguageJava, nil, &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}}),
+			expected: true,
+		},
+		{
+			desc:     "namespace-selector-not-matching",
+			inst:     selectorInstrumentation("inst", "observability", v1alpha1.InstrumentationLanguageJava, nil, &metav1.LabelSelector{MatchLabels: map[string]string{"team": "search"}}),
+			expected: false,
+		},
+		{
+			desc:     "namespace-selector-same-namespace",
+			inst:     selectorInstrumentation("inst", "apps", v1alpha1.InstrumentationLanguageJava, nil, &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}}),
+			expected: true,
+		},
+		{
+			desc:     "namespace-selector-tenant-namespace",
+			inst:     selectorInstrumentation("inst", "tenant", v1alpha1.InstrumentationLanguageJava, nil, &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}}),
+			expected: false,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			selected, err := selects(tt.inst, ns, pod, []string{"observability"})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, selected)
+		})
+	}
+}
+
+func TestGetInstrumentationInstanceFromSelector(t *testing.T) {
+	ns := corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "apps",
+			Labels: map[string]string{"team": "payments"},
+		},
+	}
+	teams := &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}}
+	local := selectorInstrumentation("local", "apps", v1alpha1.InstrumentationLanguageJava, nil, nil)
+	shared := selectorInstrumentation("shared", "observability", v1alpha1.InstrumentationLanguageJava, nil, teams)
+	annotated := &v1alpha1.Instrumentation{ObjectMeta: metav1.ObjectMeta{Name: "annotated", Namespace: "apps"}}
+	tenant := selectorInstrumentation("tenant", "tenant", v1alpha1.InstrumentationLanguageJava, nil, teams)
+
+	for _, tt := range []struct {
+		desc        string
+		insts       []*v1alpha1.Instrumentation
+		annotations map[string]string
+		expected    string
+		err         error
+	}{
+		{
+			desc:     "selected-from-other-namespace",
+			insts:    []*v1alpha1.Instrumentation{shared},
+			expected: "shared",
+		},
+		{
+			desc:  "tenant-namespace-selects-its-own-only",
+			insts: []*v1alpha1.Instrumentation{tenant},
+		},
+		{
+			desc:     "tenant-namespace-ignored",
+			insts:    []*v1alpha1.Instrumentation{tenant, shared},
+			expected: "shared",
+		},
+		{
+			desc:     "pod-namespace-takes-precedence",
+			insts:    []*v1alpha1.Instrumentation{local, shared},
+			expected: "local",
+		},
+		{
+			desc:  "multiple-selecting",
+			insts: []*v1alpha1.Instrumentation{shared, selectorInstrumentation("other", "platform", v1alpha1.InstrumentationLanguageJava, nil, teams)},
+			err:   errMultipleInstancesPossible,
+		},
+		{
+			desc:        "annotation-takes-precedence",
+			insts:       []*v1alpha1.Instrumentation{local, annotated},
+			annotations: map[string]string{annotationInjectJava: "annotated"},
+			expected:    "annotated",
+		},
+		{
+			desc:        "annotation-opts-out",
+			insts:       []*v1alpha1.Instrumentation{local},
+			annotations: map[string]string{annotationInjectJava: "false"},
+		},
+		{
+			desc:  "other-language",
+			insts: []*v1alpha1.Instrumentation{selectorInstrumentation("python", "apps", v1alpha1.InstrumentationLanguagePython, nil, nil)},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// prepare
+			builder := fake.NewClientBuilder().WithScheme(testScheme)
+			for _, inst := range tt.insts {
+				builder = builder.WithObjects(inst.DeepCopy())
+			}
+			cfg := config.New(config.WithInstrumentationAdminNamespaces([]string{"observability", "platform"}))
+			mutator := NewMutator(logr.Discard(), builder.Build(), record.NewFakeRecorder(10), cfg)
+			pod := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations}}
+
+			// test
+			selecting, err := mutator.selectingInstrumentations(context.Background(), ns, pod)
+			require.NoError(t, err)
+			inst, err := mutator.getInstrumentationInstance(context.Background(), ns, pod, annotationInjectJava, selecting[v1alpha1.InstrumentationLanguageJava])
+
+			// verify
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			if tt.expected == "" {
+				assert.Nil(t, inst)
+				return
+			}
+			require.NotNil(t, inst)
+			assert.Equal(t, tt.expected, inst.Name)
+		})
+	}
+}
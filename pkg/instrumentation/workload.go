@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package instrumentation
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Workload identifies the workload owning pods.
+type Workload struct {
+	Namespace string
+	Kind      string
+	Name      string
+}
+
+// OwningWorkload returns the workload owning the pod, looking through the ReplicaSets of Deployments and the Jobs of
+// CronJobs. A pod without controller is its own workload. Only the metadata of the ReplicaSets and the Jobs is read.
+func OwningWorkload(ctx context.Context, cli client.Client, pod metav1.Object) (Workload, error) {
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil {
+		return Workload{Namespace: pod.GetNamespace(), Kind: "Pod", Name: pod.GetName()}, nil
+	}
+	workload := Workload{Namespace: pod.GetNamespace(), Kind: owner.Kind, Name: owner.Name}
+	parent := &metav1.PartialObjectMetadata{}
+	switch owner.Kind {
+	case "ReplicaSet":
+		parent.SetGroupVersionKind(appsv1.SchemeGroupVersion.WithKind("ReplicaSet"))
+	case "Job":
+		parent.SetGroupVersionKind(batchv1.SchemeGroupVersion.WithKind("Job"))
+	default:
+		return workload, nil
+	}
+	if err := cli.Get(ctx, types.NamespacedName{Namespace: pod.GetNamespace(), Name: owner.Name}, parent); err != nil {
+		if apierrors.IsNotFound(err) {
+			return workload, nil
+		}
+		return workload, fmt.Errorf("failed to get the %s owning the pod %s: %w", owner.Kind, pod.GetName(), err)
+	}
+	if parentOwner := metav1.GetControllerOf(parent); parentOwner != nil {
+		workload.Kind, workload.Name = parentOwner.Kind, parentOwner.Name
+	}
+	return workload, nil
+}
@@ -28,10 +28,16 @@ import (
 )
 
 const (
-	injectedLabel = "sidecar.opentelemetry.io/injected"
+	// InjectedLabel is set on the pods a collector sidecar was injected into, see InjectedLabelValue.
+	InjectedLabel = "sidecar.opentelemetry.io/injected"
 	confEnvVar    = "OTEL_CONFIG"
 )
 
+// InjectedLabelValue returns the value of InjectedLabel on the pods the given collector was injected into.
+func InjectedLabelValue(otelcol v1beta1.OpenTelemetryCollector) string {
+	return naming.Truncate("%s.%s", 63, otelcol.Namespace, otelcol.Name)
+}
+
 // add a new sidecar container to the given pod, based on the given OpenTelemetryCollector.
 func add(cfg config.Config, logger logr.Logger, otelcol v1beta1.OpenTelemetryCollector, pod corev1.Pod, attributes []corev1.EnvVar) (corev1.Pod, error) {
 	otelColCfg, err := collector.ReplaceConfig(otelcol)
@@ -49,11 +55,12 @@ func add(cfg config.Config, logger logr.Logger, otelcol v1beta1.OpenTelemetryCol
 	pod.Spec.InitContainers = append(pod.Spec.InitContainers, otelcol.Spec.InitContainers...)
 	pod.Spec.Containers = append(pod.Spec.Containers, container)
 	pod.Spec.Volumes = append(pod.Spec.Volumes, otelcol.Spec.Volumes...)
+	pod.Spec.Volumes = append(pod.Spec.Volumes, collector.ConfigReferenceVolumes(otelcol.Spec.ConfigReferences)...)
 
 	if pod.Labels == nil {
 		pod.Labels = map[string]string{}
 	}
-	pod.Labels[injectedLabel] = naming.Truncate("%s.%s", 63, otelcol.Namespace, otelcol.Name)
+	pod.Labels[InjectedLabel] = InjectedLabelValue(otelcol)
 
 	return pod, nil
 }
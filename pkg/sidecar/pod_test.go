@@ -111,6 +111,7 @@ func TestAddSidecarWhenNoSidecarExists(t *testing.T) {
 				Protocol:      corev1.ProtocolTCP,
 			},
 		},
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 	}, changed.Spec.Containers[1])
 }
 
@@ -237,3 +238,52 @@ func TestAddSidecarWithAditionalEnv(t *testing.T) {
 	assert.Contains(t, changed.Spec.Containers[1].Env, extraEnv)
 
 }
+
+func TestAddSidecarWithConfigReferenceFile(t *testing.T) {
+	// prepare
+	pod := corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "my-app"},
+			},
+		},
+	}
+	otelcol := v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "otelcol-sample",
+			Namespace: "some-app",
+		},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			ConfigReferences: []v1beta1.ConfigReference{{
+				Name: "ca",
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "backend-tls"},
+					Key:                  "ca.crt",
+				},
+				MountAs: v1beta1.ConfigReferenceMountFile,
+			}},
+		},
+	}
+	cfg := config.New(config.WithCollectorImage("some-default-image"))
+
+	// test
+	changed, err := add(cfg, logger, otelcol, pod, nil)
+
+	// verify
+	assert.NoError(t, err)
+	assert.Len(t, changed.Spec.Containers, 2)
+	assert.Contains(t, changed.Spec.Containers[1].VolumeMounts, corev1.VolumeMount{
+		Name:      "ref-ca",
+		MountPath: "/var/refs/ca",
+		ReadOnly:  true,
+	})
+	assert.Contains(t, changed.Spec.Volumes, corev1.Volume{
+		Name: "ref-ca",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: "backend-tls",
+				Items:      []corev1.KeyToPath{{Key: "ca.crt", Path: "ca.crt"}},
+			},
+		},
+	})
+}